@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+
+	"github.com/seniorGolang/tg/v2/pkg/diagnostic"
+	"github.com/seniorGolang/tg/v2/pkg/generator"
+)
+
+const ruleParse = "parse"
+
+// diagnostics of command in machine-readable format, they are written at the end of command.
+var diagnostics struct {
+	sync.Mutex
+	format    string
+	out       string
+	collected diagnostic.List
+}
+
+func setupDiagnostics(c *cli.Context) error {
+
+	diagnostics.format, diagnostics.out = c.String("diagnostics"), c.String("diagnostics-out")
+	switch diagnostics.format {
+	case diagnostic.FormatText, diagnostic.FormatJSON, diagnostic.FormatSARIF:
+		return nil
+	}
+	return fmt.Errorf("unknown format of diagnostics '%s', expected %s, %s or %s", diagnostics.format, diagnostic.FormatText, diagnostic.FormatJSON, diagnostic.FormatSARIF)
+}
+
+func machineDiagnostics() bool {
+	return diagnostics.format == diagnostic.FormatJSON || diagnostics.format == diagnostic.FormatSARIF
+}
+
+// reportDiagnostics logs diagnostics as warnings and errors, they do not stop generation.
+// In machine-readable format they are collected to be written at the end of command.
+func reportDiagnostics(logger logrus.FieldLogger, diags diagnostic.List) {
+
+	if machineDiagnostics() {
+		diagnostics.Lock()
+		diagnostics.collected = append(diagnostics.collected, diags...)
+		diagnostics.Unlock()
+		return
+	}
+	for _, d := range diags {
+		if d.Severity == diagnostic.SeverityError {
+			logger.Error(d.String())
+		} else {
+			logger.Warn(d.String())
+		}
+	}
+}
+
+// reportRender collects problems of rendering of tr. Generator logs them itself, so in text format they are skipped.
+func reportRender(tr *generator.Transport) {
+
+	if machineDiagnostics() {
+		reportDiagnostics(log, tr.Diagnostics())
+	}
+}
+
+// reportParse collects error, which stops parsing of services, as diagnostic.
+func reportParse(err error) {
+
+	if err != nil && machineDiagnostics() {
+		reportDiagnostics(log, diagnostic.FromError(ruleParse, err))
+	}
+}
+
+// flushDiagnostics writes collected diagnostics in machine-readable format to stderr or to '--diagnostics-out' file,
+// stdout is kept for output of commands such as 'tg ir' and 'tg diff'.
+func flushDiagnostics(*cli.Context) (err error) {
+
+	if !machineDiagnostics() {
+		return
+	}
+	diagnostics.Lock()
+	defer diagnostics.Unlock()
+	diags := diagnostics.collected
+	diagnostics.collected = nil
+	diags.Sort()
+	var out io.Writer = os.Stderr
+	if diagnostics.out != "" {
+		var file *os.File
+		if file, err = os.Create(diagnostics.out); err != nil {
+			return
+		}
+		defer func() {
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+		}()
+		out = file
+	}
+	return diags.Write(out, diagnostics.format, Version)
+}
@@ -0,0 +1,243 @@
+package main
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/seniorGolang/tg/v2/pkg/config"
+	"github.com/seniorGolang/tg/v2/pkg/generator"
+	"github.com/seniorGolang/tg/v2/pkg/mod"
+)
+
+type serviceDiff struct {
+	Dir string `json:"dir"`
+	generator.DiffReport
+}
+
+type diffResult struct {
+	Blocked bool          `json:"blocked"`
+	Reports []serviceDiff `json:"reports"`
+}
+
+func cmdDiff(c *cli.Context) (err error) {
+
+	if c.String("base") == "" {
+		return fmt.Errorf("--base is required")
+	}
+	var cwd, root, baseRoot string
+	if cwd, err = os.Getwd(); err != nil {
+		return
+	}
+	if cwd, err = filepath.EvalSymlinks(cwd); err != nil {
+		return
+	}
+	if root, err = repoRoot(cwd); err != nil {
+		return
+	}
+	if info, statErr := os.Stat(c.String("base")); statErr == nil && info.IsDir() {
+		if baseRoot, err = filepath.Abs(c.String("base")); err != nil {
+			return
+		}
+	} else {
+		if baseRoot, err = os.MkdirTemp("", "tg-diff-"); err != nil {
+			return
+		}
+		defer os.RemoveAll(baseRoot)
+		if err = extractRef(root, c.String("base"), baseRoot); err != nil {
+			return
+		}
+	}
+	var services []config.Service
+	if services, _, err = commandServices(c); err != nil {
+		return
+	}
+	var result diffResult
+	for _, svc := range services {
+		var svcDir string
+		if svcDir, err = filepath.Abs(svc.Dir); err != nil {
+			return
+		}
+		var head, base *generator.Contract
+		cfg, _ := projectConfig(c)
+		if head, err = serviceContract(c, cfg, svc); err != nil {
+			return
+		}
+		if base, err = baseContract(c, cfg, svc, root, baseRoot); err != nil {
+			return
+		}
+		report := generator.DiffContracts(base, head)
+		result.Blocked = result.Blocked || report.Blocked()
+		relDir, _ := filepath.Rel(root, svcDir)
+		result.Reports = append(result.Reports, serviceDiff{Dir: filepath.ToSlash(relDir), DiffReport: report})
+	}
+	if c.Bool("json") {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.SetEscapeHTML(false)
+		if err = encoder.Encode(result); err != nil {
+			return
+		}
+	} else {
+		printDiff(result)
+	}
+	if result.Blocked {
+		return fmt.Errorf("breaking changes without major version bump")
+	}
+	return
+}
+
+func printDiff(result diffResult) {
+
+	for _, report := range result.Reports {
+		fmt.Printf("%s (%s -> %s): %d breaking, %d non-breaking, %d docs\n",
+			report.Dir, versionOrNone(report.BaseVersion), versionOrNone(report.HeadVersion), report.Breaking, report.NonBreaking, report.Docs)
+		for _, kind := range []generator.ChangeKind{generator.ChangeBreaking, generator.ChangeNonBreaking, generator.ChangeDocs} {
+			for _, change := range report.Changes {
+				if change.Kind == kind {
+					fmt.Printf("  %-12s %s: %s\n", kind, change.Path, change.Message)
+				}
+			}
+		}
+	}
+}
+
+func versionOrNone(version string) string {
+
+	if version == "" {
+		return "-"
+	}
+	return version
+}
+
+func serviceContract(c *cli.Context, cfg *config.Config, svc config.Service) (contract *generator.Contract, err error) {
+
+	var tr generator.Transport
+	if tr, err = newTransport(c, cfg, svc); err != nil {
+		return
+	}
+	return tr.Contract(), nil
+}
+
+// baseContract builds contract of service from base checkout. Types are resolved from module of services package,
+// so base checkout is used as is. Config of base checkout is used, when it describes the service.
+func baseContract(c *cli.Context, cfg *config.Config, svc config.Service, root, baseRoot string) (contract *generator.Contract, err error) {
+
+	var relDir string
+	if relDir, err = relPath(root, svc.Dir); err != nil {
+		return
+	}
+	baseSvc := svc
+	baseSvc.Dir = filepath.Join(baseRoot, relDir)
+	if _, err = os.Stat(baseSvc.Dir); err != nil {
+		return nil, fmt.Errorf("base: %w", err)
+	}
+	if cfgPath, _ := config.Find(baseSvc.Dir); cfgPath != "" {
+		if baseCfg, cfgErr := config.Load(cfgPath); cfgErr == nil {
+			if cfgSvc := baseCfg.Service(baseSvc.Dir); cfgSvc != nil {
+				cfg, baseSvc = baseCfg, *cfgSvc
+			}
+		}
+	}
+	return serviceContract(c, cfg, baseSvc)
+}
+
+func relPath(root, target string) (rel string, err error) {
+
+	if target, err = filepath.Abs(target); err != nil {
+		return
+	}
+	if resolved, evalErr := filepath.EvalSymlinks(target); evalErr == nil {
+		target = resolved
+	}
+	if rel, err = filepath.Rel(root, target); err != nil {
+		return
+	}
+	if strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("%s is outside of %s", target, root)
+	}
+	return
+}
+
+// repoRoot returns top-level directory of git repository or directory of go.mod, when it is not a repository.
+func repoRoot(dir string) (root string, err error) {
+
+	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
+	cmd.Dir = dir
+	if output, gitErr := cmd.Output(); gitErr == nil {
+		return filepath.EvalSymlinks(strings.TrimSpace(string(output)))
+	}
+	var modPath string
+	if modPath, err = mod.GoModPath(dir); err != nil {
+		return
+	}
+	if modPath == "" || modPath == os.DevNull {
+		return "", fmt.Errorf("go.mod not found for %s", dir)
+	}
+	return filepath.Dir(modPath), nil
+}
+
+// extractRef writes tree of git ref to directory.
+func extractRef(root, ref, dir string) (err error) {
+
+	cmd := exec.Command("git", "archive", "--format=tar", ref) // nolint:gosec
+	cmd.Dir = root
+	var stdout io.ReadCloser
+	if stdout, err = cmd.StdoutPipe(); err != nil {
+		return
+	}
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	if err = cmd.Start(); err != nil {
+		return
+	}
+	reader := tar.NewReader(stdout)
+	for {
+		var header *tar.Header
+		if header, err = reader.Next(); err == io.EOF {
+			break
+		} else if err != nil {
+			_ = cmd.Wait()
+			return fmt.Errorf("git archive %s: %w", ref, err)
+		}
+		target := filepath.Join(dir, filepath.FromSlash(header.Name))
+		if !strings.HasPrefix(target, filepath.Clean(dir)+string(os.PathSeparator)) {
+			continue
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(target, 0777)
+		case tar.TypeReg:
+			err = writeFile(target, reader, header.FileInfo().Mode().Perm())
+		}
+		if err != nil {
+			_ = cmd.Wait()
+			return
+		}
+	}
+	if err = cmd.Wait(); err != nil {
+		return fmt.Errorf("git archive %s: %s", ref, strings.TrimSpace(stderr.String()))
+	}
+	return
+}
+
+func writeFile(filePath string, reader io.Reader, perm os.FileMode) (err error) {
+
+	if err = os.MkdirAll(filepath.Dir(filePath), 0777); err != nil {
+		return
+	}
+	var file *os.File
+	if file, err = os.OpenFile(filePath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm); err != nil {
+		return
+	}
+	defer file.Close()
+	_, err = io.Copy(file, reader) // nolint:gosec
+	return
+}
@@ -0,0 +1,352 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/seniorGolang/tg/v2/pkg/config"
+	"github.com/seniorGolang/tg/v2/pkg/generator"
+)
+
+var (
+	projectCfg       *config.Config
+	projectCfgLoaded bool
+)
+
+type targetFunc func(c *cli.Context, tr *generator.Transport, svc config.Service) error
+
+// projectConfig returns config from '--config' flag or 'tg.yaml' found next to go.mod. Nil, when there is no config.
+func projectConfig(c *cli.Context) (cfg *config.Config, err error) {
+
+	if projectCfgLoaded {
+		return projectCfg, nil
+	}
+	if c.String("config") != "" {
+		cfg, err = config.Load(c.String("config"))
+	} else {
+		cfg, err = config.Discover(".")
+	}
+	if err != nil {
+		return
+	}
+	if cfg != nil {
+		log.WithField("config", cfg.Path()).Debug("use config")
+	}
+	projectCfg, projectCfgLoaded = cfg, true
+	return cfg, nil
+}
+
+// commandServices returns services of command. When '--services' is set or there is no config,
+// the only service is built from command line flags, otherwise services are taken from config.
+func commandServices(c *cli.Context) (services []config.Service, fromConfig bool, err error) {
+
+	var cfg *config.Config
+	if cfg, err = projectConfig(c); err != nil {
+		return
+	}
+	if cfg == nil || c.IsSet("services") {
+		svc := config.Service{Dir: c.String("services")}
+		if cfg != nil {
+			if cfgSvc := cfg.Service(svc.Dir); cfgSvc != nil {
+				svc = *cfgSvc
+			}
+		}
+		services = []config.Service{svc}
+	} else {
+		// --ifaces replaces interfaces of services, config is kept as it is loaded
+		services, fromConfig = append([]config.Service(nil), cfg.Services...), true
+	}
+	if c.IsSet("ifaces") {
+		for i := range services {
+			services[i].Include, services[i].Exclude = nil, nil
+			for _, iface := range c.StringSlice("ifaces") {
+				if strings.HasPrefix(iface, "!") {
+					services[i].Exclude = append(services[i].Exclude, iface)
+					continue
+				}
+				services[i].Include = append(services[i].Include, iface)
+			}
+		}
+	}
+	return
+}
+
+// eachService runs target for services of command. Services from config without the target are skipped.
+func eachService(c *cli.Context, hasTarget func(svc config.Service) bool, target targetFunc) (err error) {
+
+	var fromConfig bool
+	var services []config.Service
+	if services, fromConfig, err = commandServices(c); err != nil {
+		return
+	}
+	cfg, _ := projectConfig(c)
+	var stale int
+	if c.Bool("verify") {
+		target = verifyTarget(target, &stale)
+		defer func() { err = staleError(err, stale) }()
+	}
+	for _, svc := range services {
+		if fromConfig && !hasTarget(svc) {
+			continue
+		}
+		var tr generator.Transport
+		if tr, err = newTransport(c, cfg, svc); err != nil {
+			return
+		}
+		reportDiagnostics(log, tr.Check())
+		err = target(c, &tr, svc)
+		reportRender(&tr)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// newTransport parses services of svc, types are loaded by loader of flag or config. Config may be nil.
+func newTransport(c *cli.Context, cfg *config.Config, svc config.Service) (tr generator.Transport, err error) {
+
+	if tr, err = generator.NewTransportWithDefaults(log, Version, svc.Dir, svc.Tags(), svc.Ifaces()...); err != nil {
+		reportParse(err)
+		return
+	}
+	loader := c.String("loader")
+	if cfg != nil && cfg.Loader != "" && !c.IsSet("loader") {
+		loader = cfg.Loader
+	}
+	if err = tr.SetLoader(loader); err != nil {
+		return
+	}
+	strict := c.Bool("strict")
+	if cfg != nil && cfg.Strict != nil && !c.IsSet("strict") {
+		strict = *cfg.Strict
+	}
+	tr.SetStrict(strict)
+	tr.SetIncremental(!c.Bool("force"))
+	var cfgBackend string
+	if svc.Transport != nil {
+		cfgBackend = svc.Transport.Backend
+	}
+	if err = tr.SetBackend(stringOption(c, "backend", cfgBackend)); err != nil {
+		return
+	}
+	err = tr.SetOverrides(svc.Overrides)
+	return
+}
+
+func cmdGenerate(c *cli.Context) (err error) {
+
+	defer func() {
+		if err == nil {
+			log.Info("done")
+		}
+	}()
+	var cfg *config.Config
+	if cfg, err = projectConfig(c); err != nil {
+		return
+	}
+	if c.Args().Present() {
+		return generatePackages(c, cfg, c.Args().Slice())
+	}
+	if cfg == nil {
+		return fmt.Errorf("%s not found next to go.mod", config.FileName)
+	}
+	if c.IsSet("services") && cfg.Service(c.String("services")) == nil {
+		return fmt.Errorf("service %s is not described in %s", c.String("services"), cfg.Path())
+	}
+	var services []config.Service
+	if services, _, err = commandServices(c); err != nil {
+		return
+	}
+	var stale int
+	if c.Bool("verify") {
+		defer func() { err = staleError(err, stale) }()
+	}
+	for _, svc := range services {
+		var tr generator.Transport
+		if tr, err = newTransport(c, cfg, svc); err != nil {
+			return
+		}
+		reportDiagnostics(log, tr.Check())
+		render := func(_ *cli.Context, tr *generator.Transport, svc config.Service) error {
+			return tr.RenderTargets(svc)
+		}
+		if c.Bool("verify") {
+			render = verifyTarget(render, &stale)
+		}
+		err = render(c, &tr, svc)
+		reportRender(&tr)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// verifyTarget wraps target to render it in memory and print difference with files on disk.
+func verifyTarget(target targetFunc, stale *int) targetFunc {
+
+	return func(c *cli.Context, tr *generator.Transport, svc config.Service) (err error) {
+		var diffs []generator.FileDiff
+		if diffs, err = tr.Verify(func() error { return target(c, tr, svc) }); err != nil {
+			return
+		}
+		for _, diff := range diffs {
+			fmt.Print(diff.Diff)
+		}
+		*stale += len(diffs)
+		return
+	}
+}
+
+func staleError(err error, stale int) error {
+
+	if err == nil && stale != 0 {
+		return fmt.Errorf("%d generated file(s) are out of date, run generation again", stale)
+	}
+	return err
+}
+
+// stringOption returns value of flag, when it is set, otherwise value from config or flag default.
+func stringOption(c *cli.Context, flag, cfgValue string) string {
+
+	if c.IsSet(flag) || cfgValue == "" {
+		return c.String(flag)
+	}
+	return cfgValue
+}
+
+func boolOption(c *cli.Context, flag string, cfgValue bool) bool {
+
+	if c.IsSet(flag) {
+		return c.Bool(flag)
+	}
+	return cfgValue || c.Bool(flag)
+}
+
+func runTransport(c *cli.Context, tr *generator.Transport, svc config.Service) (err error) {
+
+	var outPath string
+	if svc.Transport != nil {
+		outPath = svc.Transport.Out
+	}
+	if outPath = stringOption(c, "out", outPath); outPath == "" {
+		outPath, _ = path.Split(filepath.ToSlash(svc.Dir))
+		outPath = path.Join(outPath, "transport")
+	}
+	if err = tr.RenderServer(outPath); err != nil {
+		return
+	}
+	if c.String("outSwagger") != "" {
+		if err = tr.RenderSwagger(c.String("outSwagger")); err != nil {
+			return
+		}
+	}
+	if c.String("redoc") != "" {
+		tr.RenderRedoc(c.String("outSwagger"), c.String("redoc"))
+	}
+	return
+}
+
+func runSwagger(c *cli.Context, tr *generator.Transport, svc config.Service) (err error) {
+
+	var outPath, redoc string
+	if svc.Swagger != nil {
+		outPath, redoc = svc.Swagger.Out, svc.Swagger.Redoc
+	}
+	if outPath = stringOption(c, "outFile", outPath); outPath == "" {
+		outPath = path.Join(svc.Dir, "swagger.yaml")
+	}
+	if err = tr.RenderSwagger(outPath, svc.Ifaces()...); err != nil {
+		return
+	}
+	if redoc = stringOption(c, "redoc", redoc); redoc != "" {
+		tr.RenderRedoc(outPath, redoc)
+	}
+	return
+}
+
+func runClient(c *cli.Context, tr *generator.Transport, svc config.Service) (err error) {
+
+	var client config.Client
+	if svc.Client != nil {
+		client = *svc.Client
+	}
+	outPath := stringOption(c, "outPath", client.Out)
+	if boolOption(c, "go", client.Go) {
+		if err = tr.RenderClient(outPath); err != nil {
+			return
+		}
+	}
+	if outPackage := stringOption(c, "outPackage", client.NPM); outPackage != "" {
+		if err = tr.RenderPackageNPM(outPath, outPackage); err != nil {
+			return
+		}
+	}
+	if boolOption(c, "js", client.JS) {
+		if err = tr.RenderClientJS(outPath); err != nil {
+			return
+		}
+	}
+	if boolOption(c, "ts", client.TS) {
+		if err = tr.RenderClientTS(outPath); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func runAzure(c *cli.Context, tr *generator.Transport, svc config.Service) (err error) {
+
+	var azure config.Azure
+	if svc.Azure != nil {
+		azure = *svc.Azure
+	}
+	outPath := stringOption(c, "outPath", azure.Out)
+	if outPath == "" {
+		outPath = path.Join(svc.Dir, "azure-fApp")
+	}
+	return tr.RenderAzure(
+		stringOption(c, "appName", azure.AppName),
+		stringOption(c, "routePrefix", azure.RoutePrefix),
+		outPath,
+		stringOption(c, "logLevel", azure.LogLevel),
+		boolOption(c, "enableHealth", azure.EnableHealth),
+	)
+}
+
+// runPlugins runs plugins of service. With '--name' only this plugin is run, it may be not described in config.
+func runPlugins(c *cli.Context, tr *generator.Transport, svc config.Service) (err error) {
+
+	plugins := svc.Plugins
+	if c.IsSet("name") {
+		plugin := config.Plugin{Name: c.String("name")}
+		for _, cfgPlugin := range svc.Plugins {
+			if cfgPlugin.Name == plugin.Name {
+				plugin = cfgPlugin
+			}
+		}
+		plugin.Out = stringOption(c, "out", plugin.Out)
+		for _, option := range c.StringSlice("option") {
+			key, value, _ := strings.Cut(option, "=")
+			if plugin.Options == nil {
+				plugin.Options = make(map[string]string)
+			}
+			plugin.Options[key] = value
+		}
+		plugins = []config.Plugin{plugin}
+	}
+	for _, plugin := range plugins {
+		if plugin.Out == "" {
+			return fmt.Errorf("plugin %s: output directory is not set", plugin.Name)
+		}
+		if err = tr.RenderPlugin(plugin.Name, plugin.Out, plugin.Options); err != nil {
+			return
+		}
+	}
+	return
+}
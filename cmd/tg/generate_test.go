@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+)
+
+func generateContext(t *testing.T, args ...string) *cli.Context {
+
+	set := flag.NewFlagSet("tg", flag.ContinueOnError)
+	set.String("config", "", "")
+	set.String("services", "./pkg/someService/service", "")
+	set.Var(&cli.StringSlice{}, "ifaces", "")
+	if err := set.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return cli.NewContext(cli.NewApp(), set, nil)
+}
+
+// writeProject writes files of project to temporary directory and makes it working one.
+// Config of project is loaded again by the next command.
+func writeProject(t *testing.T, files map[string]string) (dir string) {
+
+	dir = t.TempDir()
+	for name, content := range files {
+		filePath := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(filePath), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filePath, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Chdir(dir)
+	projectCfg, projectCfgLoaded = nil, false
+	t.Cleanup(func() { projectCfg, projectCfgLoaded = nil, false })
+	return
+}
+
+func TestCommandServices(t *testing.T) {
+
+	files := map[string]string{
+		"go.mod":            "module example.com/api\n\ngo 1.22\n",
+		"tg.yaml":           "services:\n  - dir: ./service\n    include: [ User ]\n    transport:\n      out: ./transport\n",
+		"service/user.go":   userService,
+		"internal/admin.go": strings.Replace(userService, "package service", "package internal", 1),
+	}
+	writeProject(t, files)
+
+	services, fromConfig, err := commandServices(generateContext(t, "--ifaces", "Admin"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !fromConfig || len(services) != 1 || len(services[0].Include) != 1 || services[0].Include[0] != "Admin" {
+		t.Fatalf("interfaces are not replaced: %+v", services)
+	}
+	if include := projectCfg.Services[0].Include; len(include) != 1 || include[0] != "User" {
+		t.Fatalf("config is changed by flags: %v", include)
+	}
+	if err = cmdGenerate(generateContext(t, "--services", "./internal")); err == nil || !strings.Contains(err.Error(), "service ./internal is not described in") {
+		t.Fatalf("expected error of unknown service: %v", err)
+	}
+}
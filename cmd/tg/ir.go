@@ -0,0 +1,35 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/seniorGolang/tg/v2/pkg/config"
+	"github.com/seniorGolang/tg/v2/pkg/generator"
+)
+
+// cmdIR prints contract model of services as JSON. Output has the same shape for one and several services.
+func cmdIR(c *cli.Context) (err error) {
+
+	set := generator.ContractSet{Version: generator.ContractVersion, Contracts: []*generator.Contract{}}
+	err = eachService(c, func(config.Service) bool { return true }, func(c *cli.Context, tr *generator.Transport, svc config.Service) error {
+		set.Contracts = append(set.Contracts, tr.Contract())
+		return nil
+	})
+	if err != nil {
+		return
+	}
+	var data []byte
+	if data, err = json.MarshalIndent(set, "", "  "); err != nil {
+		return
+	}
+	data = append(data, '\n')
+	if c.String("out") == "" {
+		_, err = os.Stdout.Write(data)
+		return
+	}
+	log.Infof("write to %s", c.String("out"))
+	return os.WriteFile(c.String("out"), data, 0600)
+}
@@ -0,0 +1,14 @@
+package main
+
+import (
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/seniorGolang/tg/v2/pkg/lsp"
+)
+
+// cmdLsp serves language server of annotations over stdin and stdout, it is started by editor.
+func cmdLsp(*cli.Context) error {
+	return lsp.NewServer(os.Stdin, os.Stdout, Version).Serve()
+}
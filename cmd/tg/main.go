@@ -4,13 +4,15 @@
 package main
 
 import (
+	"fmt"
 	"os"
-	"os/exec"
-	"path"
+	"runtime"
 	"time"
 
 	"github.com/urfave/cli/v2"
 
+	"github.com/seniorGolang/tg/v2/pkg/config"
+	"github.com/seniorGolang/tg/v2/pkg/diagnostic"
 	"github.com/seniorGolang/tg/v2/pkg/generator"
 	"github.com/seniorGolang/tg/v2/pkg/logger"
 	"github.com/seniorGolang/tg/v2/pkg/skeleton"
@@ -32,6 +34,42 @@ func main() {
 	app.Name = "golang service 't'ransport 'g'enerator (tg)"
 	app.Compiled, _ = time.Parse(time.RFC3339, BuildStamp)
 
+	app.Flags = []cli.Flag{
+		&cli.BoolFlag{
+			Name:    "strict",
+			Value:   os.Getenv("CI") != "",
+			EnvVars: []string{"TG_STRICT"},
+			Usage:   "fail on any render error and restore output directory (enabled by default in CI)",
+		},
+		&cli.StringFlag{
+			Name:  "config",
+			Usage: "path to tg.yaml (by default it is searched next to go.mod)",
+		},
+		&cli.StringFlag{
+			Name:    "loader",
+			Value:   generator.LoaderSource,
+			EnvVars: []string{"TG_LOADER"},
+			Usage:   "loader of contract types: 'source' parses package directories, 'packages' uses go/packages with full type information",
+		},
+		&cli.BoolFlag{
+			Name:    "force",
+			EnvVars: []string{"TG_FORCE"},
+			Usage:   "render all targets ignoring manifest of previous generation in output directory",
+		},
+		&cli.StringFlag{
+			Name:    "diagnostics",
+			Value:   diagnostic.FormatText,
+			EnvVars: []string{"TG_DIAGNOSTICS"},
+			Usage:   "format of diagnostics: 'text' logs them, 'json' and 'sarif' write them at the end of command",
+		},
+		&cli.StringFlag{
+			Name:  "diagnostics-out",
+			Usage: "path to file of diagnostics in 'json' or 'sarif' format (stderr by default)",
+		},
+	}
+	app.Before = setupDiagnostics
+	app.After = flushDiagnostics
+
 	app.Commands = []*cli.Command{
 		{
 			Name:   "init",
@@ -115,6 +153,14 @@ func main() {
 					Name:  "outSwagger",
 					Usage: "path to output swagger file",
 				},
+				&cli.StringFlag{
+					Name:  "backend",
+					Usage: "HTTP framework of server: fiber, fiber3 or nethttp",
+				},
+				&cli.BoolFlag{
+					Name:  "verify",
+					Usage: "do not write files, fail with diff when generated files are out of date",
+				},
 			},
 
 			UsageText:   "tg transport",
@@ -158,6 +204,10 @@ func main() {
 					Value: false,
 					Usage: "enable ts client with package manifest",
 				},
+				&cli.BoolFlag{
+					Name:  "verify",
+					Usage: "do not write files, fail with diff when generated files are out of date",
+				},
 			},
 
 			UsageText:   "tg client --services ./pkg/someService/service",
@@ -185,11 +235,192 @@ func main() {
 					Name:  "redoc",
 					Usage: "path to output redoc bundle",
 				},
+				&cli.BoolFlag{
+					Name:  "verify",
+					Usage: "do not write files, fail with diff when generated files are out of date",
+				},
 			},
 
 			UsageText:   "tg swagger --include firstIface --exclude secondIface",
 			Description: "generate swagger documentation by interfaces",
 		},
+		{
+			Name:   "generate",
+			Usage:  "run all targets configured in tg.yaml",
+			Action: cmdGenerate,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "services",
+					Usage: "generate only services from this path",
+				},
+				&cli.StringSliceFlag{
+					Name:  "ifaces",
+					Usage: "included interfaces",
+				},
+				&cli.BoolFlag{
+					Name:  "verify",
+					Usage: "do not write files, fail with diff when generated files are out of date",
+				},
+				&cli.IntFlag{
+					Name:  "jobs",
+					Usage: "number of services packages generated in parallel by 'tg generate ./...'",
+					Value: runtime.NumCPU(),
+				},
+			},
+			UsageText:   "tg generate [./...]",
+			Description: "generate transport, clients, swagger, azure manifests and plugins for every service in tg.yaml or, with package patterns, for every services package found by them",
+		},
+		{
+			Name:   "plugin",
+			Usage:  "run external generator 'tg-<name>' with contract model on stdin",
+			Action: cmdPlugin,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "name",
+					Usage: "plugin name or path to executable (all plugins from tg.yaml, when not set)",
+				},
+				&cli.StringFlag{
+					Name:  "services",
+					Value: "./pkg/someService/service",
+					Usage: "path to services package",
+				},
+				&cli.StringSliceFlag{
+					Name:  "ifaces",
+					Usage: "included interfaces",
+				},
+				&cli.StringFlag{
+					Name:  "out",
+					Usage: "path to output folder",
+				},
+				&cli.StringSliceFlag{
+					Name:  "option",
+					Usage: "plugin option in form key=value",
+				},
+				&cli.BoolFlag{
+					Name:  "verify",
+					Usage: "do not write files, fail with diff when generated files are out of date",
+				},
+			},
+			UsageText:   "tg plugin --name gateway --services ./pkg/someService/service --out ./deploy/gateway",
+			Description: "run plugin, which reads contract model as JSON from stdin and returns files to write",
+		},
+		{
+			Name:   "check",
+			Usage:  "validate interfaces in 'service' package without generation",
+			Action: cmdCheck,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "services",
+					Value: "./pkg/someService/service",
+					Usage: "path to services package",
+				},
+				&cli.StringSliceFlag{
+					Name:  "ifaces",
+					Usage: "included interfaces",
+				},
+			},
+			UsageText:   "tg check --services ./pkg/someService/service",
+			Description: "check signatures, annotations and routes of services, report problems with file:line positions",
+		},
+		{
+			Name:        "lsp",
+			Usage:       "serve language server of '@tg' annotations for editors",
+			Action:      cmdLsp,
+			UsageText:   "tg lsp",
+			Description: "complete names and values of annotations, show their documentation on hover and report problems of annotations and methods signatures by Language Server Protocol over stdin and stdout",
+		},
+		{
+			Name:   "ir",
+			Usage:  "print contract model of services as JSON",
+			Action: cmdIR,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "services",
+					Value: "./pkg/someService/service",
+					Usage: "path to services package",
+				},
+				&cli.StringSliceFlag{
+					Name:  "ifaces",
+					Usage: "included interfaces",
+				},
+				&cli.StringFlag{
+					Name:  "out",
+					Usage: "path to output file (stdout by default)",
+				},
+			},
+			UsageText:   "tg ir --services ./pkg/someService/service",
+			Description: "print services, methods, routes, resolved types and annotations in versioned JSON format",
+		},
+		{
+			Name:   "watch",
+			Usage:  "regenerate targets on changes of services and their types",
+			Action: cmdWatch,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "services",
+					Value: "./pkg/someService/service",
+					Usage: "path to services package",
+				},
+				&cli.StringSliceFlag{
+					Name:  "ifaces",
+					Usage: "included interfaces",
+				},
+				&cli.StringFlag{
+					Name:  "out",
+					Usage: "path to output transport folder",
+				},
+				&cli.StringFlag{
+					Name:  "backend",
+					Usage: "HTTP framework of server: fiber, fiber3 or nethttp",
+				},
+				&cli.StringFlag{
+					Name:  "outPath",
+					Usage: "path to output go client",
+				},
+				&cli.StringFlag{
+					Name:  "outFile",
+					Usage: "path to output swagger file",
+				},
+				&cli.DurationFlag{
+					Name:  "interval",
+					Value: 500 * time.Millisecond,
+					Usage: "interval of checking files",
+				},
+				&cli.DurationFlag{
+					Name:  "debounce",
+					Value: 300 * time.Millisecond,
+					Usage: "delay after last change before generation",
+				},
+			},
+			UsageText:   "tg watch --services ./pkg/someService/service --out ./pkg/someService/transport",
+			Description: "watch services package and packages of used types, regenerate targets of changed services",
+		},
+		{
+			Name:   "diff",
+			Usage:  "report contract changes of services against base version",
+			Action: cmdDiff,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "base",
+					Usage: "git ref or directory with base version of repository",
+				},
+				&cli.StringFlag{
+					Name:  "services",
+					Value: "./pkg/someService/service",
+					Usage: "path to services package",
+				},
+				&cli.StringSliceFlag{
+					Name:  "ifaces",
+					Usage: "included interfaces",
+				},
+				&cli.BoolFlag{
+					Name:  "json",
+					Usage: "print report as JSON",
+				},
+			},
+			UsageText:   "tg diff --base origin/master --services ./pkg/someService/service",
+			Description: "classify contract changes as breaking, non-breaking or docs-only; fail on breaking changes without major version bump",
+		},
 	}
 	err := app.Run(os.Args)
 	if err != nil {
@@ -226,32 +457,7 @@ func cmdClient(c *cli.Context) (err error) {
 			log.Info("done")
 		}
 	}()
-	var tr generator.Transport
-	if tr, err = generator.NewTransport(log, Version, c.String("services"), c.StringSlice("ifaces")...); err != nil {
-		return
-	}
-	if c.Bool("go") {
-		if err = tr.RenderClient(c.String("outPath")); err != nil {
-			return
-		}
-	}
-
-	if c.String("outPackage") != "" {
-		if err = tr.RenderPackageNPM(c.String("outPath"), c.String("outPackage")); err != nil {
-			return
-		}
-	}
-	if c.Bool("js") {
-		if err = tr.RenderClientJS(c.String("outPath")); err != nil {
-			return
-		}
-	}
-	if c.Bool("ts") {
-		if err = tr.RenderClientTS(c.String("outPath")); err != nil {
-			return
-		}
-	}
-	return
+	return eachService(c, func(svc config.Service) bool { return svc.Client != nil }, runClient)
 }
 
 func cmdTransport(c *cli.Context) (err error) {
@@ -261,29 +467,7 @@ func cmdTransport(c *cli.Context) (err error) {
 			log.Info("done")
 		}
 	}()
-	var tr generator.Transport
-	if tr, err = generator.NewTransport(log, Version, c.String("services"), c.StringSlice("ifaces")...); err != nil {
-		return
-	}
-	outPath, _ := path.Split(c.String("services"))
-	outPath = path.Join(outPath, "transport")
-	if c.String("out") != "" {
-		outPath = c.String("out")
-	}
-	if err = tr.RenderServer(outPath); err != nil {
-		return
-	}
-	if c.String("outSwagger") != "" {
-		err = tr.RenderSwagger(c.String("outSwagger"))
-	}
-	if c.String("redoc") != "" {
-		var output []byte
-		log.Infof("write to %s", c.String("redoc"))
-		if output, err = exec.Command("redoc-cli", "bundle", c.String("outSwagger"), "-o", c.String("redoc")).Output(); err != nil { // nolint:gosec
-			log.WithError(err).Error(string(output))
-		}
-	}
-	return
+	return eachService(c, func(svc config.Service) bool { return svc.Transport != nil }, runTransport)
 }
 
 func cmdSwagger(c *cli.Context) (err error) {
@@ -293,26 +477,51 @@ func cmdSwagger(c *cli.Context) (err error) {
 			log.Info("done")
 		}
 	}()
+	return eachService(c, func(svc config.Service) bool { return svc.Swagger != nil }, runSwagger)
+}
 
-	var tr generator.Transport
-	if tr, err = generator.NewTransport(log, Version, c.String("services"), c.StringSlice("ifaces")...); err != nil {
-		return
+func cmdPlugin(c *cli.Context) (err error) {
+
+	defer func() {
+		if err == nil {
+			log.Info("done")
+		}
+	}()
+	hasPlugin := func(svc config.Service) bool {
+		for _, plugin := range svc.Plugins {
+			if !c.IsSet("name") || plugin.Name == c.String("name") {
+				return true
+			}
+		}
+		return false
 	}
+	return eachService(c, hasPlugin, runPlugins)
+}
 
-	outPath := path.Join(c.String("services"), "swagger.yaml")
+func cmdCheck(c *cli.Context) (err error) {
 
-	if c.String("outFile") != "" {
-		outPath = c.String("outFile")
+	var services []config.Service
+	if services, _, err = commandServices(c); err != nil {
+		return
 	}
-	if err = tr.RenderSwagger(outPath, c.StringSlice("ifaces")...); err == nil {
-		if c.String("redoc") != "" {
-			var output []byte
-			log.Infof("write to %s", c.String("redoc"))
-			if output, err = exec.Command("redoc-cli", "bundle", outPath, "-o", c.String("redoc")).Output(); err != nil {
-				log.WithError(err).Error(string(output))
-			}
+	cfg, _ := projectConfig(c)
+	var diags diagnostic.List
+	for _, svc := range services {
+		var tr generator.Transport
+		if tr, err = newTransport(c, cfg, svc); err != nil {
+			return
 		}
+		diags = append(diags, tr.Check()...)
+	}
+	if machineDiagnostics() {
+		reportDiagnostics(log, diags)
+	} else if err = diags.Write(os.Stdout, diagnostic.FormatText, Version); err != nil {
+		return
+	}
+	if diags.HasErrors() {
+		return fmt.Errorf("check failed: %d error(s), %d warning(s)", diags.Count(diagnostic.SeverityError), diags.Count(diagnostic.SeverityWarning))
 	}
+	log.Info("ok")
 	return
 }
 
@@ -323,13 +532,5 @@ func cmdAzure(c *cli.Context) (err error) {
 			log.Info("done")
 		}
 	}()
-	var tr generator.Transport
-	if tr, err = generator.NewTransport(log, Version, c.String("services")); err != nil {
-		return
-	}
-	outPath := path.Join(c.String("services"), "azure-fApp")
-	if c.String("outPath") != "" {
-		outPath = c.String("outPath")
-	}
-	return tr.RenderAzure(c.String("appName"), c.String("routePrefix"), outPath, c.String("logLevel"), c.Bool("enableHealth"))
+	return eachService(c, func(svc config.Service) bool { return svc.Azure != nil }, runAzure)
 }
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/seniorGolang/tg/v2/pkg/config"
+	"github.com/seniorGolang/tg/v2/pkg/generator"
+)
+
+const (
+	statusGenerated = "generated"
+	statusUnchanged = "unchanged"
+	statusStale     = "stale"
+	statusFailed    = "failed"
+)
+
+type packageResult struct {
+	dir      string
+	status   string
+	files    int
+	duration time.Duration
+	diffs    []generator.FileDiff
+	err      error
+}
+
+// generatePackages generates services packages matched by patterns ('./...', './pkg/...' or directory) in parallel.
+// Targets of package are taken from config or by convention, only changed files are written.
+func generatePackages(c *cli.Context, cfg *config.Config, patterns []string) (err error) {
+
+	var dirs []string
+	for _, pattern := range patterns {
+		var found []string
+		if found, err = generator.FindServices(pattern); err != nil {
+			return
+		}
+		dirs = append(dirs, found...)
+	}
+	if len(dirs) == 0 {
+		return fmt.Errorf("no services packages found by %v", patterns)
+	}
+	jobs := c.Int("jobs")
+	if jobs < 1 {
+		jobs = 1
+	}
+	results := make([]packageResult, len(dirs))
+	services := make([]config.Service, len(dirs))
+	owners := make(map[string]int)
+	for i, dir := range dirs {
+		services[i] = cfg.ServiceOf(dir)
+		for _, out := range targetOutputs(services[i]) {
+			owner, found := owners[out]
+			if !found {
+				owners[out] = i
+				continue
+			}
+			// both packages are failed, otherwise result depends on order of packages
+			results[i] = packageResult{dir: dir, status: statusFailed, err: fmt.Errorf("output %s is used by %s", relDir(out), relDir(dirs[owner]))}
+			if results[owner].err == nil {
+				results[owner] = packageResult{dir: dirs[owner], status: statusFailed, err: fmt.Errorf("output %s is used by %s", relDir(out), relDir(dir))}
+			}
+		}
+	}
+	semaphore := make(chan struct{}, jobs)
+	var wg sync.WaitGroup
+	for i := range dirs {
+		if results[i].err != nil {
+			continue
+		}
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func() {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			results[i] = generatePackage(c, cfg, services[i])
+		}()
+	}
+	wg.Wait()
+	for _, result := range results {
+		for _, diff := range result.diffs {
+			fmt.Print(diff.Diff)
+		}
+	}
+	return printResults(os.Stdout, results)
+}
+
+// targetOutputs returns absolute paths of outputs of service targets.
+func targetOutputs(svc config.Service) (outputs []string) {
+
+	if svc.Transport != nil {
+		outputs = append(outputs, svc.Transport.Out)
+	}
+	if svc.Swagger != nil {
+		outputs = append(outputs, svc.Swagger.Out)
+	}
+	if svc.Client != nil {
+		outputs = append(outputs, svc.Client.Out)
+	}
+	if svc.Azure != nil {
+		outputs = append(outputs, svc.Azure.Out)
+	}
+	for _, plugin := range svc.Plugins {
+		outputs = append(outputs, plugin.Out)
+	}
+	for i := range outputs {
+		outputs[i], _ = filepath.Abs(outputs[i])
+	}
+	return
+}
+
+// generatePackage renders targets of svc. Config is loaded before packages are generated in parallel, it may be nil.
+func generatePackage(c *cli.Context, cfg *config.Config, svc config.Service) (result packageResult) {
+
+	start := time.Now()
+	result.dir = svc.Dir
+	defer func() {
+		result.duration = time.Since(start)
+		if result.err != nil {
+			result.status = statusFailed
+		}
+	}()
+	if result.err = svc.Validate(); result.err != nil {
+		return
+	}
+	var tr generator.Transport
+	if tr, result.err = newTransport(c, cfg, svc); result.err != nil {
+		return
+	}
+	reportDiagnostics(log.WithField("package", relDir(svc.Dir)), tr.Check())
+	defer reportRender(&tr)
+	render := func() error { return tr.RenderTargets(svc) }
+	if c.Bool("verify") {
+		// diffs are printed after all packages, packages are generated in parallel
+		if result.diffs, result.err = tr.Verify(render); result.err != nil {
+			return
+		}
+		result.files, result.status = len(result.diffs), statusStale
+		if len(result.diffs) == 0 {
+			result.status = statusUnchanged
+		}
+		return
+	}
+	var changed []string
+	if changed, result.err = tr.RenderChanged(render); result.err != nil {
+		return
+	}
+	if svc.Swagger != nil && svc.Swagger.Redoc != "" {
+		tr.RenderRedoc(svc.Swagger.Out, svc.Swagger.Redoc)
+	}
+	result.files, result.status = len(changed), statusGenerated
+	if len(changed) == 0 {
+		result.status = statusUnchanged
+	}
+	return
+}
+
+// printResults prints summary table of packages to out and returns error, when any package is failed or stale.
+func printResults(out io.Writer, results []packageResult) error {
+
+	var failed, stale int
+	table := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(table, "PACKAGE\tSTATUS\tFILES\tTIME\tERROR")
+	for _, result := range results {
+		var errText string
+		if result.err != nil {
+			errText = result.err.Error()
+		}
+		switch result.status {
+		case statusFailed:
+			failed++
+		case statusStale:
+			stale += result.files
+		}
+		_, _ = fmt.Fprintf(table, "%s\t%s\t%d\t%s\t%s\n", relDir(result.dir), result.status, result.files, result.duration.Round(time.Millisecond), errText)
+	}
+	_ = table.Flush()
+	if failed != 0 {
+		return fmt.Errorf("%d of %d package(s) failed", failed, len(results))
+	}
+	return staleError(nil, stale)
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/urfave/cli/v2"
+)
+
+const userService = `package service
+
+import "context"
+
+// @tg jsonRPC-server
+type User interface {
+	GetName(ctx context.Context, id int) (name string, err error)
+}
+`
+
+func packagesContext(verify bool) *cli.Context {
+
+	set := flag.NewFlagSet("tg", flag.ContinueOnError)
+	set.Int("jobs", 2, "")
+	set.Bool("verify", verify, "")
+	set.Bool("strict", false, "")
+	set.Bool("force", false, "")
+	set.String("loader", "", "")
+	set.String("backend", "", "")
+	set.String("config", "", "")
+	return cli.NewContext(cli.NewApp(), set, nil)
+}
+
+func TestGeneratePackages(t *testing.T) {
+
+	files := map[string]string{
+		"go.mod":            "module example.com/api\n\ngo 1.22\n",
+		"a/service/user.go": userService,
+		"b/service/user.go": userService,
+		"c/service/user.go": userService,
+		"c/api/user.go":     strings.Replace(userService, "package service", "package api", 1),
+	}
+	writeProject(t, files)
+
+	err := generatePackages(packagesContext(false), nil, []string{"./..."})
+	if err == nil || err.Error() != "2 of 4 package(s) failed" {
+		t.Fatalf("both packages with the same output are expected to fail: %v", err)
+	}
+	for _, name := range []string{"a/transport/server.go", "b/transport/server.go"} {
+		if _, err = os.Stat(name); err != nil {
+			t.Fatalf("package is not generated: %v", err)
+		}
+	}
+	if _, err = os.Stat("c/transport"); !os.IsNotExist(err) {
+		t.Fatalf("output of conflicting packages is generated: %v", err)
+	}
+	if err = os.RemoveAll("c"); err != nil {
+		t.Fatal(err)
+	}
+	if err = generatePackages(packagesContext(true), nil, []string{"./..."}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err = os.WriteFile("b/transport/server.go", []byte("package transport\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = generatePackages(packagesContext(true), nil, []string{"./..."}); err == nil || !strings.Contains(err.Error(), "out of date") {
+		t.Fatalf("expected stale error: %v", err)
+	}
+}
+
+func TestPrintResults(t *testing.T) {
+
+	var out bytes.Buffer
+	err := printResults(&out, []packageResult{
+		{dir: "a/service", status: statusGenerated, files: 3, duration: 1500 * time.Microsecond},
+		{dir: "b/service", status: statusFailed, err: errors.New("output b/transport is used by c/service")},
+		{dir: "c/service", status: statusStale, files: 2},
+	})
+	if err == nil || err.Error() != "1 of 3 package(s) failed" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	expected := []string{
+		"PACKAGE    STATUS     FILES  TIME  ERROR",
+		"a/service  generated  3      2ms",
+		"b/service  failed     0      0s    output b/transport is used by c/service",
+		"c/service  stale      2      0s",
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("unexpected table:\n%s", out.String())
+	}
+	for i, line := range lines {
+		if strings.TrimRight(line, " ") != expected[i] {
+			t.Fatalf("line %d is %q, expected %q", i, line, expected[i])
+		}
+	}
+	out.Reset()
+	if err = printResults(&out, []packageResult{{dir: "a/service", status: statusStale, files: 2}}); err == nil || !strings.Contains(err.Error(), "2 generated file(s) are out of date") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/seniorGolang/tg/v2/pkg/config"
+	"github.com/seniorGolang/tg/v2/pkg/mod"
+	"github.com/seniorGolang/tg/v2/pkg/watcher"
+)
+
+type watchedService struct {
+	svc  config.Service
+	dirs []string
+}
+
+func cmdWatch(c *cli.Context) (err error) {
+
+	var fromConfig bool
+	var services []config.Service
+	if services, fromConfig, err = commandServices(c); err != nil {
+		return
+	}
+	cfg, _ := projectConfig(c)
+	if !fromConfig {
+		for i := range services {
+			watchFlagTargets(c, &services[i])
+		}
+	}
+	watched := make([]*watchedService, 0, len(services))
+	for _, svc := range services {
+		if svc.Transport == nil && svc.Client == nil && svc.Swagger == nil && svc.Azure == nil && len(svc.Plugins) == 0 {
+			log.WithField("services", svc.Dir).Warn("no targets, skip")
+			continue
+		}
+		watched = append(watched, &watchedService{svc: svc})
+	}
+	if len(watched) == 0 {
+		return fmt.Errorf("nothing to watch: configure targets in %s or set --out, --outPath or --outFile", config.FileName)
+	}
+	w := watcher.New(c.Duration("interval"), c.Duration("debounce"))
+	for _, ws := range watched {
+		regenerate(c, cfg, ws)
+	}
+	flushWatchDiagnostics(c)
+	w.SetDirs(watchedDirs(watched)...)
+	log.WithField("dirs", len(w.Dirs())).Info("watching for changes")
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	err = w.Watch(ctx, func(files []string) {
+		for _, ws := range watched {
+			if affected := ws.affected(files); len(affected) != 0 {
+				log.WithField("services", relDir(ws.svc.Dir)).WithField("files", strings.Join(affected, ",")).Info("changed")
+				regenerate(c, cfg, ws)
+			}
+		}
+		flushWatchDiagnostics(c)
+		w.SetDirs(watchedDirs(watched)...)
+	})
+	if err == context.Canceled {
+		log.Info("stopped")
+		return nil
+	}
+	return
+}
+
+// flushWatchDiagnostics writes diagnostics of regeneration, file of diagnostics contains the last regeneration.
+func flushWatchDiagnostics(c *cli.Context) {
+
+	if err := flushDiagnostics(c); err != nil {
+		log.WithError(err).Error("write diagnostics")
+	}
+}
+
+// watchFlagTargets enables targets by flags, when services are not taken from config.
+func watchFlagTargets(c *cli.Context, svc *config.Service) {
+
+	if c.IsSet("out") {
+		svc.Transport = &config.Transport{Out: c.String("out"), Backend: c.String("backend")}
+	}
+	if c.IsSet("outPath") {
+		svc.Client = &config.Client{Out: c.String("outPath"), Go: true}
+	}
+	if c.IsSet("outFile") {
+		svc.Swagger = &config.Swagger{Out: c.String("outFile")}
+	}
+}
+
+// regenerate renders targets of service in memory and writes only changed files, so targets,
+// which are not affected by changes, are not written. Names of written targets are returned.
+// Errors are logged, watching continues.
+func regenerate(c *cli.Context, cfg *config.Config, ws *watchedService) (regenerated []string) {
+
+	svcLog := log.WithField("services", relDir(ws.svc.Dir))
+	// go.work and go.mod may be changed since previous generation
+	mod.ResetCache()
+	tr, err := newTransport(c, cfg, ws.svc)
+	if err != nil {
+		svcLog.WithError(err).Error("parse services")
+		if len(ws.dirs) == 0 {
+			svcDir, _ := filepath.Abs(ws.svc.Dir)
+			ws.dirs = []string{svcDir}
+		}
+		return
+	}
+	reportDiagnostics(svcLog, tr.Check())
+	targets := []struct {
+		name    string
+		enabled bool
+		run     targetFunc
+	}{
+		{name: "transport", enabled: ws.svc.Transport != nil, run: runTransport},
+		{name: "swagger", enabled: ws.svc.Swagger != nil, run: runSwagger},
+		{name: "client", enabled: ws.svc.Client != nil, run: runClient},
+		{name: "azure", enabled: ws.svc.Azure != nil, run: runAzure},
+		{name: "plugins", enabled: len(ws.svc.Plugins) != 0, run: runPlugins},
+	}
+	for _, target := range targets {
+		if !target.enabled {
+			continue
+		}
+		var changed []string
+		if changed, err = tr.RenderChanged(func() error { return target.run(c, &tr, ws.svc) }); err != nil {
+			svcLog.WithError(err).Errorf("%s failed", target.name)
+			continue
+		}
+		if len(changed) == 0 {
+			svcLog.Debugf("%s is not affected", target.name)
+			continue
+		}
+		// redoc-cli bundles swagger file on disk, it is skipped by render in memory
+		if ws.svc.Swagger != nil && ws.svc.Swagger.Redoc != "" && target.name == "swagger" {
+			tr.RenderRedoc(ws.svc.Swagger.Out, ws.svc.Swagger.Redoc)
+		}
+		svcLog.WithField("files", len(changed)).Infof("%s regenerated", target.name)
+		regenerated = append(regenerated, target.name)
+	}
+	reportRender(&tr)
+	ws.dirs = tr.SourceDirs()
+	return
+}
+
+// affected returns changed files from directories of service.
+func (ws *watchedService) affected(files []string) (affected []string) {
+
+	for _, file := range files {
+		for _, dir := range ws.dirs {
+			if strings.HasPrefix(file, dir+string(filepath.Separator)) {
+				affected = append(affected, relDir(file))
+				break
+			}
+		}
+	}
+	return
+}
+
+func watchedDirs(watched []*watchedService) (dirs []string) {
+
+	for _, ws := range watched {
+		dirs = append(dirs, ws.dirs...)
+	}
+	return
+}
+
+func relDir(dir string) string {
+
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, dir); err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+	}
+	return dir
+}
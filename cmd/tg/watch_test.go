@@ -0,0 +1,85 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/seniorGolang/tg/v2/pkg/config"
+)
+
+const watchedUser = `package service
+
+import (
+	"context"
+
+	"example.com/api/dto"
+)
+
+// @tg jsonRPC-server
+type User interface {
+	// GetUser returns user by id.
+	GetUser(ctx context.Context, id int) (user dto.User, err error)
+}
+`
+
+func watchContext() *cli.Context {
+
+	set := flag.NewFlagSet("tg", flag.ContinueOnError)
+	for _, name := range []string{"config", "loader", "backend", "out", "outSwagger", "outFile", "redoc"} {
+		set.String(name, "", "")
+	}
+	set.Bool("strict", false, "")
+	set.Bool("force", false, "")
+	return cli.NewContext(cli.NewApp(), set, nil)
+}
+
+func TestRegenerate(t *testing.T) {
+
+	files := map[string]string{
+		"go.mod":          "module example.com/api\n\ngo 1.22\n",
+		"service/user.go": watchedUser,
+		"dto/user.go":     "package dto\n\ntype User struct {\n\tName string `json:\"name\"`\n}\n",
+	}
+	dir := writeProject(t, files)
+
+	c := watchContext()
+	ws := &watchedService{svc: config.Service{
+		Dir:       "service",
+		Transport: &config.Transport{Out: "transport"},
+		Swagger:   &config.Swagger{Out: "api/swagger.yaml"},
+	}}
+	if regenerated := regenerate(c, nil, ws); !slices.Equal(regenerated, []string{"transport", "swagger"}) {
+		t.Fatalf("unexpected targets of first generation: %v", regenerated)
+	}
+	dtoFile := filepath.Join(dir, "dto", "user.go")
+	if affected := ws.affected([]string{dtoFile, filepath.Join(dir, "other", "other.go")}); len(affected) != 1 || affected[0] != filepath.Join("dto", "user.go") {
+		t.Fatalf("package of types is not watched: %v", affected)
+	}
+	if regenerated := regenerate(c, nil, ws); len(regenerated) != 0 {
+		t.Fatalf("targets without changes are regenerated: %v", regenerated)
+	}
+	if err := os.WriteFile("service/user.go", []byte(strings.Replace(watchedUser, "returns user by id", "returns user by identifier", 1)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if regenerated := regenerate(c, nil, ws); !slices.Equal(regenerated, []string{"swagger"}) {
+		t.Fatalf("only swagger is affected by doc comment, regenerated: %v", regenerated)
+	}
+	if err := os.WriteFile(dtoFile, []byte("package dto\n\ntype User struct {\n\tName string `json:\"fullName\"`\n}\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if regenerated := regenerate(c, nil, ws); !slices.Equal(regenerated, []string{"swagger"}) {
+		t.Fatalf("only swagger is affected by tag of field, regenerated: %v", regenerated)
+	}
+	if err := os.WriteFile("service/user.go", []byte(strings.Replace(watchedUser, "id int", "id string", 1)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if regenerated := regenerate(c, nil, ws); !slices.Equal(regenerated, []string{"transport", "swagger"}) {
+		t.Fatalf("targets are not regenerated by change of method: %v", regenerated)
+	}
+}
@@ -241,12 +241,17 @@ func parseDeclaration(decl ast.Decl, file *types.File, opt Option) error {
 					if err != nil {
 						return fmt.Errorf("%s: can't parse struct fields: %v", typeSpec.Name.Name, err)
 					}
+					typeParams, err := parseParams(typeSpec.TypeParams, file, opt)
+					if err != nil {
+						return fmt.Errorf("%s: can't parse type params: %v", typeSpec.Name.Name, err)
+					}
 					file.Structures = append(file.Structures, types.Struct{
 						Base: types.Base{
 							Name: typeSpec.Name.Name,
 							Docs: parseCommentFromSources(opt, d.Doc, typeSpec.Doc, typeSpec.Comment),
 						},
-						Fields: strFields,
+						TypeParams: typeParams,
+						Fields:     strFields,
 					})
 				default:
 					if opt.check(IgnoreTypes) {
@@ -256,10 +261,14 @@ func parseDeclaration(decl ast.Decl, file *types.File, opt Option) error {
 					if err != nil {
 						return fmt.Errorf("%s: can't parse type: %v", typeSpec.Name.Name, err)
 					}
+					typeParams, err := parseParams(typeSpec.TypeParams, file, opt)
+					if err != nil {
+						return fmt.Errorf("%s: can't parse type params: %v", typeSpec.Name.Name, err)
+					}
 					file.Types = append(file.Types, types.FileType{Base: types.Base{
 						Name: typeSpec.Name.Name,
 						Docs: parseCommentFromSources(opt, d.Doc, typeSpec.Doc, typeSpec.Comment),
-					}, Type: newType})
+					}, TypeParams: typeParams, Type: newType})
 				}
 			}
 		}
@@ -334,7 +343,7 @@ func parseConstant(decl *ast.GenDecl, file *types.File, opt Option) (constants [
 					return nil, fmt.Errorf("can't parse type: %v", err)
 				}
 				if len(spec.Values) > idx {
-					_, _, iotaMark, err = parseByValue(spec.Values[idx], file, opt)
+					variable.Value, _, iotaMark, err = parseByValue(spec.Values[idx], file, opt)
 					if err != nil {
 						return nil, fmt.Errorf("can't parse type: %v", err)
 					}
@@ -491,6 +500,16 @@ func parseByType(spec interface{}, file *types.File, opt Option) (tt types.Type,
 		return types.TChan{Next: next, Direction: int(t.Dir)}, iotaMark, nil
 	case *ast.ParenExpr:
 		return parseByType(t.X, file, opt)
+	case *ast.IndexExpr:
+		return parseInstance(t.X, []ast.Expr{t.Index}, file, opt)
+	case *ast.IndexListExpr:
+		return parseInstance(t.X, t.Indices, file, opt)
+	case *ast.BinaryExpr:
+		// type constraint union like `int | string`
+		return types.TName{TypeName: "any"}, false, nil
+	case *ast.UnaryExpr:
+		// approximation constraint like `~int`
+		return parseByType(t.X, file, opt)
 	case *ast.BadExpr:
 		return nil, false, fmt.Errorf("bad expression")
 	case *ast.FuncType:
@@ -509,6 +528,24 @@ func parseByType(spec interface{}, file *types.File, opt Option) (tt types.Type,
 	}
 }
 
+// parseInstance parses instantiation of generic type like `Page[User]`.
+func parseInstance(generic ast.Expr, indices []ast.Expr, file *types.File, opt Option) (types.Type, bool, error) {
+
+	next, _, err := parseByType(generic, file, opt)
+	if err != nil {
+		return nil, false, err
+	}
+	instance := types.TInstance{Next: next}
+	for _, index := range indices {
+		arg, _, err := parseByType(index, file, opt)
+		if err != nil {
+			return nil, false, err
+		}
+		instance.Args = append(instance.Args, arg)
+	}
+	return instance, false, nil
+}
+
 func parseArrayLen(t *ast.ArrayType) int {
 	if t == nil {
 		return -2
@@ -576,7 +613,7 @@ func parseInterfaceMethods(ifaceType *ast.InterfaceType, file *types.File, opt O
 					return nil, nil, err
 				}
 				fns = append(fns, fn)
-			case *ast.Ident:
+			case *ast.Ident, *ast.SelectorExpr:
 				// Embedded interfaces
 				iface, _, err := parseByType(method.Type, file, opt)
 				if err != nil {
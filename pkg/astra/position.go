@@ -0,0 +1,123 @@
+package astra
+
+import (
+	"go/ast"
+	"go/token"
+
+	"github.com/seniorGolang/tg/v2/pkg/astra/types"
+)
+
+// Fills declaration positions of interfaces, their methods with args and results and structures with fields.
+// Entities are matched by names and order, as they were collected by ParseAstFile.
+func fillPositions(fSet *token.FileSet, tree *ast.File, file *types.File) {
+
+	position := func(pos token.Pos) types.Position {
+		p := fSet.Position(pos)
+		return types.Position{Filename: p.Filename, Line: p.Line, Column: p.Column}
+	}
+	file.Pos = position(tree.Package)
+	for _, decl := range tree.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			switch t := typeSpec.Type.(type) {
+			case *ast.InterfaceType:
+				for i := range file.Interfaces {
+					if file.Interfaces[i].Name != typeSpec.Name.Name {
+						continue
+					}
+					file.Interfaces[i].Pos = position(typeSpec.Name.Pos())
+					fillInterfacePositions(position, t, &file.Interfaces[i])
+				}
+			case *ast.StructType:
+				for i := range file.Structures {
+					if file.Structures[i].Name != typeSpec.Name.Name {
+						continue
+					}
+					file.Structures[i].Pos = position(typeSpec.Name.Pos())
+					fillFieldPositions(position, t.Fields, func(idx int) *types.Base {
+						if idx < len(file.Structures[i].Fields) {
+							return &file.Structures[i].Fields[idx].Base
+						}
+						return nil
+					})
+				}
+			default:
+				for i := range file.Types {
+					if file.Types[i].Name == typeSpec.Name.Name {
+						file.Types[i].Pos = position(typeSpec.Name.Pos())
+					}
+				}
+			}
+		}
+	}
+}
+
+func fillInterfacePositions(position func(token.Pos) types.Position, ifaceType *ast.InterfaceType, iface *types.Interface) {
+
+	if ifaceType.Methods == nil {
+		return
+	}
+	var embedded int
+	for _, field := range ifaceType.Methods.List {
+		switch funcType := field.Type.(type) {
+		case *ast.FuncType:
+			if len(field.Names) == 0 {
+				continue
+			}
+			for _, fn := range iface.Methods {
+				if fn.Name != field.Names[0].Name {
+					continue
+				}
+				fn.Pos = position(field.Names[0].Pos())
+				fillFieldPositions(position, funcType.Params, func(idx int) *types.Base {
+					if idx < len(fn.Args) {
+						return &fn.Args[idx].Base
+					}
+					return nil
+				})
+				fillFieldPositions(position, funcType.Results, func(idx int) *types.Base {
+					if idx < len(fn.Results) {
+						return &fn.Results[idx].Base
+					}
+					return nil
+				})
+			}
+		case *ast.Ident:
+			if embedded < len(iface.Interfaces) {
+				iface.Interfaces[embedded].Pos = position(field.Pos())
+			}
+			embedded++
+		}
+	}
+}
+
+// Walks field list in the same order as parseParams does and sets position of each variable.
+func fillFieldPositions(position func(token.Pos) types.Position, fields *ast.FieldList, base func(idx int) *types.Base) {
+
+	if fields == nil {
+		return
+	}
+	var idx int
+	for _, field := range fields.List {
+		if len(field.Names) == 0 {
+			if b := base(idx); b != nil {
+				b.Pos = position(field.Type.Pos())
+			}
+			idx++
+			continue
+		}
+		for _, name := range field.Names {
+			if b := base(idx); b != nil {
+				b.Pos = position(name.Pos())
+			}
+			idx++
+		}
+	}
+}
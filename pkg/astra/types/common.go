@@ -4,7 +4,9 @@ package types
 // It contains name of entity and docs.
 // Docs is a comments in golang syntax above entity declaration.
 // Each block comment is counted as one.
+// Pos is filled only when source file is parsed with file set (see astra.ParseFile).
 type Base struct {
 	Name string   `json:"name,omitempty"`
 	Docs []string `json:"docs,omitempty"`
+	Pos  Position `json:"pos,omitempty"`
 }
@@ -2,8 +2,9 @@ package types
 
 type FileType struct {
 	Base
-	Type    Type      `json:"type,omitempty"`
-	Methods []*Method `json:"methods,omitempty"`
+	TypeParams []Variable `json:"type_params,omitempty"` // Type parameters of generic type.
+	Type       Type       `json:"type,omitempty"`
+	Methods    []*Method  `json:"methods,omitempty"`
 }
 
 // File is a top-level entity, that contains all top-level declarations of the file.
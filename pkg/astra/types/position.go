@@ -0,0 +1,31 @@
+package types
+
+import (
+	"fmt"
+)
+
+// Position of entity declaration in source file.
+// Zero value means position is unknown.
+type Position struct {
+	Filename string `json:"filename,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+}
+
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+func (p Position) String() string {
+
+	switch {
+	case p.Filename == "":
+		return "-"
+	case !p.IsValid():
+		return p.Filename
+	case p.Column == 0:
+		return fmt.Sprintf("%s:%d", p.Filename, p.Line)
+	default:
+		return fmt.Sprintf("%s:%d:%d", p.Filename, p.Line, p.Column)
+	}
+}
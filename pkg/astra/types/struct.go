@@ -17,8 +17,9 @@ func (f StructField) String() string {
 
 type Struct struct {
 	Base
-	Fields  []StructField `json:"fields,omitempty"`
-	Methods []*Method     `json:"methods,omitempty"`
+	TypeParams []Variable    `json:"type_params,omitempty"` // Type parameters of generic struct.
+	Fields     []StructField `json:"fields,omitempty"`
+	Methods    []*Method     `json:"methods,omitempty"`
 }
 
 func (s Struct) t() {}
@@ -122,6 +122,30 @@ func (i TImport) NextType() Type {
 	return i.Next
 }
 
+// TInstance is an instantiation of generic type like `Page[User]`.
+type TInstance struct {
+	Next Type   `json:"next,omitempty"`
+	Args []Type `json:"args,omitempty"`
+}
+
+func (i TInstance) t() {}
+
+func (i TInstance) String() string {
+	args := make([]string, 0, len(i.Args))
+	for _, arg := range i.Args {
+		args = append(args, arg.String())
+	}
+	str := ""
+	if i.Next != nil {
+		str += i.Next.String()
+	}
+	return str + "[" + strings.Join(args, ", ") + "]"
+}
+
+func (i TInstance) NextType() Type {
+	return i.Next
+}
+
 // TEllipsis used only for function params in declarations like `strs ...string`
 type TEllipsis struct {
 	Next Type `json:"next,omitempty"`
@@ -14,20 +14,31 @@ import (
 
 // Opens and parses file by name and return information about it.
 func ParseFile(filename string, options ...Option) (*types.File, error) {
+	return ParseSource(filename, nil, options...)
+}
+
+// Parses source of file and return information about it. Source is read from file, when src is nil,
+// so unsaved content of file (for example, in editor) can be parsed.
+func ParseSource(filename string, src []byte, options ...Option) (*types.File, error) {
 
 	path, err := filepath.Abs(filename)
 	if err != nil {
 		return nil, fmt.Errorf("can not filepath.Abs: %v", err)
 	}
 	fSet := token.NewFileSet()
-	tree, err := astParser.ParseFile(fSet, path, nil, astParser.ParseComments)
+	var source any
+	if src != nil {
+		source = src
+	}
+	tree, err := astParser.ParseFile(fSet, path, source, astParser.ParseComments)
 	if err != nil {
-		return nil, fmt.Errorf("error when parse file: %v", err)
+		return nil, fmt.Errorf("error when parse file: %w", err)
 	}
 	info, err := ParseAstFile(tree, options...)
 	if err != nil {
 		return nil, fmt.Errorf("error when parsing info from file: %v", err)
 	}
+	fillPositions(fSet, tree, info)
 	return info, nil
 }
 
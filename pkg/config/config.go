@@ -0,0 +1,329 @@
+// Package config reads project-level 'tg.yaml', which describes services and generation targets.
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/seniorGolang/tg/v2/pkg/mod"
+	"github.com/seniorGolang/tg/v2/pkg/tags"
+)
+
+const FileName = "tg.yaml"
+
+type Config struct {
+	Strict *bool `yaml:"strict,omitempty"`
+	// Loader of types used by contract: 'source' (default) or 'packages'.
+	Loader   string    `yaml:"loader,omitempty"`
+	Services []Service `yaml:"services"`
+	// Convention describes targets of services packages found by 'tg generate ./...', which are not listed in services.
+	// Paths of targets are relative to directory of found package, dir is not used.
+	Convention *Service `yaml:"convention,omitempty"`
+
+	path string
+}
+
+// Service describes one services package and targets generated from it.
+// All paths are relative to directory of config file.
+type Service struct {
+	Dir         string   `yaml:"dir"`
+	Include     []string `yaml:"include,omitempty"`
+	Exclude     []string `yaml:"exclude,omitempty"`
+	Annotations []string `yaml:"annotations,omitempty"`
+
+	Transport *Transport `yaml:"transport,omitempty"`
+	Swagger   *Swagger   `yaml:"swagger,omitempty"`
+	Client    *Client    `yaml:"client,omitempty"`
+	Azure     *Azure     `yaml:"azure,omitempty"`
+	Plugins   []Plugin   `yaml:"plugins,omitempty"`
+
+	// Overrides replaces generated artifacts (error-response, logger, health, metrics, client, http-client)
+	// by Go templates. Key is a name of artifact, value is a path of template file.
+	Overrides map[string]string `yaml:"overrides,omitempty"`
+}
+
+type Transport struct {
+	Out     string `yaml:"out"`
+	Backend string `yaml:"backend,omitempty"`
+}
+
+type Swagger struct {
+	Out   string `yaml:"out"`
+	Redoc string `yaml:"redoc,omitempty"`
+}
+
+type Client struct {
+	Out string `yaml:"out"`
+	NPM string `yaml:"npm,omitempty"`
+	Go  bool   `yaml:"go,omitempty"`
+	JS  bool   `yaml:"js,omitempty"`
+	TS  bool   `yaml:"ts,omitempty"`
+}
+
+type Azure struct {
+	Out          string `yaml:"out"`
+	AppName      string `yaml:"appName,omitempty"`
+	RoutePrefix  string `yaml:"routePrefix,omitempty"`
+	LogLevel     string `yaml:"logLevel,omitempty"`
+	EnableHealth bool   `yaml:"enableHealth,omitempty"`
+}
+
+// Plugin is an external generator 'tg-<name>', which receives contract model on stdin.
+type Plugin struct {
+	Name    string            `yaml:"name"`
+	Out     string            `yaml:"out"`
+	Options map[string]string `yaml:"options,omitempty"`
+}
+
+// Find returns path of 'tg.yaml' placed next to 'go.mod' of module, which contains dir,
+// or next to 'go.work', when dir is outside of modules of workspace.
+// Empty path is returned, when there is no config file.
+func Find(dir string) (cfgPath string, err error) {
+
+	var modPath string
+	if modPath, err = mod.GoModPath(dir); err != nil {
+		return "", err
+	}
+	if modPath == "" || modPath == os.DevNull {
+		if modPath = mod.GoWorkPath(dir); modPath == "" {
+			return "", nil
+		}
+	}
+	cfgPath = filepath.Join(filepath.Dir(modPath), FileName)
+	if _, err = os.Stat(cfgPath); os.IsNotExist(err) {
+		return "", nil
+	}
+	return cfgPath, err
+}
+
+func Load(cfgPath string) (cfg *Config, err error) {
+
+	var data []byte
+	if data, err = os.ReadFile(cfgPath); err != nil {
+		return
+	}
+	cfg = &Config{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err = decoder.Decode(cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", cfgPath, err)
+	}
+	if cfg.path, err = filepath.Abs(cfgPath); err != nil {
+		return nil, err
+	}
+	for i := range cfg.Services {
+		if err = cfg.Services[i].Validate(); err != nil {
+			return nil, fmt.Errorf("%s: services[%d]: %w", cfgPath, i, err)
+		}
+		cfg.Services[i].resolve(filepath.Dir(cfgPath))
+	}
+	if cfg.Convention != nil {
+		convention := *cfg.Convention
+		convention.Dir = "."
+		if err = convention.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: convention: %w", cfgPath, err)
+		}
+	}
+	return
+}
+
+// Discover finds and loads config of module, which contains dir.
+// Nil config is returned, when there is no config file.
+func Discover(dir string) (cfg *Config, err error) {
+
+	var cfgPath string
+	if cfgPath, err = Find(dir); err != nil || cfgPath == "" {
+		return
+	}
+	return Load(cfgPath)
+}
+
+func (cfg *Config) Path() string {
+	return cfg.path
+}
+
+// Service returns service with the same directory or nil.
+func (cfg *Config) Service(dir string) *Service {
+
+	absDir, _ := filepath.Abs(dir)
+	for i := range cfg.Services {
+		if svcDir, _ := filepath.Abs(cfg.Services[i].Dir); svcDir == absDir {
+			return &cfg.Services[i]
+		}
+	}
+	return nil
+}
+
+// ServiceOf returns service of found services package dir: service from services with the same directory,
+// service by convention or, when there is no convention, service with transport in sibling directory 'transport'.
+// Config may be nil.
+func (cfg *Config) ServiceOf(dir string) (svc Service) {
+
+	if cfg != nil {
+		if cfgSvc := cfg.Service(dir); cfgSvc != nil {
+			return *cfgSvc
+		}
+	}
+	if cfg == nil || cfg.Convention == nil {
+		return Service{Dir: dir, Transport: &Transport{Out: filepath.Join(filepath.Dir(filepath.Clean(dir)), "transport")}}
+	}
+	// templates and local plugins are relative to directory of config
+	svc = cfg.Convention.clone()
+	for artifact, tmpl := range svc.Overrides {
+		if !filepath.IsAbs(tmpl) {
+			svc.Overrides[artifact] = filepath.Join(filepath.Dir(cfg.path), tmpl)
+		}
+	}
+	for i := range svc.Plugins {
+		if strings.ContainsRune(svc.Plugins[i].Name, '/') && !filepath.IsAbs(svc.Plugins[i].Name) {
+			svc.Plugins[i].Name = filepath.Join(filepath.Dir(cfg.path), svc.Plugins[i].Name)
+		}
+	}
+	svc.Dir = ""
+	svc.resolve(dir)
+	svc.Dir = dir
+	return
+}
+
+// Ifaces returns included and excluded interfaces in format of generator.NewTransport.
+func (svc Service) Ifaces() (ifaces []string) {
+
+	ifaces = append(ifaces, svc.Include...)
+	for _, iface := range svc.Exclude {
+		ifaces = append(ifaces, "!"+strings.TrimPrefix(iface, "!"))
+	}
+	return
+}
+
+// Tags returns default annotations of service. Each line has the same syntax as '// @tg' comment.
+func (svc Service) Tags() tags.DocTags {
+
+	docs := make([]string, 0, len(svc.Annotations))
+	for _, line := range svc.Annotations {
+		docs = append(docs, "// @tg "+strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "@tg")))
+	}
+	return tags.ParseTags(docs)
+}
+
+// Validate checks, that service has directory and output of each target.
+func (svc Service) Validate() error {
+
+	if svc.Dir == "" {
+		return fmt.Errorf("dir is required")
+	}
+	if len(svc.Include) != 0 && len(svc.Exclude) != 0 {
+		return fmt.Errorf("include and exclude cannot be set at same time")
+	}
+	targets := map[string]*string{}
+	if svc.Transport != nil {
+		targets["transport"] = &svc.Transport.Out
+	}
+	if svc.Swagger != nil {
+		targets["swagger"] = &svc.Swagger.Out
+	}
+	if svc.Client != nil {
+		targets["client"] = &svc.Client.Out
+	}
+	if svc.Azure != nil {
+		targets["azure"] = &svc.Azure.Out
+	}
+	for i := range svc.Plugins {
+		if svc.Plugins[i].Name == "" {
+			return fmt.Errorf("plugins[%d]: name is required", i)
+		}
+		targets["plugin "+svc.Plugins[i].Name] = &svc.Plugins[i].Out
+	}
+	for artifact, tmpl := range svc.Overrides {
+		if tmpl == "" {
+			return fmt.Errorf("overrides: %s: path of template is required", artifact)
+		}
+	}
+	for target, out := range targets {
+		if *out == "" {
+			return fmt.Errorf("%s: out is required", target)
+		}
+	}
+	return nil
+}
+
+// Resolve returns copy of service, where relative paths of services package, outputs, templates
+// and local plugins are resolved from baseDir.
+func (svc Service) Resolve(baseDir string) (resolved Service) {
+
+	resolved = svc.clone()
+	resolved.resolve(baseDir)
+	return
+}
+
+// clone returns copy of service, which shares nothing with svc.
+func (svc Service) clone() Service {
+
+	cloned := svc
+	cloned.Include = slices.Clone(svc.Include)
+	cloned.Exclude = slices.Clone(svc.Exclude)
+	cloned.Annotations = slices.Clone(svc.Annotations)
+	if svc.Transport != nil {
+		transport := *svc.Transport
+		cloned.Transport = &transport
+	}
+	if svc.Swagger != nil {
+		swagger := *svc.Swagger
+		cloned.Swagger = &swagger
+	}
+	if svc.Client != nil {
+		client := *svc.Client
+		cloned.Client = &client
+	}
+	if svc.Azure != nil {
+		azure := *svc.Azure
+		cloned.Azure = &azure
+	}
+	cloned.Plugins = nil
+	for _, plugin := range svc.Plugins {
+		plugin.Options = maps.Clone(plugin.Options)
+		cloned.Plugins = append(cloned.Plugins, plugin)
+	}
+	cloned.Overrides = maps.Clone(svc.Overrides)
+	return cloned
+}
+
+func (svc *Service) resolve(baseDir string) {
+
+	abs := func(p *string) {
+		if *p != "" && !filepath.IsAbs(*p) {
+			*p = filepath.Join(baseDir, *p)
+		}
+	}
+	abs(&svc.Dir)
+	if svc.Transport != nil {
+		abs(&svc.Transport.Out)
+	}
+	if svc.Swagger != nil {
+		abs(&svc.Swagger.Out)
+		abs(&svc.Swagger.Redoc)
+	}
+	if svc.Client != nil {
+		abs(&svc.Client.Out)
+		abs(&svc.Client.NPM)
+	}
+	if svc.Azure != nil {
+		abs(&svc.Azure.Out)
+	}
+	for artifact, tmpl := range svc.Overrides {
+		abs(&tmpl)
+		svc.Overrides[artifact] = tmpl
+	}
+	for i := range svc.Plugins {
+		abs(&svc.Plugins[i].Out)
+		if strings.ContainsRune(svc.Plugins[i].Name, '/') {
+			abs(&svc.Plugins[i].Name)
+		}
+	}
+}
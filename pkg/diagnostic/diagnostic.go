@@ -0,0 +1,91 @@
+package diagnostic
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/seniorGolang/tg/v2/pkg/astra/types"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// Diagnostic is a single problem found in services contract.
+// Rule is a stable identifier of the check, which produced it.
+// Annotation is a name of '@tg' annotation, which problem refers to, when it is known.
+type Diagnostic struct {
+	Pos        types.Position `json:"pos"`
+	Rule       string         `json:"rule"`
+	Severity   Severity       `json:"severity"`
+	Message    string         `json:"message"`
+	Annotation string         `json:"annotation,omitempty"`
+}
+
+func Errorf(pos types.Position, rule, format string, args ...any) Diagnostic {
+	return Diagnostic{Pos: pos, Rule: rule, Severity: SeverityError, Message: fmt.Sprintf(format, args...)}
+}
+
+func Warnf(pos types.Position, rule, format string, args ...any) Diagnostic {
+	return Diagnostic{Pos: pos, Rule: rule, Severity: SeverityWarning, Message: fmt.Sprintf(format, args...)}
+}
+
+// String returns diagnostic in compiler-like form: 'file:line:col: severity: message [rule]'.
+// File name is relative to current directory, when possible.
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s: %s [%s]", d.relative().Pos, d.Severity, d.Message, d.Rule)
+}
+
+// relative returns diagnostic with file name relative to current directory, when possible.
+func (d Diagnostic) relative() Diagnostic {
+
+	if wd, err := os.Getwd(); err == nil && filepath.IsAbs(d.Pos.Filename) {
+		if rel, err := filepath.Rel(wd, d.Pos.Filename); err == nil && !strings.HasPrefix(rel, "..") {
+			d.Pos.Filename = rel
+		}
+	}
+	return d
+}
+
+type List []Diagnostic
+
+func (l List) HasErrors() bool {
+	for _, d := range l {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+func (l List) Count(severity Severity) (count int) {
+	for _, d := range l {
+		if d.Severity == severity {
+			count++
+		}
+	}
+	return
+}
+
+// Sort orders diagnostics by file, line, column and message.
+func (l List) Sort() {
+	sort.SliceStable(l, func(i, j int) bool {
+		if l[i].Pos.Filename != l[j].Pos.Filename {
+			return l[i].Pos.Filename < l[j].Pos.Filename
+		}
+		if l[i].Pos.Line != l[j].Pos.Line {
+			return l[i].Pos.Line < l[j].Pos.Line
+		}
+		if l[i].Pos.Column != l[j].Pos.Column {
+			return l[i].Pos.Column < l[j].Pos.Column
+		}
+		return l[i].Message < l[j].Message
+	})
+}
@@ -0,0 +1,68 @@
+package diagnostic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/scanner"
+	"io"
+	"path/filepath"
+
+	"github.com/seniorGolang/tg/v2/pkg/astra/types"
+)
+
+// Formats of diagnostics output.
+const (
+	FormatText  = "text"
+	FormatJSON  = "json"
+	FormatSARIF = "sarif"
+)
+
+// FromError returns diagnostics of error, which stops parsing of services. Positions of syntax errors are kept,
+// other errors are reported without position.
+func FromError(rule string, err error) (diags List) {
+
+	var syntaxErrors scanner.ErrorList
+	if errors.As(err, &syntaxErrors) {
+		for _, syntaxErr := range syntaxErrors {
+			pos := types.Position{Filename: syntaxErr.Pos.Filename, Line: syntaxErr.Pos.Line, Column: syntaxErr.Pos.Column}
+			diags = append(diags, Errorf(pos, rule, "%s", syntaxErr.Msg))
+		}
+		return
+	}
+	return List{Errorf(types.Position{}, rule, "%v", err)}
+}
+
+// Write writes diagnostics in format: text is one diagnostic per line, json is array of diagnostics
+// and sarif is SARIF 2.1.0 log of tool. File names are relative to current directory, when possible.
+func (l List) Write(w io.Writer, format, toolVersion string) (err error) {
+
+	if format == FormatText {
+		for _, d := range l {
+			if _, err = fmt.Fprintln(w, d); err != nil {
+				return
+			}
+		}
+		return
+	}
+	relative := make(List, 0, len(l))
+	for _, d := range l {
+		d = d.relative()
+		d.Pos.Filename = filepath.ToSlash(d.Pos.Filename)
+		relative = append(relative, d)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	switch format {
+	case FormatJSON:
+		return encoder.Encode(relative)
+	case FormatSARIF:
+		var base string
+		if base, err = filepath.Abs("."); err != nil {
+			return
+		}
+		return encoder.Encode(relative.sarif(toolVersion, base))
+	}
+	return fmt.Errorf("unknown format of diagnostics '%s', expected %s, %s or %s", format, FormatText, FormatJSON, FormatSARIF)
+}
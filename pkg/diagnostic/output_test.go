@@ -0,0 +1,77 @@
+package diagnostic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"testing"
+
+	"github.com/seniorGolang/tg/v2/pkg/astra/types"
+)
+
+func TestWrite(t *testing.T) {
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+	_, parseErr := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, "service", "broken.go"), "package service\nfunc broken(", 0)
+	diags := append(List{
+		Warnf(types.Position{Filename: filepath.Join(dir, "service", "user.go"), Line: 12, Column: 6}, "unknown-annotation", "unknown annotation 'logs'"),
+		Errorf(types.Position{}, "render", "renderHTTP: <failed>"),
+	}, FromError("parse", fmt.Errorf("parse: %w", parseErr))...)
+
+	var out bytes.Buffer
+	if err := diags.Write(&out, FormatJSON, "v2"); err != nil {
+		t.Fatal(err)
+	}
+	var decoded []Diagnostic
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != 3 || decoded[0].Pos.Filename != "service/user.go" || decoded[2].Pos != (types.Position{Filename: "service/broken.go", Line: 2, Column: 13}) || decoded[2].Rule != "parse" {
+		t.Fatalf("unexpected diagnostics: %+v", decoded)
+	}
+	if !bytes.Contains(out.Bytes(), []byte("<failed>")) {
+		t.Fatalf("message is escaped: %s", out.String())
+	}
+
+	out.Reset()
+	if err := diags.Write(&out, FormatSARIF, "v2"); err != nil {
+		t.Fatal(err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(out.Bytes(), &log); err != nil {
+		t.Fatal(err)
+	}
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != 3 || len(run.Results) != 3 {
+		t.Fatalf("unexpected run: %+v", run)
+	}
+	result := run.Results[0]
+	location := result.Locations[0].PhysicalLocation
+	if result.Level != "warning" || run.Tool.Driver.Rules[result.RuleIndex].ID != "unknown-annotation" ||
+		location.ArtifactLocation != (sarifArtifactURI{URI: "service/user.go", URIBaseID: sarifRoot}) || *location.Region != (sarifRegion{StartLine: 12, StartColumn: 6}) {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if len(run.Results[1].Locations) != 0 {
+		t.Fatalf("diagnostic without position has location: %+v", run.Results[1])
+	}
+	if err := diags.Write(&out, "xml", "v2"); err == nil {
+		t.Fatal("unknown format is accepted")
+	}
+}
+
+func TestFileURI(t *testing.T) {
+
+	for path, expected := range map[string]string{
+		"/work/api":         "file:///work/api",
+		"C:/work/api":       "file:///C:/work/api",
+		"/work/my api/a.go": "file:///work/my%20api/a.go",
+	} {
+		if uri := fileURI(path); uri != expected {
+			t.Fatalf("uri of %s is %s, expected %s", path, uri, expected)
+		}
+	}
+}
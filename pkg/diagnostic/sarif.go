@@ -0,0 +1,129 @@
+package diagnostic
+
+import (
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	toolName     = "tg"
+	sarifToolURI = "https://github.com/seniorGolang/tg"
+	sarifRoot    = "SRCROOT"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool               sarifTool                   `json:"tool"`
+	OriginalURIBaseIDs map[string]sarifArtifactURI `json:"originalUriBaseIds"`
+	Results            []sarifResult               `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactURI `json:"artifactLocation"`
+	Region           *sarifRegion     `json:"region,omitempty"`
+}
+
+type sarifArtifactURI struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+var sarifLevels = map[Severity]string{
+	SeverityError:   "error",
+	SeverityWarning: "warning",
+	SeverityInfo:    "note",
+}
+
+// sarif returns log of diagnostics, relative file names are resolved from base directory.
+func (l List) sarif(toolVersion, base string) sarifLog {
+
+	rules := make(map[string]int)
+	var ruleIDs []string
+	for _, d := range l {
+		if _, found := rules[d.Rule]; !found {
+			rules[d.Rule] = 0
+			ruleIDs = append(ruleIDs, d.Rule)
+		}
+	}
+	sort.Strings(ruleIDs)
+	driver := sarifDriver{Name: toolName, Version: toolVersion, InformationURI: sarifToolURI, Rules: make([]sarifRule, 0, len(ruleIDs))}
+	for i, id := range ruleIDs {
+		rules[id] = i
+		driver.Rules = append(driver.Rules, sarifRule{ID: id})
+	}
+	run := sarifRun{
+		Tool:               sarifTool{Driver: driver},
+		OriginalURIBaseIDs: map[string]sarifArtifactURI{sarifRoot: {URI: fileURI(base) + "/"}},
+		Results:            make([]sarifResult, 0, len(l)),
+	}
+	for _, d := range l {
+		result := sarifResult{RuleID: d.Rule, RuleIndex: rules[d.Rule], Level: sarifLevels[d.Severity], Message: sarifMessage{Text: d.Message}}
+		if d.Pos.Filename != "" {
+			location := sarifPhysicalLocation{ArtifactLocation: sarifArtifactURI{URI: d.Pos.Filename, URIBaseID: sarifRoot}}
+			if filepath.IsAbs(filepath.FromSlash(d.Pos.Filename)) {
+				location.ArtifactLocation = sarifArtifactURI{URI: fileURI(d.Pos.Filename)}
+			}
+			if d.Pos.IsValid() {
+				location.Region = &sarifRegion{StartLine: d.Pos.Line, StartColumn: d.Pos.Column}
+			}
+			result.Locations = []sarifLocation{{PhysicalLocation: location}}
+		}
+		run.Results = append(run.Results, result)
+	}
+	return sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}}
+}
+
+// fileURI returns URI of absolute path, path with volume name 'C:/dir' becomes 'file:///C:/dir'.
+func fileURI(path string) string {
+
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
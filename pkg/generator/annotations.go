@@ -0,0 +1,129 @@
+package generator
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/seniorGolang/tg/v2/pkg/astra/types"
+	"github.com/seniorGolang/tg/v2/pkg/diagnostic"
+	"github.com/seniorGolang/tg/v2/pkg/tags"
+)
+
+type annotationsChecker struct {
+	tr      *Transport
+	diags   diagnostic.List
+	seen    map[string]bool
+	visited map[string]bool
+	// resolve enables checking of types, which are used by declarations
+	resolve bool
+}
+
+func newAnnotationsChecker(tr *Transport, resolve bool) *annotationsChecker {
+	return &annotationsChecker{tr: tr, seen: make(map[string]bool), visited: make(map[string]bool), resolve: resolve}
+}
+
+// CheckAnnotations validates annotations of package, interfaces, methods and types of their arguments and results
+// by registry of annotations: unknown names, values of wrong type and annotations at wrong level are reported.
+func (tr *Transport) CheckAnnotations() diagnostic.List {
+
+	checker := newAnnotationsChecker(tr, true)
+	tr.checkAnnotations(checker)
+	checker.diags.Sort()
+	return checker.diags
+}
+
+func (tr *Transport) checkAnnotations(checker *annotationsChecker) {
+
+	for _, pkgDoc := range tr.pkgDocs {
+		checker.report(pkgDoc.Pos, "package "+pkgDoc.Name, pkgDoc.Docs, tags.LevelPackage)
+	}
+	for _, serviceName := range tr.serviceKeys() {
+		svc := tr.services[serviceName]
+		checker.report(svc.Pos, svc.Name, svc.Docs, tags.LevelInterface)
+		for _, method := range svc.methods {
+			vars := slices.Concat(method.argsWithoutContext(), method.resultsWithoutError())
+			names := make([]string, 0, len(vars))
+			for _, v := range vars {
+				names = append(names, v.Name)
+			}
+			checker.report(method.Pos, fmt.Sprintf("%s.%s", svc.Name, method.Name), method.Docs, tags.LevelMethod, names...)
+			for _, v := range vars {
+				checker.walk(svc.pkgPath, v.Type)
+			}
+		}
+	}
+}
+
+func (checker *annotationsChecker) report(pos types.Position, where string, docs []string, level tags.Level, vars ...string) {
+
+	for _, problem := range tags.Validate(tags.ParseTags(docs), level, vars...) {
+		d := diagnostic.Warnf(pos, problem.Rule, "%s: %s", where, problem.Message)
+		if problem.Error {
+			d = diagnostic.Errorf(pos, problem.Rule, "%s: %s", where, problem.Message)
+		}
+		d.Annotation = problem.Name
+		// methods of embedded interface are shared by services
+		if key := fmt.Sprint(pos, d.Message); !checker.seen[key] {
+			checker.seen[key] = true
+			checker.diags = append(checker.diags, d)
+		}
+	}
+}
+
+// walk checks annotations of types, which are reachable from varType.
+func (checker *annotationsChecker) walk(pkg string, varType types.Type) {
+
+	if !checker.resolve {
+		return
+	}
+	switch vType := varType.(type) {
+	case types.TName:
+		if !types.IsBuiltin(vType) {
+			checker.named(pkg, vType.TypeName)
+		}
+	case types.TImport:
+		if name, ok := vType.Next.(types.TName); ok && vType.Import != nil {
+			checker.named(vType.Import.Package, name.TypeName)
+		}
+	case types.TPointer:
+		checker.walk(pkg, vType.Next)
+	case types.TArray:
+		checker.walk(pkg, vType.Next)
+	case types.TEllipsis:
+		checker.walk(pkg, vType.Next)
+	case types.TMap:
+		checker.walk(pkg, vType.Key)
+		checker.walk(pkg, vType.Value)
+	case types.TInstance:
+		checker.walk(pkg, vType.Next)
+		for _, arg := range vType.Args {
+			checker.walk(pkg, arg)
+		}
+	case types.Struct:
+		checker.fields(pkg, vType)
+	}
+}
+
+func (checker *annotationsChecker) named(pkg, name string) {
+
+	key := pkg + "." + name
+	if checker.visited[key] || isStdPackage(pkg) {
+		return
+	}
+	checker.visited[key] = true
+	nextType, _ := checker.tr.searchTypeWithConstants(pkg, name)
+	if structType, ok := nextType.(types.Struct); ok {
+		checker.report(structType.Pos, name, structType.Docs, tags.LevelType)
+		checker.fields(pkg, structType)
+		return
+	}
+	checker.walk(pkg, nextType)
+}
+
+func (checker *annotationsChecker) fields(pkg string, structType types.Struct) {
+
+	for _, field := range structType.Fields {
+		checker.report(field.Pos, structType.Name+"."+field.Name, field.Docs, tags.LevelType)
+		checker.walk(pkg, field.Type)
+	}
+}
@@ -0,0 +1,74 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/seniorGolang/tg/v2/pkg/diagnostic"
+	"github.com/seniorGolang/tg/v2/pkg/tags"
+)
+
+func TestCheckAnnotations(t *testing.T) {
+
+	files := map[string]string{
+		"go.mod": "module example.com/api\n\ngo 1.22\n",
+		"dto/user.go": `package dto
+
+type User struct {
+	// @tg requried
+	Name string ` + "`json:\"name\"`" + `
+}
+`,
+		"service/user.go": `// @tg version=1.0.0 titel=API
+package service
+
+import (
+	"context"
+
+	"example.com/api/dto"
+)
+
+// @tg jsonRPC-sever http-prefix=api
+type Users interface {
+	// @tg http-mehtod=GET
+	// @tg http-method=FETCH http-path=/user/:id
+	// @tg trace usr.tags=json:u
+	Get(ctx context.Context, id int) (user dto.User, err error)
+	// @tg http-headers=token|X-Token 404=skip
+	Save(ctx context.Context, token string) (err error)
+}
+`,
+	}
+	writeProject(t, files)
+	tr, err := NewTransport(logrus.New(), "test", "service")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	diags := tr.CheckAnnotations()
+	expected := []struct {
+		rule     string
+		severity diagnostic.Severity
+		message  string
+	}{
+		{rule: tags.RuleUnknown, severity: diagnostic.SeverityWarning, message: "User.Name: unknown annotation 'requried', did you mean 'required'?"},
+		{rule: tags.RuleUnknown, severity: diagnostic.SeverityWarning, message: "package service: unknown annotation 'titel', did you mean 'title'?"},
+		{rule: tags.RuleUnknown, severity: diagnostic.SeverityWarning, message: "Users: unknown annotation 'jsonRPC-sever', did you mean 'jsonRPC-server'?"},
+		{rule: tags.RuleValue, severity: diagnostic.SeverityError, message: "Users.Get: annotation 'http-method': 'FETCH' is not one of GET, POST, PUT, PATCH, DELETE, OPTIONS"},
+		{rule: tags.RuleLevel, severity: diagnostic.SeverityWarning, message: "Users.Get: annotation 'trace' is not applicable to method, it is applicable to package, interface"},
+		{rule: tags.RuleUnknown, severity: diagnostic.SeverityWarning, message: "Users.Get: annotation 'usr.tags' refers to unknown variable 'usr', did you mean 'user'?"},
+		{rule: tags.RuleUnknown, severity: diagnostic.SeverityWarning, message: "Users.Get: unknown annotation 'http-mehtod', did you mean 'http-method'?"},
+	}
+	if len(diags) != len(expected) {
+		t.Fatalf("expected %d diagnostics, got %d:\n%v", len(expected), len(diags), diags)
+	}
+	for i, d := range diags {
+		if d.Rule != expected[i].rule || d.Severity != expected[i].severity || d.Message != expected[i].message {
+			t.Fatalf("unexpected diagnostic %d: %s", i, d)
+		}
+		if !strings.HasSuffix(d.Pos.Filename, ".go") || !d.Pos.IsValid() {
+			t.Fatalf("diagnostic has no position: %s", d)
+		}
+	}
+}
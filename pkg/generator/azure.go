@@ -48,10 +48,10 @@ func (app *azure) render(appName, routePrefix, outFilePath, logLevel string, ena
 				},
 			}
 			outFileName := path.Join(outFilePath, appName, utils.ToLowerCamel(svcName)+svcMethod.Name, "function.json")
-			if err = os.MkdirAll(filepath.Dir(outFileName), 0777); err != nil {
+			if err = app.output().MkdirAll(filepath.Dir(outFileName), 0777); err != nil {
 				return
 			}
-			if err = os.WriteFile(outFileName, toJSON(fn), 0600); err != nil {
+			if err = app.output().WriteFile(outFileName, toJSON(fn), 0600); err != nil {
 				return
 			}
 		}
@@ -90,10 +90,10 @@ func (app *azure) render(appName, routePrefix, outFilePath, logLevel string, ena
 	}
 	outFileName := path.Join(outFilePath, appName, "host.json")
 	if _, fsErr := os.Stat(outFileName); os.IsNotExist(fsErr) {
-		if err = os.MkdirAll(filepath.Dir(outFileName), 0777); err != nil {
+		if err = app.output().MkdirAll(filepath.Dir(outFileName), 0777); err != nil {
 			return
 		}
-		return os.WriteFile(outFileName, toJSON(host), 0600)
+		return app.output().WriteFile(outFileName, toJSON(host), 0600)
 	}
 	return
 }
@@ -0,0 +1,285 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+)
+
+// Backends of generated transport: HTTP framework, which server is built on.
+const (
+	BackendFiber   = "fiber"
+	BackendFiberV3 = "fiber3"
+	BackendNetHTTP = "nethttp"
+)
+
+var backends = []string{BackendFiber, BackendFiberV3, BackendNetHTTP}
+
+// aliasNetHTTP is a name of 'net/http' in generated files of services, where 'http' is a name of receiver.
+const aliasNetHTTP = "nethttp"
+
+// SetBackend selects HTTP framework of generated transport. Empty backend is BackendFiber.
+// Services and clients are the same for all backends.
+func (tr *Transport) SetBackend(backend string) error {
+
+	if backend != "" && !slices.Contains(backends, backend) {
+		return fmt.Errorf("unknown backend '%s', expected one of: %s", backend, strings.Join(backends, ", "))
+	}
+	tr.state.backend = backend
+	return nil
+}
+
+func (tr *Transport) backend() string {
+
+	if tr.state.backend == "" {
+		return BackendFiber
+	}
+	return tr.state.backend
+}
+
+func (tr *Transport) isNetHTTP() bool {
+	return tr.backend() == BackendNetHTTP
+}
+
+func (tr *Transport) isFiberV3() bool {
+	return tr.backend() == BackendFiberV3
+}
+
+// fiberPkg returns import path of fiber of backend.
+func (tr *Transport) fiberPkg() string {
+
+	if tr.isFiberV3() {
+		return packageFiberV3
+	}
+	return packageFiber
+}
+
+func (tr *Transport) fiberAdaptor() string {
+
+	if tr.isFiberV3() {
+		return packageFiberV3Adaptor
+	}
+	return packageFiberAdaptor
+}
+
+// fiberCtx returns parameter of fiber handler by name ftx: context of fiber v3 is an interface.
+func (tr *Transport) fiberCtx(ftx string) *Statement {
+
+	if tr.isFiberV3() {
+		return Id(ftx).Qual(packageFiberV3, "Ctx")
+	}
+	return Id(ftx).Op("*").Qual(packageFiber, "Ctx")
+}
+
+// fiberQuietConfig returns config of fiber application without startup message, fiber v3 disables it on listen.
+func (tr *Transport) fiberQuietConfig() Code {
+
+	if tr.isFiberV3() {
+		return Qual(packageFiberV3, "Config").Values()
+	}
+	return Qual(packageFiber, "Config").Values(Dict{Id("DisableStartupMessage"): True()})
+}
+
+func (tr *Transport) fiberListen(app *Statement) Code {
+
+	if tr.isFiberV3() {
+		return app.Dot("Listen").Call(Id("address"), Qual(packageFiberV3, "ListenConfig").Values(Dict{Id("DisableStartupMessage"): True()}))
+	}
+	return app.Dot("Listen").Call(Id("address"))
+}
+
+func (tr *Transport) bodyParser(ftx string, out Code) Code {
+
+	if tr.isFiberV3() {
+		return Id(ftx).Dot("Bind").Call().Dot("Body").Call(out)
+	}
+	return Id(ftx).Dot("BodyParser").Call(out)
+}
+
+func (tr *Transport) redirect(ftx string, location Code) Code {
+
+	if tr.isFiberV3() {
+		return Id(ftx).Dot("Redirect").Call().Dot("To").Call(location)
+	}
+	return Id(ftx).Dot("Redirect").Call(location)
+}
+
+// exchangeParams returns parameters of handler, which receive request and response of HTTP framework.
+// Fiber handlers receive context by name ftx.
+func (tr *Transport) exchangeParams(ftx string) []Code {
+
+	if tr.isNetHTTP() {
+		return []Code{Id("w").Qual(packageHttp, "ResponseWriter"), Id("r").Op("*").Qual(packageHttp, "Request")}
+	}
+	return []Code{tr.fiberCtx(ftx)}
+}
+
+// exchangeArgs returns arguments for parameters of exchangeParams.
+func (tr *Transport) exchangeArgs(ftx string) []Code {
+
+	if tr.isNetHTTP() {
+		return []Code{Id("w"), Id("r")}
+	}
+	return []Code{Id(ftx)}
+}
+
+// exchangeNil returns arguments for parameters of exchangeParams, when there is no HTTP request (parallel batch).
+func (tr *Transport) exchangeNil() []Code {
+
+	if tr.isNetHTTP() {
+		return []Code{Nil(), Nil()}
+	}
+	return []Code{Nil()}
+}
+
+// hasExchange returns condition, which is true when handler has HTTP request.
+func (tr *Transport) hasExchange(ftx string) Code {
+
+	if tr.isNetHTTP() {
+		return Id("r").Op("!=").Nil()
+	}
+	return Id(ftx).Op("!=").Nil()
+}
+
+func (tr *Transport) userContext(ftx string) Code {
+
+	if tr.isNetHTTP() {
+		return Id("r").Dot("Context").Call()
+	}
+	if tr.isFiberV3() {
+		return Id(ftx).Dot("Context").Call()
+	}
+	return Id(ftx).Dot("UserContext").Call()
+}
+
+// requestCtx returns request of fasthttp by context of fiber.
+func (tr *Transport) requestCtx(ftx string) Code {
+
+	if tr.isFiberV3() {
+		return Id(ftx).Dot("RequestCtx").Call()
+	}
+	return Id(ftx).Dot("Context").Call()
+}
+
+func (tr *Transport) setUserContext(ftx string, ctx Code) Code {
+
+	if tr.isFiberV3() {
+		return Id(ftx).Dot("SetContext").Call(ctx)
+	}
+	return Id(ftx).Dot("SetUserContext").Call(ctx)
+}
+
+func (tr *Transport) requestHeader(ftx string, name Code) Code {
+
+	if tr.isNetHTTP() {
+		return Id("r").Dot("Header").Dot("Get").Call(name)
+	}
+	return String().Call(Id(ftx).Dot("Request").Call().Dot("Header").Dot("Peek").Call(name))
+}
+
+func (tr *Transport) requestCookie(ftx string, name Code) Code {
+
+	if tr.isNetHTTP() {
+		return Id("cookieValue").Call(Id("r"), name)
+	}
+	return Id(ftx).Dot("Cookies").Call(name)
+}
+
+func (tr *Transport) pathParam(ftx string, name Code) Code {
+
+	if tr.isNetHTTP() {
+		return Id("r").Dot("PathValue").Call(name)
+	}
+	return Id(ftx).Dot("Params").Call(name)
+}
+
+func (tr *Transport) queryParam(ftx string, name Code) Code {
+
+	if tr.isNetHTTP() {
+		return Id("r").Dot("URL").Dot("Query").Call().Dot("Get").Call(name)
+	}
+	return Id(ftx).Dot("Query").Call(name)
+}
+
+func (tr *Transport) setResponseHeader(ftx string, name, value Code) Code {
+
+	if tr.isNetHTTP() {
+		return Id("w").Dot("Header").Call().Dot("Set").Call(name, value)
+	}
+	return Id(ftx).Dot("Set").Call(name, value)
+}
+
+func (tr *Transport) setResponseCookie(ftx string, cookie Code) Code {
+
+	if tr.isNetHTTP() {
+		return Qual(packageHttp, "SetCookie").Call(Id("w"), cookie)
+	}
+	return Id(ftx).Dot("Cookie").Call(cookie)
+}
+
+// httpStatus returns constant of HTTP status by name, for example 'StatusInternalServerError'.
+func (tr *Transport) httpStatus(name string) Code {
+
+	if tr.isNetHTTP() {
+		return Qual(packageHttp, name)
+	}
+	return Qual(tr.fiberPkg(), name)
+}
+
+// muxSegment matches segment of path, which pattern of http.ServeMux expresses: literal or whole segment placeholder ':name'.
+var muxSegment = regexp.MustCompile(`^(:[A-Za-z_][A-Za-z0-9_]*|[^:*?+{}]*)$`)
+
+// muxUnsupported returns segment of path, which has no equivalent in pattern of http.ServeMux:
+// optional placeholder ':id?', wildcards '*' and '+', placeholder with suffix ':id.json' or several placeholders ':a-:b'.
+func muxUnsupported(routePath string) (segment string, found bool) {
+
+	for _, segment = range strings.Split(routePath, "/") {
+		if !muxSegment.MatchString(segment) {
+			return segment, true
+		}
+	}
+	return "", false
+}
+
+// muxPattern converts path of route to pattern of http.ServeMux: '/users/:id' is '{METHOD} /users/{id}',
+// path with trailing slash matches only itself.
+func muxPattern(method, routePath string) string {
+
+	segments := strings.Split(routePath, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "{" + strings.TrimPrefix(segment, ":") + "}"
+		}
+	}
+	pattern := strings.Join(segments, "/")
+	if strings.HasSuffix(pattern, "/") {
+		pattern += "{$}"
+	}
+	return strings.ToUpper(method) + " " + pattern
+}
+
+// handlerParams returns parameters of JSON-RPC method handler: context, request and response of HTTP framework
+// and request of JSON-RPC by name.
+func (tr *Transport) handlerParams(request string) []Code {
+	return slices.Concat([]Code{Id("userCtx").Qual(packageContext, "Context")}, tr.exchangeParams("ftx"), []Code{Id(request).Id("baseJsonRPC")})
+}
+
+// handlerArgs returns arguments for parameters of handlerParams.
+func (tr *Transport) handlerArgs(request string) []Code {
+	return slices.Concat([]Code{Id("userCtx")}, tr.exchangeArgs("ftx"), []Code{Id(request)})
+}
+
+// tracerPkg returns embedded package of tracer middleware for backend, it is copied into 'tracer' of transport.
+func (tr *Transport) tracerPkg() string {
+
+	switch {
+	case tr.isNetHTTP():
+		return "nethttp/tracer"
+	case tr.isFiberV3():
+		return "fiber3/tracer"
+	}
+	return "tracer"
+}
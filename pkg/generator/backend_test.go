@@ -0,0 +1,150 @@
+package generator
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/seniorGolang/tg/v2/pkg/config"
+)
+
+func generateBackend(t *testing.T, backend string) *MemFS {
+
+	files := map[string]string{
+		"go.mod": "module example.com/api\n\ngo 1.22\n",
+		"service/user.go": `// @tg version=1.0.0
+// @tg jsonRPC-ws=/ws
+package service
+
+import "context"
+
+// @tg jsonRPC-server log trace metrics
+type User interface {
+	GetName(ctx context.Context, id int) (name string, err error)
+}
+
+// @tg http-server
+type Shop interface {
+	// @tg http-method=GET
+	// @tg http-path=/item/:id
+	Item(ctx context.Context, id string) (name string, err error)
+}
+`,
+	}
+	dir := writeProject(t, files)
+	memFS := NewMemFS(dir)
+	_, err := Generate(Config{
+		Services: []config.Service{{
+			Dir:       "service",
+			Transport: &config.Transport{Out: "transport", Backend: backend},
+		}},
+		Output: memFS,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return memFS
+}
+
+func assertGenerated(t *testing.T, memFS *MemFS, forbidden string, want map[string]string) {
+
+	for _, name := range memFS.Files() {
+		data, err := fs.ReadFile(memFS, name)
+		if err != nil {
+			t.Fatalf("read %s: %v", name, err)
+		}
+		if strings.Contains(string(data), forbidden) {
+			t.Fatalf("%s depends on %s", name, forbidden)
+		}
+	}
+	for name, code := range want {
+		data, err := fs.ReadFile(memFS, name)
+		if err != nil {
+			t.Fatalf("%s is not generated: %v", name, err)
+		}
+		if !strings.Contains(string(data), code) {
+			t.Fatalf("%s does not contain %q:\n%s", name, code, data)
+		}
+	}
+}
+
+func TestGenerateNetHTTP(t *testing.T) {
+
+	assertGenerated(t, generateBackend(t, BackendNetHTTP), "gofiber", map[string]string{
+		"transport/batch.go":          "w.WriteHeader(http.StatusNoContent)",
+		"transport/server.go":         "func (srv *Server) ServeHTTP(w http.ResponseWriter, r *http.Request)",
+		"transport/middleware.go":     "func recoverHandler(next http.Handler) http.Handler",
+		"transport/shop-http.go":      `route.HandleFunc("GET /item/{id}", http.serveItem)`,
+		"transport/tracer/nethttp.go": "func Middleware(opts ...Option) func(next http.Handler) http.Handler",
+		"transport/websocket.go":      "conn, err := srv.wsUpgrader.Upgrade(w, r, nil)",
+	})
+}
+
+func TestGenerateFiberV3(t *testing.T) {
+
+	assertGenerated(t, generateBackend(t, BackendFiberV3), "fiber/v2", map[string]string{
+		"transport/fiber.go":        "func recoverHandler(ctx fiber.Ctx) error",
+		"transport/shop-rest.go":    "func (http *httpShop) serveItem(ctx fiber.Ctx) (err error)",
+		"transport/metrics.go":      `"github.com/gofiber/fiber/v3/middleware/adaptor"`,
+		"transport/tracer/fiber.go": "func Middleware(opts ...Option) fiber.Handler",
+		"transport/websocket.go":    "return srv.wsUpgrader.Upgrade(ctx.RequestCtx(), func(conn *websocket.Conn) {",
+	})
+}
+
+func TestSetBackend(t *testing.T) {
+
+	var tr Transport
+	tr.state = &renderState{}
+	if err := tr.SetBackend("gin"); err == nil || !strings.Contains(err.Error(), "fiber, fiber3, nethttp") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := tr.SetBackend(""); err != nil || tr.backend() != BackendFiber {
+		t.Fatalf("default backend is %s: %v", tr.backend(), err)
+	}
+}
+
+func TestCheckNetHTTPPaths(t *testing.T) {
+
+	files := map[string]string{
+		"go.mod": "module example.com/api\n\ngo 1.22\n",
+		"service/files.go": `package service
+
+import "context"
+
+// @tg http-server
+type Files interface {
+	// @tg http-method=GET
+	// @tg http-path=/item/:id
+	Item(ctx context.Context, id string) (name string, err error)
+	// @tg http-method=GET
+	// @tg http-path=/optional/:id?
+	Optional(ctx context.Context, id string) (name string, err error)
+	// @tg http-method=GET
+	// @tg http-path=/files/*
+	Files(ctx context.Context) (names []string, err error)
+	// @tg http-method=GET
+	// @tg http-path=/range/:from-:to
+	Range(ctx context.Context, from string, to string) (names []string, err error)
+	// @tg http-method=GET
+	// @tg http-path=/doc/:id.json
+	Doc(ctx context.Context, id string) (name string, err error)
+}
+`,
+	}
+	dir := writeProject(t, files)
+	for backend, expected := range map[string]int{BackendFiber: 0, BackendNetHTTP: 4} {
+		result, err := Generate(Config{
+			Services: []config.Service{{
+				Dir:       "service",
+				Transport: &config.Transport{Out: "transport", Backend: backend},
+			}},
+			Output: NewMemFS(dir),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if rules := checkRules(result.Diagnostics); rules[ruleNetHTTPPath] != expected {
+			t.Fatalf("%s: expected %d unsupported paths: %v", backend, expected, result.Diagnostics)
+		}
+	}
+}
@@ -4,6 +4,8 @@
 package generator
 
 import (
+	"slices"
+
 	. "github.com/dave/jennifer/jen" // nolint:staticcheck
 )
 
@@ -20,24 +22,34 @@ func batchJobType() (code Code) {
 	)
 }
 
-func batchDoFunc(target batchTarget) (code Code) {
+func (tr *Transport) batchDoFunc(target batchTarget) (code Code) {
 
 	recv := func() *Statement { return Id(target.receiver) }
+	var syncOn Code = Id(_ctx_).Dot("Get").Call(Lit(syncHeader))
+	if tr.isNetHTTP() {
+		syncOn = tr.requestHeader(_ctx_, Lit(syncHeader))
+	}
 
 	return Func().Params(recv().Op("*").Id(target.receiverType)).Id("doBatch").
-		Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx"), Id("requests").Op("[]").Id("baseJsonRPC")).
+		Params(append(tr.exchangeParams(_ctx_), Id("requests").Op("[]").Id("baseJsonRPC"))...).
 		Params(Id("responses").Id("jsonrpcResponses")).
 		BlockFunc(func(bg *Group) {
 
 			bg.If(Len(Id("requests")).Op(">").Add(recv().Dot("maxBatchSize"))).Block(
-				Id("responses").Dot("append").Call(Id("makeErrorResponseJsonRPC").Call(Nil(), Id("invalidRequestError"), Lit("batch size exceeded"), Nil())),
+				Id("responses").Op("=").Id("jsonrpcResponses").Values(Op("&").Id("baseJsonRPC").Values(Dict{
+					Id("Version"): Id("Version"),
+					Id("Error"): Op("&").Id("errorJsonRPC").Values(Dict{
+						Id("Code"):    Id("invalidRequestError"),
+						Id("Message"): Lit("batch size exceeded"),
+					}),
+				})),
 				Return(),
 			)
-			bg.Id("userCtx").Op(":=").Id(_ctx_).Dot("UserContext").Call()
-			bg.If(Qual(packageStrings, "EqualFold").Call(Id(_ctx_).Dot("Get").Call(Lit(syncHeader)), Lit("true"))).Block(
+			bg.Id("userCtx").Op(":=").Add(tr.userContext(_ctx_))
+			bg.If(Qual(packageStrings, "EqualFold").Call(syncOn, Lit("true"))).Block(
 				Id("results").Op(":=").Make(Index().Op("*").Id("baseJsonRPC"), Len(Id("requests"))),
 				For(List(Id("idx"), Id("request")).Op(":=").Range().Id("requests")).Block(
-					Id("response").Op(":=").Add(recv().Dot("doSingleBatch").Call(Id("userCtx"), Id(_ctx_), Id("request"))),
+					Id("response").Op(":=").Add(recv().Dot("doSingleBatch").Call(slices.Concat([]Code{Id("userCtx")}, tr.exchangeArgs(_ctx_), []Code{Id("request")})...)),
 					If(Id("request").Dot("ID").Op("!=").Nil()).Block(
 						Id("results").Index(Id("idx")).Op("=").Id("response"),
 					),
@@ -48,6 +60,20 @@ func batchDoFunc(target batchTarget) (code Code) {
 				),
 				Return(),
 			)
+			bg.Return(recv().Dot("doParallelBatch").Call(Id("userCtx"), Id("requests")))
+		})
+}
+
+// batchParallelFunc returns function, which executes requests of batch by pool of workers without HTTP request.
+func (tr *Transport) batchParallelFunc(target batchTarget) (code Code) {
+
+	recv := func() *Statement { return Id(target.receiver) }
+
+	return Func().Params(recv().Op("*").Id(target.receiverType)).Id("doParallelBatch").
+		Params(Id("userCtx").Qual(packageContext, "Context"), Id("requests").Op("[]").Id("baseJsonRPC")).
+		Params(Id("responses").Id("jsonrpcResponses")).
+		BlockFunc(func(bg *Group) {
+
 			bg.Var().Id("wg").Qual(packageSync, "WaitGroup")
 			bg.Id("workers").Op(":=").Add(recv().Dot("maxParallelBatch"))
 			bg.If(Len(Id("requests")).Op("<").Id("workers")).Block(
@@ -60,7 +86,7 @@ func batchDoFunc(target batchTarget) (code Code) {
 				Go().Func().Params().Block(
 					Defer().Id("wg").Dot("Done").Call(),
 					For(Id("job").Op(":=").Range().Id("jobs")).Block(
-						Id("response").Op(":=").Add(recv().Dot("doSingleBatch").Call(Id("userCtx"), Nil(), Id("job").Dot("request"))),
+						Id("response").Op(":=").Add(recv().Dot("doSingleBatch").Call(slices.Concat([]Code{Id("userCtx")}, tr.exchangeNil(), []Code{Id("job").Dot("request")})...)),
 						If(Id("job").Dot("request").Dot("ID").Op("!=").Nil()).Block(
 							Id("results").Index(Id("job").Dot("idx")).Op("=").Id("response"),
 						),
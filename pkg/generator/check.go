@@ -0,0 +1,168 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/seniorGolang/tg/v2/pkg/astra/types"
+	"github.com/seniorGolang/tg/v2/pkg/diagnostic"
+	"github.com/seniorGolang/tg/v2/pkg/tags"
+)
+
+const (
+	ruleUnnamedParam   = "unnamed-param"
+	ruleContextFirst   = "context-first"
+	ruleErrorLast      = "error-last"
+	rulePathArg        = "http-path-arg"
+	ruleUnknownVar     = "unknown-var"
+	ruleDuplicateRoute = "duplicate-route"
+	ruleNotifyResult   = "notify-result"
+	ruleNetHTTPPath    = "nethttp-path"
+)
+
+// routeParam matches parameter of route: routes are compared with parameters replaced by ":".
+var routeParam = regexp.MustCompile(`:[^/]+`)
+
+type route struct {
+	method *method
+	path   string
+}
+
+// Check validates services contract without rendering anything.
+func (tr *Transport) Check() (diags diagnostic.List) {
+
+	diags = append(tr.checkServices(), tr.CheckAnnotations()...)
+	diags.Sort()
+	return
+}
+
+// CheckFile validates file of services or types without resolving types of other files: signatures, routes
+// and annotations of services declared in file and annotations of its structures. It is fast enough for editors.
+func CheckFile(filePath string, file *types.File) (diags diagnostic.List) {
+
+	discard := logrus.New()
+	discard.SetOutput(io.Discard)
+	tr := &Transport{
+		log:      discard,
+		tags:     tags.ParseTags(file.Docs),
+		pkgDocs:  []types.Base{file.Base},
+		services: make(map[string]*service),
+		state:    &renderState{},
+	}
+	for _, iface := range file.Interfaces {
+		if len(tags.ParseTags(iface.Docs)) != 0 {
+			tr.services[iface.Name] = newService(discard, tr, filePath, iface, file.Interfaces)
+		}
+	}
+	checker := newAnnotationsChecker(tr, false)
+	tr.checkAnnotations(checker)
+	for _, structType := range file.Structures {
+		checker.report(structType.Pos, structType.Name, structType.Docs, tags.LevelType)
+		checker.fields("", structType)
+	}
+	diags = append(tr.checkServices(), checker.diags...)
+	diags.Sort()
+	return
+}
+
+func (tr *Transport) checkServices() (diags diagnostic.List) {
+
+	routes := make(map[string]route)
+	for _, serviceName := range tr.serviceKeys() {
+		svc := tr.services[serviceName]
+		diags = append(diags, svc.diags...)
+		if svc.isJsonRPC() {
+			tr.checkRoute(routes, &diags, svc.Pos, "POST", svc.batchPath(), nil)
+		}
+		for _, method := range svc.methods {
+			diags = append(diags, method.check()...)
+			if method.isJsonRPC() {
+				tr.checkRoute(routes, &diags, method.Pos, "POST", method.jsonrpcPath(), method)
+			}
+			if method.isHTTP() {
+				tr.checkRoute(routes, &diags, method.Pos, strings.ToUpper(method.httpMethod()), method.httpPath(), method)
+			}
+		}
+	}
+	return
+}
+
+func (tr *Transport) checkRoute(routes map[string]route, diags *diagnostic.List, pos types.Position, httpMethod, urlPath string, m *method) {
+
+	if segment, found := muxUnsupported(urlPath); found && tr.isNetHTTP() {
+		where := "batch endpoint"
+		if m != nil {
+			where = m.svc.Name + "." + m.Name
+		}
+		d := diagnostic.Errorf(pos, ruleNetHTTPPath, "%s: path '%s' cannot be served by %s backend: segment '%s' is supported by fiber only", where, urlPath, BackendNetHTTP, segment)
+		if m != nil {
+			d.Annotation = tagHttpPath
+		}
+		*diags = append(*diags, d)
+	}
+	key := httpMethod + " " + routeParam.ReplaceAllString(urlPath, ":")
+	if registered, found := routes[key]; found {
+		owner := "batch endpoint"
+		if registered.method != nil {
+			owner = registered.method.svc.Name + "." + registered.method.Name
+		}
+		*diags = append(*diags, diagnostic.Errorf(pos, ruleDuplicateRoute, "route '%s %s' is already registered by %s", httpMethod, urlPath, owner))
+		return
+	}
+	routes[key] = route{method: m, path: urlPath}
+}
+
+func (m *method) check() (diags diagnostic.List) {
+
+	where := fmt.Sprintf("%s.%s", m.svc.Name, m.Name)
+	for _, vars := range [][]types.Variable{m.Args, m.Results} {
+		for _, v := range vars {
+			if v.Name == "" {
+				diags = append(diags, diagnostic.Errorf(v.Pos, ruleUnnamedParam, "%s: all arguments and results must be named", where))
+				break
+			}
+		}
+	}
+	if !isContextFirst(m.Args) {
+		diags = append(diags, diagnostic.Errorf(m.Pos, ruleContextFirst, "%s: first argument must be context.Context", where))
+	}
+	if !isErrorLast(m.Results) {
+		diags = append(diags, diagnostic.Errorf(m.Pos, ruleErrorLast, "%s: last result must be error", where))
+	}
+	if m.tags.Contains(tagNotify) && len(m.resultsWithoutError()) != 0 {
+		d := diagnostic.Warnf(m.Pos, ruleNotifyResult, "%s: results of method are lost, when it is called as notification", where)
+		d.Annotation = tagNotify
+		diags = append(diags, d)
+	}
+	for argName := range m.argPathMap() {
+		if m.argByName(argName) == nil {
+			d := diagnostic.Errorf(m.Pos, rulePathArg, "%s: path placeholder ':%s' has no matching argument", where, argName)
+			d.Annotation = tagHttpPath
+			diags = append(diags, d)
+		}
+	}
+	mappings := []struct {
+		tag    string
+		vars   map[string]string
+		result bool
+	}{
+		{tag: tagHttpArg, vars: m.argParamMap()},
+		{tag: tagHttpHeader, vars: m.varHeaderMap(), result: true},
+		{tag: tagHttpCookies, vars: m.varCookieMap(), result: true},
+	}
+	for _, mapping := range mappings {
+		for varName := range mapping.vars {
+			if m.argByName(varName) != nil || (mapping.result && m.resultByName(varName) != nil) {
+				continue
+			}
+			d := diagnostic.Errorf(m.Pos, ruleUnknownVar, "%s: %s refers to unknown variable '%s'", where, mapping.tag, varName)
+			d.Annotation = mapping.tag
+			diags = append(diags, d)
+		}
+	}
+	return
+}
@@ -0,0 +1,80 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/seniorGolang/tg/v2/pkg/astra/types"
+	"github.com/seniorGolang/tg/v2/pkg/diagnostic"
+	"github.com/seniorGolang/tg/v2/pkg/tags"
+)
+
+func newCheckTransport(ifaces ...types.Interface) *Transport {
+
+	tr := &Transport{
+		log:      logrus.New(),
+		tags:     make(tags.DocTags),
+		services: make(map[string]*service),
+	}
+	for _, iface := range ifaces {
+		svc := &service{tr: tr, log: tr.log, Interface: iface, tags: tags.ParseTags(iface.Docs)}
+		for _, fn := range iface.Methods {
+			svc.methods = append(svc.methods, newMethod(tr.log, svc, fn))
+		}
+		tr.services[iface.Name] = svc
+	}
+	return tr
+}
+
+func checkRules(diags diagnostic.List) (rules map[string]int) {
+
+	rules = make(map[string]int)
+	for _, d := range diags {
+		rules[d.Rule]++
+	}
+	return
+}
+
+func TestCheckReportsSignatureAndAnnotationProblems(t *testing.T) {
+
+	ctxArg := types.Variable{Base: types.Base{Name: "ctx"}, Type: types.TImport{Import: &types.Import{Package: packageContext}, Next: types.TName{TypeName: "Context"}}}
+	errRet := types.Variable{Base: types.Base{Name: "err"}, Type: types.TName{TypeName: "error"}}
+	stringType := types.TName{TypeName: "string"}
+
+	tr := newCheckTransport(types.Interface{
+		Base: types.Base{Name: "Shop", Docs: []string{"// @tg http-server"}},
+		Methods: []*types.Function{
+			{
+				Base:    types.Base{Name: "Item", Docs: []string{"// @tg http-method=GET", "// @tg http-path=/item/:id", "// @tg http-headers=token|X-Token"}},
+				Args:    []types.Variable{ctxArg, {Base: types.Base{Name: "id"}, Type: stringType}},
+				Results: []types.Variable{{Base: types.Base{Name: "name"}, Type: stringType}, errRet},
+			},
+			{
+				Base:    types.Base{Name: "Same", Docs: []string{"// @tg http-method=GET", "// @tg http-path=/item/:uid"}},
+				Args:    []types.Variable{{Type: stringType}},
+				Results: []types.Variable{{Base: types.Base{Name: "name"}, Type: stringType}},
+			},
+			{
+				Base:    types.Base{Name: "Touch", Docs: []string{"// @tg notify"}},
+				Args:    []types.Variable{ctxArg, {Base: types.Base{Name: "id"}, Type: stringType}},
+				Results: []types.Variable{{Base: types.Base{Name: "name"}, Type: stringType}, errRet},
+			},
+		},
+	})
+	rules := checkRules(tr.Check())
+	expected := map[string]int{
+		ruleUnknownVar:     1,
+		rulePathArg:        1,
+		ruleUnnamedParam:   1,
+		ruleContextFirst:   1,
+		ruleErrorLast:      1,
+		ruleDuplicateRoute: 1,
+		ruleNotifyResult:   1,
+	}
+	for rule, count := range expected {
+		if rules[rule] != count {
+			t.Fatalf("expected %d diagnostic(s) of rule %s, got %v", count, rule, rules)
+		}
+	}
+}
@@ -26,7 +26,7 @@ func (tr *Transport) cleanup(outDir string) {
 		if goFile, err := os.Open(filePath); err == nil {
 			if firstLine, err := bufio.NewReader(goFile).ReadString('\n'); err == nil {
 				if strings.TrimSpace(strings.TrimPrefix(firstLine, "//")) == doNotEdit {
-					if err = os.Remove(filePath); err != nil {
+					if err = tr.output().Remove(filePath); err != nil {
 						tr.log.WithError(err).Warn("cleanup")
 					}
 				}
@@ -13,7 +13,7 @@ import (
 
 func (tr *Transport) renderClientBatch(outDir string) (err error) {
 
-	srcFile := newSrc(filepath.Base(outDir))
+	srcFile := tr.newSrc(filepath.Base(outDir))
 	srcFile.PackageComment(doNotEdit)
 
 	srcFile.ImportName(fmt.Sprintf("%s/cb", tr.pkgPath(outDir)), "cb")
@@ -36,7 +36,9 @@ func (tr *Transport) renderClientBatch(outDir string) (err error) {
 		bg.Id("callbacks").Op(":=").Make(Map(Qual(fmt.Sprintf("%s/jsonrpc", tr.pkgPath(outDir)), "ID")).Id("rpcCallback"))
 		bg.For(List(Id("_"), Id("request")).Op(":=").Range().Id("requests")).Block(
 			Id("rpcRequests").Op("=").Append(Id("rpcRequests"), Id("request").Dot("rpcRequest")),
-			Id("callbacks").Op("[").Id("request").Dot("rpcRequest").Dot("ID").Op("]").Op("=").Id("request").Dot("retHandler"),
+			If(Id("request").Dot("retHandler").Op("!=").Nil()).Block(
+				Id("callbacks").Op("[").Id("request").Dot("rpcRequest").Dot("ID").Op("]").Op("=").Id("request").Dot("retHandler"),
+			),
 		)
 		bg.Var().Err().Error()
 		bg.Var().Id("rpcResponses").Qual(fmt.Sprintf("%s/jsonrpc", tr.pkgPath(outDir)), "ResponsesRPC")
@@ -12,7 +12,7 @@ import (
 
 func (tr *Transport) renderClientCache(outDir string) (err error) {
 
-	srcFile := newSrc(filepath.Base(outDir))
+	srcFile := tr.newSrc(filepath.Base(outDir))
 	srcFile.PackageComment(doNotEdit)
 
 	srcFile.Type().Id("cache").InterfaceFunc(func(ig *Group) {
@@ -12,7 +12,7 @@ import (
 
 func (tr *Transport) renderClientError(outDir string) (err error) {
 
-	srcFile := newSrc(filepath.Base(outDir))
+	srcFile := tr.newSrc(filepath.Base(outDir))
 	srcFile.PackageComment(doNotEdit)
 
 	srcFile.ImportName(packageStdJSON, "json")
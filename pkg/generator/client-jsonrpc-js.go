@@ -6,7 +6,6 @@ package generator
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"path"
 	"path/filepath"
 	"strings"
@@ -23,7 +22,7 @@ type clientJS struct {
 }
 
 func (tr *Transport) RenderClientJS(outDir string) (err error) {
-	return newClientJS(tr).render(outDir)
+	return tr.renderIncremental(targetClientJS, outDir, nil, func() error { return newClientJS(tr).render(outDir) })
 }
 
 func newClientJS(tr *Transport) (js *clientJS) {
@@ -36,6 +35,10 @@ func newClientJS(tr *Transport) (js *clientJS) {
 }
 
 func (tr *Transport) RenderPackageNPM(outJs, outPkg string) (err error) {
+	return tr.renderIncremental(targetNPM, outPkg, tr.moduleRel(outJs), func() error { return tr.renderPackageNPM(outJs, outPkg) })
+}
+
+func (tr *Transport) renderPackageNPM(outJs, outPkg string) (err error) {
 
 	type npmPackage struct {
 		Name          string   `json:"name"`
@@ -80,14 +83,14 @@ func (tr *Transport) RenderPackageNPM(outJs, outPkg string) (err error) {
 	if bytes, err = json.MarshalIndent(data, "", "    "); err != nil {
 		return
 	}
-	return os.WriteFile(path.Join(outPkg, "package.json"), bytes, 0600)
+	return tr.output().WriteFile(path.Join(outPkg, "package.json"), bytes, 0600)
 }
 
 func (js *clientJS) render(outDir string) (err error) {
 
 	outFilename := path.Join(outDir, "jsonrpc-client.js")
-	_ = os.Remove(outFilename)
-	if err = os.MkdirAll(outDir, 0777); err != nil {
+	_ = js.output().Remove(outFilename)
+	if err = js.output().MkdirAll(outDir, 0777); err != nil {
 		return
 	}
 	var jsFile bytesWriter
@@ -107,6 +110,10 @@ func (js *clientJS) render(outDir string) (err error) {
 				jsFile.add("* %s\n", comment)
 				jsFile.add("*\n")
 			}
+			if comment := method.description(); comment != "" {
+				jsFile.add("%s", jsDocLines(comment))
+				jsFile.add("*\n")
+			}
 			for _, arg := range method.arguments() {
 				switch vType := arg.Type.(type) {
 				case types.TEllipsis:
@@ -121,7 +128,7 @@ func (js *clientJS) render(outDir string) (err error) {
 				for _, ret := range method.results() {
 					fields = append(fields, fmt.Sprintf("%s: %s", ret.Name, js.walkVariable(ret.Name, svc.pkgPath, ret.Type, method.tags).typeLink()))
 				}
-				jsFile.add(strings.Join(fields, ",")) // nolint
+				jsFile.add("%s", strings.Join(fields, ",")) // nolint
 				jsFile.add("}>}\n")
 			}
 			jsFile.add("**/\n")
@@ -134,18 +141,29 @@ func (js *clientJS) render(outDir string) (err error) {
 				}
 				fields = append(fields, prefix+utils.ToLowerCamel(arg.Name))
 			}
-			jsFile.add(strings.Join(fields, ",")) // nolint
+			jsFile.add("%s", strings.Join(fields, ",")) // nolint
 			jsFile.add(") {\n")
 			jsFile.add("return this.scheduler.__scheduleRequest(\"%s\", {", svc.lccName()+"."+method.lccName())
+			args := fields
 			fields = []string{}
 			for _, arg := range method.arguments() {
 				fields = append(fields, fmt.Sprintf("%[1]s:%[1]s", utils.ToLowerCamel(arg.Name)))
 			}
-			jsFile.add(strings.Join(fields, ",")) // nolint
+			jsFile.add("%s", strings.Join(fields, ",")) // nolint
 			jsFile.add("}).catch(e => { throw ")
 			jsFile.add("%sConvertError(e)", utils.ToLowerCamel(method.fullName()))
 			jsFile.add("; })\n")
 			jsFile.add("}\n")
+			if method.isNotify() {
+				jsFile.add("/**\n")
+				jsFile.add("* Calls %s as notification, server does not answer it.\n", method.lccName())
+				jsFile.add("*\n")
+				jsFile.add("* @return {PromiseLike<void>}\n")
+				jsFile.add("**/\n")
+				jsFile.add("notify%s(%s) {\n", method.Name, strings.Join(args, ","))
+				jsFile.add("return this.scheduler.__scheduleNotification(\"%s\", {%s});\n", svc.lccName()+"."+method.lccName(), strings.Join(fields, ","))
+				jsFile.add("}\n")
+			}
 		}
 		jsFile.add("}\n\n")
 	}
@@ -174,17 +192,21 @@ func (js *clientJS) render(outDir string) (err error) {
 			jsFile.add("}\n}\n")
 		}
 	}
-	for _, def := range js.typeDef {
-		jsFile.add(def.js()) // nolint
+	for _, name := range sortedKeys(js.typeDef) {
+		def := js.typeDef[name]
+		jsFile.add("%s", def.js()) // nolint
 	}
-	return os.WriteFile(outFilename, jsFile.Bytes(), 0600)
+	return js.output().WriteFile(outFilename, jsFile.Bytes(), 0600)
 }
 
 type typeDefJs struct {
-	name       string
-	kind       string
-	typeName   string
-	properties map[string]typeDefJs
+	name        string
+	kind        string
+	typeName    string
+	description string
+	properties  map[string]typeDefJs
+	// propertyDocs keeps descriptions of struct fields, properties may share definition of type
+	propertyDocs map[string]string
 }
 
 func (def typeDefJs) def() (prop string) {
@@ -214,8 +236,14 @@ func (def typeDefJs) js() (js string) {
 	case "array":
 		js += fmt.Sprintf("* @typedef %s %s \n", def.def(), def.name)
 	case "struct":
+		js += jsDocLines(def.description)
 		js += fmt.Sprintf("* @typedef {Object} %s\n", def.name)
-		for name, property := range def.properties {
+		for _, name := range sortedKeys(def.properties) {
+			property := def.properties[name]
+			if propertyDoc := def.propertyDocs[name]; propertyDoc != "" {
+				js += fmt.Sprintf("* @property {%s} %s - %s\n", property.def(), name, strings.ReplaceAll(jsDocText(propertyDoc), "\n", " "))
+				continue
+			}
 			js += fmt.Sprintf("* @property {%s} %s\n", property.def(), name)
 		}
 	default:
@@ -257,7 +285,7 @@ func (js *clientJS) walkVariable(typeName, pkgPath string, varType types.Type, v
 			schema.typeName = vType.String()
 			return
 		}
-		if nextType := searchType(pkgPath, vType.TypeName); nextType != nil {
+		if nextType := js.searchType(pkgPath, vType.TypeName); nextType != nil {
 			if js.knownCount(vType.TypeName) < 3 {
 				js.knownInc(vType.TypeName)
 				js.typeDef[vType.TypeName] = js.walkVariable(typeName, pkgPath, nextType, varTags)
@@ -289,26 +317,39 @@ func (js *clientJS) walkVariable(typeName, pkgPath string, varType types.Type, v
 		schema.name = vType.Name
 		schema.kind = "struct"
 		schema.typeName = "struct"
+		schema.description = docText(vType.Docs)
+		schema.propertyDocs = make(map[string]string)
 		for _, field := range vType.Fields {
 			if fieldName, inline := jsonName(field); fieldName != "-" {
 				embed := js.walkVariable(field.Name, pkgPath, field.Type, tags.ParseTags(field.Docs))
 				if !inline {
 					schema.properties[fieldName] = embed
+					schema.propertyDocs[fieldName] = description(field.Docs)
 					continue
 				}
-				for eField, def := range js.typeDef[field.Type.String()].properties {
+				embedded := js.typeDef[field.Type.String()]
+				for eField, def := range embedded.properties {
 					schema.properties[eField] = def
+					schema.propertyDocs[eField] = embedded.propertyDocs[eField]
 				}
 			}
 		}
 	case types.TImport:
-		if nextType := searchType(vType.Import.Package, vType.Next.String()); nextType != nil {
+		if nextType := js.searchType(vType.Import.Package, vType.Next.String()); nextType != nil {
 			if js.knownCount(vType.Next.String()) < 3 {
 				js.knownInc(vType.Next.String())
 				js.typeDef[vType.Next.String()] = js.walkVariable(typeName, vType.Import.Package, nextType, varTags)
 			}
 			return js.typeDef[vType.Next.String()]
 		}
+	case types.TInstance:
+		if instance, defPkg, found := js.instantiate(vType, pkgPath); found {
+			if js.knownCount(instance.Name) < 3 {
+				js.knownInc(instance.Name)
+				js.typeDef[instance.Name] = js.walkVariable(typeName, defPkg, instance, varTags)
+			}
+			return js.typeDef[instance.Name]
+		}
 	case types.TEllipsis:
 		schema.kind = "array"
 		schema.typeName = "array"
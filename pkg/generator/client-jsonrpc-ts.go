@@ -5,7 +5,6 @@ package generator
 
 import (
 	"fmt"
-	"os"
 	"path"
 	"strings"
 
@@ -21,7 +20,7 @@ type clientTS struct {
 }
 
 func (tr *Transport) RenderClientTS(outDir string) (err error) {
-	return newClientTS(tr).render(outDir)
+	return tr.renderIncremental(targetClientTS, outDir, nil, func() error { return newClientTS(tr).render(outDir) })
 }
 
 func newClientTS(tr *Transport) (js *clientTS) {
@@ -35,7 +34,7 @@ func newClientTS(tr *Transport) (js *clientTS) {
 
 func (ts *clientTS) render(outDir string) (err error) {
 
-	if err = tsCopyTo("jsonrpc", outDir); err != nil {
+	if err = tsCopyTo(ts.output(), "jsonrpc", outDir); err != nil {
 		return err
 	}
 	for _, name := range ts.serviceKeys() {
@@ -55,34 +54,66 @@ func (ts *clientTS) renderService(svc *service, outDir string) (err error) {
 	ts.knownTypes = make(map[string]int)
 	ts.typeDefTs = make(map[string]typeDefTs)
 	outFilename := path.Join(outDir, fmt.Sprintf("%s.ts", svc.lccName()))
-	_ = os.Remove(outFilename)
-	if err = os.MkdirAll(outDir, 0777); err != nil {
+	_ = ts.output().Remove(outFilename)
+	if err = ts.output().MkdirAll(outDir, 0777); err != nil {
 		return
 	}
 	var jsFile bytesWriter
-	jsFile.add("import {rpcClient} from \"./jsonrpc/jsonrpc\";\n\n")
+	if ts.hasWebSocket() {
+		jsFile.add("import {rpcClient, RpcTransport} from \"./jsonrpc/jsonrpc\";\n\n")
+	} else {
+		jsFile.add("import {rpcClient} from \"./jsonrpc/jsonrpc\";\n\n")
+	}
+	var notify []string
+	for _, method := range svc.methods {
+		if method.isNotify() {
+			notify = append(notify, fmt.Sprintf("Notify%[1]s: \"%[1]s\"", method.Name))
+		}
+	}
+	var notifyOption string
+	if len(notify) != 0 {
+		notifyOption = fmt.Sprintf(",\n            notify: {%s}", strings.Join(notify, ", "))
+	}
 	jsFile.add("export namespace %sAPI {\n\n", svc.Name)
 	jsFile.add(`export const RPC = (headers?: Record<string, string>) => {
         return rpcClient<Methods>({
             url: "%s",
-            getHeaders: () => headers
+            getHeaders: () => headers%s
+        })
+    }
+`, svc.batchPath(), notifyOption)
+	if ts.hasWebSocket() {
+		// WebSocket endpoint serves all services, so name of method is qualified by service
+		jsFile.add(`export const RPCWebSocket = (transport: RpcTransport) => {
+        return rpcClient<Methods>({
+            transport: (req, signal) => transport({...req, method: "%s." + req.method}, signal)%s
         })
     }
-`, svc.batchPath())
+`, svc.lccName(), notifyOption)
+	}
 	jsFile.add("export type Methods = {\n")
 	for _, method := range svc.methods {
+		jsFile.add("%s", tsDoc(method.description()))
 		jsFile.add("%s(params: {%s}) : {%s}\n",
 			method.Name,
 			ts.paramsToFuncParams(svc.pkgPath, method.tags, method.argsWithoutContext()),
 			ts.paramsToFuncParams(svc.pkgPath, method.tags, method.resultsWithoutError()),
 		)
+		if method.isNotify() {
+			jsFile.add("%s", tsDoc(fmt.Sprintf("Calls %s as notification, server does not answer it.", method.Name)))
+			jsFile.add("Notify%s(params: {%s}) : void\n",
+				method.Name,
+				ts.paramsToFuncParams(svc.pkgPath, method.tags, method.argsWithoutContext()),
+			)
+		}
 	}
 	jsFile.add("}\n")
-	for _, def := range ts.typeDefTs {
-		jsFile.add(def.ts()) // nolint
+	for _, name := range sortedKeys(ts.typeDefTs) {
+		def := ts.typeDefTs[name]
+		jsFile.add("%s", def.ts()) // nolint
 	}
 	jsFile.add("}\n\n")
-	return os.WriteFile(outFilename, jsFile.Bytes(), 0600)
+	return ts.output().WriteFile(outFilename, jsFile.Bytes(), 0600)
 }
 
 func (ts *clientTS) paramsToFuncParams(pkgPath string, tags tags.DocTags, vars []types.Variable) string {
@@ -95,12 +126,18 @@ func (ts *clientTS) paramsToFuncParams(pkgPath string, tags tags.DocTags, vars [
 }
 
 type typeDefTs struct {
-	name       string
-	kind       string
-	typeName   string
-	nullable   bool
-	value      interface{}
-	properties map[string]typeDefTs
+	name        string
+	kind        string
+	typeName    string
+	description string
+	nullable    bool
+	value       interface{}
+	typeParams  []string
+	properties  map[string]typeDefTs
+	// propertyDocs keeps descriptions of struct fields, properties may share definition of type
+	propertyDocs map[string]string
+	// constants keeps names of constants in order of declaration, iota gives them values in this order
+	constants []string
 }
 
 func (def typeDefTs) def() (prop string) {
@@ -128,13 +165,13 @@ func (def typeDefTs) ts() (js string) {
 		if len(def.properties) > 1 {
 			if def.typeName == "iota" {
 				var cnt int
-				for key := range def.properties {
+				for _, key := range def.constants {
 					js += fmt.Sprintf("export const %s = %d;\n", key, cnt)
 					cnt++
 				}
 			} else {
 				js += "export enum " + def.typeName + " {\n"
-				for key := range def.properties {
+				for _, key := range def.constants {
 					js += fmt.Sprintf("%s,\n", key)
 				}
 				js += "}\n"
@@ -143,12 +180,19 @@ func (def typeDefTs) ts() (js string) {
 			js += fmt.Sprintf("export const %s = %v;\n", def.name, def.value)
 		}
 	case "struct":
-		js += "export interface " + def.name + " {\n"
-		for name, property := range def.properties {
+		js += tsDoc(def.description)
+		js += "export interface " + def.name
+		if len(def.typeParams) != 0 {
+			js += "<" + strings.Join(def.typeParams, ", ") + ">"
+		}
+		js += " {\n"
+		for _, name := range sortedKeys(def.properties) {
+			property := def.properties[name]
 			var pNullable string
 			if property.nullable {
 				pNullable = "?"
 			}
+			js += tsDoc(def.propertyDocs[name])
 			js += fmt.Sprintf("%s%s: %s\n", name, pNullable, castTypeTs(property.def()))
 		}
 		js += "}\n"
@@ -175,7 +219,7 @@ func (ts *clientTS) walkVariable(typeName, pkgPath string, varType types.Type, v
 	schema.name = typeName
 	schema.typeName = varType.String()
 	schema.properties = make(map[string]typeDefTs)
-	if fl, ok := varTags["nullable"]; ok {
+	if fl, ok := varTags[tagNullable]; ok {
 		schema.nullable = fl == "true"
 	}
 	if newType := castTypeTs(varType.String()); newType != varType.String() {
@@ -230,17 +274,24 @@ func (ts *clientTS) walkVariable(typeName, pkgPath string, varType types.Type, v
 		schema.name = vType.Name
 		schema.kind = "struct"
 		schema.typeName = "struct"
+		schema.description = docText(vType.Docs)
+		schema.propertyDocs = make(map[string]string)
+		for _, param := range vType.TypeParams {
+			schema.typeParams = append(schema.typeParams, param.Name)
+		}
 		for _, field := range vType.Fields {
 			if fieldName, inline := jsonName(field); fieldName != "-" {
 				embed := ts.walkVariable(field.Name, pkgPath, field.Type, tags.ParseTags(field.Docs))
 				if !inline {
 					schema.properties[fieldName] = embed
+					schema.propertyDocs[fieldName] = description(field.Docs)
 					continue
 				}
 				inlineTokens := strings.Split(field.Type.String(), ".")
 				embed = ts.typeDefTs[inlineTokens[len(inlineTokens)-1]]
 				for eField, def := range embed.properties {
 					schema.properties[eField] = def
+					schema.propertyDocs[eField] = embed.propertyDocs[eField]
 				}
 			}
 		}
@@ -259,6 +310,7 @@ func (ts *clientTS) walkVariable(typeName, pkgPath string, varType types.Type, v
 				}
 				def.properties = make(map[string]typeDefTs)
 				for _, v := range c.Constants {
+					def.constants = append(def.constants, v.Name)
 					def.properties[v.Name] = typeDefTs{
 						kind:     "constant",
 						name:     v.Name,
@@ -270,6 +322,15 @@ func (ts *clientTS) walkVariable(typeName, pkgPath string, varType types.Type, v
 			}
 			return ts.typeDefTs[vType.Next.String()]
 		}
+	case types.TInstance:
+		generic := ts.walkVariable(typeName, pkgPath, vType.Next, nil)
+		args := make([]string, 0, len(vType.Args))
+		for _, arg := range vType.Args {
+			args = append(args, castTypeTs(ts.walkVariable(arg.String(), pkgPath, arg, nil).typeLink()))
+		}
+		schema.kind = "scalar"
+		schema.name = generic.typeLink()
+		schema.typeName = fmt.Sprintf("%s<%s>", generic.typeLink(), strings.Join(args, ", "))
 	case types.TEllipsis:
 		schema.kind = "array"
 		schema.typeName = "array"
@@ -279,7 +340,7 @@ func (ts *clientTS) walkVariable(typeName, pkgPath string, varType types.Type, v
 			ts.typeDefTs[vType.Next.String()] = ts.walkVariable(typeName, pkgPath, vType.Next, varTags)
 		}
 	case types.TPointer:
-		return ts.walkVariable(typeName, pkgPath, vType.Next, tags.DocTags{"nullable": "true"})
+		return ts.walkVariable(typeName, pkgPath, vType.Next, tags.DocTags{tagNullable: "true"})
 	case types.TInterface:
 		schema.kind = "scalar"
 		schema.name = "interface"
@@ -0,0 +1,19 @@
+package generator
+
+import "testing"
+
+func TestTypeDefTsConstantsOrder(t *testing.T) {
+
+	constants := []string{"Zebra", "Alpha", "Middle"}
+	def := typeDefTs{kind: "constant", typeName: "iota", constants: constants, properties: make(map[string]typeDefTs)}
+	for _, name := range constants {
+		def.properties[name] = typeDefTs{kind: "constant", name: name}
+	}
+	if js := def.ts(); js != "export const Zebra = 0;\nexport const Alpha = 1;\nexport const Middle = 2;\n" {
+		t.Fatalf("constants are not numbered in order of declaration:\n%s", js)
+	}
+	def.typeName = "Status"
+	if js := def.ts(); js != "export enum Status {\nZebra,\nAlpha,\nMiddle,\n}\n" {
+		t.Fatalf("members of enum are not in order of declaration:\n%s", js)
+	}
+}
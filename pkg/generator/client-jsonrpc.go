@@ -13,16 +13,16 @@ import (
 
 func (tr *Transport) renderClientJsonRPC(outDir string) (err error) {
 
-	if err = pkgCopyTo("jsonrpc", outDir); err != nil {
+	if err = pkgCopyTo(tr.output(), "jsonrpc", outDir); err != nil {
 		return err
 	}
-	if err = pkgCopyTo("cb", outDir); err != nil {
+	if err = pkgCopyTo(tr.output(), "cb", outDir); err != nil {
 		return err
 	}
-	if err = pkgCopyTo("hasher", outDir); err != nil {
+	if err = pkgCopyTo(tr.output(), "hasher", outDir); err != nil {
 		return err
 	}
-	srcFile := newSrc(filepath.Base(outDir))
+	srcFile := tr.newSrc(filepath.Base(outDir))
 	srcFile.PackageComment(doNotEdit)
 	srcFile.ImportName(packageHttp, "http")
 	srcFile.ImportName(packageFiber, "fiber")
@@ -35,7 +35,27 @@ func (tr *Transport) renderClientJsonRPC(outDir string) (err error) {
 	srcFile.ImportName(fmt.Sprintf("%s/jsonrpc", tr.pkgPath(outDir)), "jsonrpc")
 
 	srcFile.Line().Add(tr.jsonrpcClientStructFunc(outDir))
-	srcFile.Line().Func().Id("New").Params(Id("endpoint").String(), Id("opts").Op("...").Id("Option")).Params(Id("cli").Op("*").Id("ClientJsonRPC")).BlockFunc(
+	srcFile.Line().Add(tr.overridable(&srcFile, OverrideClient, nil, tr.jsonrpcClientNewFunc(outDir)))
+	srcFile.Line().Func().Params(Id("cli").Op("*").Id("ClientJsonRPC")).Id("Close").Params().Error().Block(
+		Return(Id("cli").Dot("rpc").Dot("Close").Call()),
+	)
+	for _, name := range tr.serviceKeys() {
+		svc := tr.services[name]
+		if svc.tags.Contains(tagServerJsonRPC) {
+			srcFile.Line().Func().Params(Id("cli").Op("*").Id("ClientJsonRPC")).Id(svc.Name).Params().Params(Op("*").Id("Client" + svc.Name)).Block(
+				Return(Op("&").Id("Client" + svc.Name).Values(Dict{
+					Id("ClientJsonRPC"): Id("cli"),
+				})),
+			)
+		}
+	}
+	srcFile.Line().Add(tr.jsonrpcClientProceedResponseFunc(outDir))
+	return srcFile.Save(path.Join(outDir, "jsonrpc.go"))
+}
+
+func (tr *Transport) jsonrpcClientNewFunc(outDir string) Code {
+
+	return Func().Id("New").Params(Id("endpoint").String(), Id("opts").Op("...").Id("Option")).Params(Id("cli").Op("*").Id("ClientJsonRPC")).BlockFunc(
 		func(bg *Group) {
 			bg.Line()
 			bg.List(Id("hostname"), Id("_")).Op(":=").Qual(packageOS, "Hostname").Call()
@@ -49,18 +69,6 @@ func (tr *Transport) renderClientJsonRPC(outDir string) (err error) {
 			bg.Id("cli").Dot("cb").Op("=").Qual(fmt.Sprintf("%s/cb", tr.pkgPath(outDir)), "NewCircuitBreaker").Call(Lit(tr.module.Module.Mod.String()), Id("cli").Dot("cbCfg"))
 			bg.Return()
 		})
-	for _, name := range tr.serviceKeys() {
-		svc := tr.services[name]
-		if svc.tags.Contains(tagServerJsonRPC) {
-			srcFile.Line().Func().Params(Id("cli").Op("*").Id("ClientJsonRPC")).Id(svc.Name).Params().Params(Op("*").Id("Client" + svc.Name)).Block(
-				Return(Op("&").Id("Client" + svc.Name).Values(Dict{
-					Id("ClientJsonRPC"): Id("cli"),
-				})),
-			)
-		}
-	}
-	srcFile.Line().Add(tr.jsonrpcClientProceedResponseFunc(outDir))
-	return srcFile.Save(path.Join(outDir, "jsonrpc.go"))
 }
 
 func (tr *Transport) jsonrpcClientProceedResponseFunc(outDir string) Code {
@@ -13,7 +13,7 @@ import (
 
 func (tr *Transport) renderClientOptions(outDir string) (err error) {
 
-	srcFile := newSrc(filepath.Base(outDir))
+	srcFile := tr.newSrc(filepath.Base(outDir))
 	srcFile.PackageComment(doNotEdit)
 
 	srcFile.ImportName(fmt.Sprintf("%s/cb", tr.pkgPath(outDir)), "cb")
@@ -73,6 +73,11 @@ func (tr *Transport) renderClientOptions(outDir string) (err error) {
 			Id("cli").Dot("rpcOpts").Op("=").Append(Id("cli").Dot("rpcOpts"), Qual(fmt.Sprintf("%s/jsonrpc", tr.pkgPath(outDir)), "ConfigTLS").Call(Id("tlsConfig"))),
 		),
 	)
+	srcFile.Line().Func().Id("ResponseTimeout").Params(Id("timeout").Qual(packageTime, "Duration")).Params(Id("Option")).Block(
+		Return(Func().Params(Id("cli").Op("*").Id("ClientJsonRPC"))).Block(
+			Id("cli").Dot("rpcOpts").Op("=").Append(Id("cli").Dot("rpcOpts"), Qual(fmt.Sprintf("%s/jsonrpc", tr.pkgPath(outDir)), "ResponseTimeout").Call(Id("timeout"))),
+		),
+	)
 	srcFile.Line().Func().Id("LogRequest").Params().Params(Id("Option")).Block(
 		Return(Func().Params(Id("cli").Op("*").Id("ClientJsonRPC"))).Block(
 			Id("cli").Dot("rpcOpts").Op("=").Append(Id("cli").Dot("rpcOpts"), Qual(fmt.Sprintf("%s/jsonrpc", tr.pkgPath(outDir)), "LogRequest").Call()),
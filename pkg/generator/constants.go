@@ -28,6 +28,8 @@ const (
 	packageZeroLog        = "github.com/rs/zerolog"
 	packageZeroLogLog     = "github.com/rs/zerolog/log"
 	packageFiberAdaptor   = "github.com/gofiber/adaptor/v2"
+	packageFiberV3        = "github.com/gofiber/fiber/v3"
+	packageFiberV3Adaptor = "github.com/gofiber/fiber/v3/middleware/adaptor"
 	packageAttributeOTEL  = "go.opentelemetry.io/otel/attribute"
 	packageOTEL           = "go.opentelemetry.io/otel"
 	packageTrace          = "go.opentelemetry.io/otel/trace"
@@ -35,6 +37,13 @@ const (
 	packagePrometheus     = "github.com/prometheus/client_golang/prometheus"
 	packagePrometheusAuto = "github.com/prometheus/client_golang/prometheus/promauto"
 	packagePrometheusHttp = "github.com/prometheus/client_golang/prometheus/promhttp"
+	packageGRPC           = "google.golang.org/grpc"
+	packageGRPCCodes      = "google.golang.org/grpc/codes"
+	packageGRPCStatus     = "google.golang.org/grpc/status"
+	packageTimestampPB    = "google.golang.org/protobuf/types/known/timestamppb"
+	packageDurationPB     = "google.golang.org/protobuf/types/known/durationpb"
+	packageWebSocket      = "github.com/gorilla/websocket"
+	packageFasthttpWS     = "github.com/fasthttp/websocket"
 )
 
 const jsonRPCClientBase = `
@@ -56,6 +65,7 @@ class JSONRPCScheduler {
 	  this._transport = transport;
 	  this._requestID = 0;
 	  this._scheduleRequests = {};
+	  this._scheduleNotifications = [];
 	  this._commitTimerID = null;
 	  this._beforeRequest = null;
 	}
@@ -69,13 +79,18 @@ class JSONRPCScheduler {
 	  this._commitTimerID = setTimeout(() => {
 		this._commitTimerID = null;
 		const scheduleRequests = { ...this._scheduleRequests };
+		const scheduleNotifications = this._scheduleNotifications;
 		this._scheduleRequests = {};
+		this._scheduleNotifications = [];
 		let requests = [];
 		for (let key in scheduleRequests) {
 		  requests.push(scheduleRequests[key].request);
 		}
+		scheduleNotifications.forEach((notification) => requests.push(notification.request));
 		this.__doRequest(requests)
 		  .then((responses) => {
+			scheduleNotifications.forEach((notification) => notification.resolve());
+			responses = responses || [];
 			for (let i = 0; i < responses.length; i++) {
               const schedule = scheduleRequests[responses[i].id];
 			  if (responses[i].error) {
@@ -86,6 +101,7 @@ class JSONRPCScheduler {
 			}
 		  })
          .catch((e) => {
+           scheduleNotifications.forEach((notification) => notification.reject(e));
            for (let key in requests) {
              if (!requests.hasOwnProperty(key)) {
                continue;
@@ -126,6 +142,24 @@ class JSONRPCScheduler {
 	  this.__scheduleCommit();
 	  return p;
 	}
+	/**
+    * Notification has no id, server executes it without response.
+    *
+    * @param {string} method
+    * @param {Object} params
+    * @returns {Promise<void>}
+    */
+	__scheduleNotification(method, params) {
+	  const p = new Promise((resolve, reject) => {
+		this._scheduleNotifications.push({
+		  request: { jsonrpc: "2.0", method: method, params: params },
+		  resolve,
+		  reject,
+		});
+	  });
+	  this.__scheduleCommit();
+	  return p;
+	}
 	__doRequest(request) {
 	  return this._transport.doRequest(request);
 	}
@@ -133,4 +167,125 @@ class JSONRPCScheduler {
 	  return ++this._requestID;
 	}
  }
+
+export class JSONRPCWebSocketTransport {
+	/**
+	 * Sends requests by one WebSocket connection, connection is opened again after it is broken.
+	 *
+	 * @param {string} url
+	 * @param {{reconnectDelay?: number, maxReconnectDelay?: number, timeout?: number}} [options]
+	 */
+	constructor(url, options = {}) {
+	  this._url = url;
+	  this._reconnectDelay = options.reconnectDelay || 1000;
+	  this._maxReconnectDelay = options.maxReconnectDelay || 30000;
+	  this._timeout = options.timeout || 30000;
+	  this._attempt = 0;
+	  this._requestID = 0;
+	  this._pending = {};
+	  this._queue = [];
+	  this._closed = false;
+	  this.__connect();
+	}
+	__connect() {
+	  this._socket = new WebSocket(this._url);
+	  this._socket.onopen = () => {
+		this._attempt = 0;
+		const queue = this._queue;
+		this._queue = [];
+		queue.forEach((key) => this.__send(key));
+	  };
+	  this._socket.onmessage = (event) => {
+		let responses = JSON.parse(event.data);
+		if (!Array.isArray(responses)) {
+		  responses = [responses];
+		}
+		if (responses.length === 1 && (responses[0].id === undefined || responses[0].id === null)) {
+		  // error without id (parsing of message or size of batch) cannot be matched to request
+		  if (responses[0].error) {
+			this.__reject(new Error(responses[0].error.message), true);
+		  }
+		  return;
+		}
+		if (responses.length === 0 || !this._pending.hasOwnProperty(responses[0].id)) {
+		  return;
+		}
+		const pending = this._pending[responses[0].id];
+		delete this._pending[responses[0].id];
+		clearTimeout(pending.timerID);
+		pending.resolve(responses.map((response) => ({ ...response, id: pending.ids[response.id] })));
+	  };
+	  this._socket.onclose = () => {
+		this.__reject(new Error("websocket connection closed"), true);
+		if (this._closed) {
+		  return;
+		}
+		const delay = Math.min(this._reconnectDelay * 2 ** this._attempt, this._maxReconnectDelay);
+		this._attempt++;
+		this._reconnectID = setTimeout(() => this.__connect(), delay);
+	  };
+	}
+	__reject(err, sentOnly) {
+	  for (let key in this._pending) {
+		if (this._pending[key].sent || !sentOnly) {
+		  clearTimeout(this._pending[key].timerID);
+		  this._pending[key].reject(err);
+		  delete this._pending[key];
+		}
+	  }
+	}
+	__send(key) {
+	  const pending = this._pending[key];
+	  if (!pending) {
+		return;
+	  }
+	  if (this._socket.readyState !== WebSocket.OPEN) {
+		this._queue.push(key);
+		return;
+	  }
+	  pending.sent = true;
+	  this._socket.send(pending.message);
+	  if (pending.notify) {
+		clearTimeout(pending.timerID);
+		delete this._pending[key];
+		pending.resolve([]);
+	  }
+	}
+	/**
+	 * @param {Array<Object>} requests
+	 * @returns {Promise<Array<Object>>}
+	 */
+	doRequest(requests) {
+	  return new Promise((resolve, reject) => {
+		if (this._closed) {
+		  reject(new Error("websocket transport closed"));
+		  return;
+		}
+		const ids = {};
+		const batch = requests.map((request) => {
+		  if (request.id === undefined) {
+			return request;
+		  }
+		  const id = ++this._requestID;
+		  ids[id] = request.id;
+		  return { ...request, id: id };
+		});
+		// response of batch is matched by first request, which is not notification
+		const first = batch.find((request) => request.id !== undefined);
+		const key = first ? first.id : "notify" + ++this._requestID;
+		const timerID = setTimeout(() => {
+		  delete this._pending[key];
+		  reject(new Error("websocket request timeout"));
+		}, this._timeout);
+		this._pending[key] = { ids, resolve, reject, timerID, message: JSON.stringify(batch), sent: false, notify: !first };
+		this.__send(key);
+	  });
+	}
+	close() {
+	  this._closed = true;
+	  clearTimeout(this._reconnectID);
+	  this._socket.close();
+	  this.__reject(new Error("websocket transport closed"), false);
+	}
+ }
 `
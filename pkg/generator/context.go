@@ -9,7 +9,7 @@ import (
 
 func (tr *Transport) renderContext(outDir string) (err error) {
 
-	srcFile := newSrc(filepath.Base(outDir))
+	srcFile := tr.newSrc(filepath.Base(outDir))
 
 	srcFile.PackageComment(doNotEdit)
 
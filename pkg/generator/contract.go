@@ -0,0 +1,460 @@
+package generator
+
+import (
+	"fmt"
+	"go/build"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/seniorGolang/tg/v2/pkg/astra/types"
+	"github.com/seniorGolang/tg/v2/pkg/tags"
+)
+
+// ContractVersion is a version of Contract JSON schema. It is changed on incompatible changes of schema.
+const ContractVersion = 1
+
+// ContractSet is a list of contracts of several service packages, which is versioned as a whole.
+type ContractSet struct {
+	Version   int         `json:"version"`
+	Contracts []*Contract `json:"contracts"`
+}
+
+// Contract is a resolved model of services: what clients see on the wire.
+// Named types are collected in Types by key '<import path>.<name>'. Positions are relative to module root.
+// Model does not depend on version of generator, so it is the same for every build of tg.
+type Contract struct {
+	Version  int                         `json:"version"`
+	Module   string                      `json:"module"`
+	Tags     tags.DocTags                `json:"tags,omitempty"`
+	Services []ContractService           `json:"services"`
+	Types    map[string]*ContractTypeDef `json:"types,omitempty"`
+}
+
+// ContractService describes interface. Tags are annotations of interface itself,
+// EffectiveTags are merged with package-level annotations.
+type ContractService struct {
+	Name          string           `json:"name"`
+	Package       string           `json:"package"`
+	Doc           string           `json:"doc,omitempty"`
+	Pos           types.Position   `json:"pos,omitzero"`
+	Tags          tags.DocTags     `json:"tags,omitempty"`
+	EffectiveTags tags.DocTags     `json:"effectiveTags,omitempty"`
+	JsonRPC       *ContractJsonRPC `json:"jsonRPC,omitempty"`
+	HTTP          bool             `json:"http,omitempty"`
+	GRPC          bool             `json:"grpc,omitempty"`
+	Methods       []ContractMethod `json:"methods"`
+}
+
+type ContractMethod struct {
+	Name          string           `json:"name"`
+	Doc           string           `json:"doc,omitempty"`
+	Pos           types.Position   `json:"pos,omitzero"`
+	Tags          tags.DocTags     `json:"tags,omitempty"`
+	EffectiveTags tags.DocTags     `json:"effectiveTags,omitempty"`
+	JsonRPC       *ContractJsonRPC `json:"jsonRPC,omitempty"`
+	HTTP          *ContractHTTP    `json:"http,omitempty"`
+	Args          []ContractVar    `json:"args,omitempty"`
+	Results       []ContractVar    `json:"results,omitempty"`
+}
+
+// ContractJsonRPC is a JSON-RPC method name and URL path, which accepts it.
+// For service it describes batch endpoint.
+type ContractJsonRPC struct {
+	Method string `json:"method,omitempty"`
+	Path   string `json:"path"`
+}
+
+type ContractHTTP struct {
+	Method  string `json:"method"`
+	Path    string `json:"path"`
+	Success int    `json:"success"`
+}
+
+const (
+	inBody   = "body"
+	inPath   = "path"
+	inQuery  = "query"
+	inHeader = "header"
+	inCookie = "cookie"
+)
+
+// ContractVar is an argument or result of method and its place in request or response.
+type ContractVar struct {
+	Name     string        `json:"name"`
+	In       string        `json:"in"`
+	Key      string        `json:"key"`
+	Type     *ContractType `json:"type"`
+	Required bool          `json:"required,omitempty"`
+}
+
+type ContractType struct {
+	Kind    string          `json:"kind"`
+	Name    string          `json:"name,omitempty"`
+	Package string          `json:"package,omitempty"`
+	Len     int             `json:"len,omitempty"`
+	Key     *ContractType   `json:"key,omitempty"`
+	Elem    *ContractType   `json:"elem,omitempty"`
+	Fields  []ContractField `json:"fields,omitempty"`
+}
+
+const (
+	kindBuiltin   = "builtin"
+	kindNamed     = "named"
+	kindPointer   = "pointer"
+	kindSlice     = "slice"
+	kindArray     = "array"
+	kindMap       = "map"
+	kindStruct    = "struct"
+	kindInterface = "interface"
+	kindChan      = "chan"
+	kindFunc      = "func"
+)
+
+// ContractTypeDef is a definition of named type. Struct types have Fields, other types have Underlying.
+// Types from standard library and not found types are kept opaque (without definition).
+type ContractTypeDef struct {
+	Name       string          `json:"name"`
+	Package    string          `json:"package"`
+	Doc        string          `json:"doc,omitempty"`
+	Opaque     bool            `json:"opaque,omitempty"`
+	Fields     []ContractField `json:"fields,omitempty"`
+	Underlying *ContractType   `json:"underlying,omitempty"`
+	Values     []ContractValue `json:"values,omitempty"`
+}
+
+type ContractField struct {
+	Name     string        `json:"name"`
+	JSON     string        `json:"json"`
+	Doc      string        `json:"doc,omitempty"`
+	Type     *ContractType `json:"type"`
+	Inline   bool          `json:"inline,omitempty"`
+	Required bool          `json:"required,omitempty"`
+}
+
+type ContractValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value,omitempty"`
+}
+
+func (t *ContractType) String() string {
+
+	if t == nil {
+		return ""
+	}
+	switch t.Kind {
+	case kindNamed:
+		return t.Package + "." + t.Name
+	case kindPointer:
+		return "*" + t.Elem.String()
+	case kindSlice:
+		return "[]" + t.Elem.String()
+	case kindArray:
+		return fmt.Sprintf("[%d]%s", t.Len, t.Elem.String())
+	case kindMap:
+		return fmt.Sprintf("map[%s]%s", t.Key.String(), t.Elem.String())
+	case kindStruct:
+		fields := make([]string, 0, len(t.Fields))
+		for _, field := range t.Fields {
+			fields = append(fields, field.JSON+" "+field.Type.String())
+		}
+		return "struct{" + strings.Join(fields, "; ") + "}"
+	case kindChan:
+		return "chan " + t.Elem.String()
+	default:
+		return t.Name
+	}
+}
+
+// Contract builds resolved model of services. DTO packages are searched the same way as for generation.
+func (tr *Transport) Contract() (contract *Contract) {
+
+	contract = &Contract{
+		Version: ContractVersion,
+		Tags:    tr.tags,
+		Types:   make(map[string]*ContractTypeDef),
+	}
+	if tr.module != nil && tr.module.Module != nil {
+		contract.Module = tr.module.Module.Mod.Path
+	}
+	for _, serviceName := range tr.serviceKeys() {
+		contract.Services = append(contract.Services, tr.services[serviceName].contract(contract))
+	}
+	return
+}
+
+func (svc *service) contract(contract *Contract) (cs ContractService) {
+
+	cs = ContractService{
+		Name:          svc.Name,
+		Package:       svc.pkgPath,
+		Doc:           docText(svc.Docs),
+		Pos:           svc.tr.relPosition(svc.Pos),
+		Tags:          tags.ParseTags(svc.Docs),
+		EffectiveTags: svc.tags,
+		HTTP:          svc.tags.Contains(tagServerHTTP),
+		GRPC:          svc.tags.Contains(tagServerGRPC),
+	}
+	if svc.isJsonRPC() {
+		cs.JsonRPC = &ContractJsonRPC{Path: svc.batchPath()}
+	}
+	for _, method := range svc.methods {
+		cs.Methods = append(cs.Methods, method.contract(contract))
+	}
+	return
+}
+
+func (m *method) contract(contract *Contract) (cm ContractMethod) {
+
+	cm = ContractMethod{
+		Name:          m.Name,
+		Doc:           docText(m.Docs),
+		Pos:           m.svc.tr.relPosition(m.Pos),
+		Tags:          tags.ParseTags(m.Docs),
+		EffectiveTags: m.tags,
+	}
+	if m.isJsonRPC() {
+		cm.JsonRPC = &ContractJsonRPC{Method: m.fullName(), Path: m.jsonrpcPath()}
+	}
+	if m.isHTTP() {
+		cm.HTTP = &ContractHTTP{
+			Method:  strings.ToUpper(m.httpMethod()),
+			Path:    m.httpPath(),
+			Success: m.tags.ValueInt(tagHttpSuccess, 200),
+		}
+	}
+	builder := contractBuilder{tr: m.svc.tr, contract: contract}
+	for _, field := range m.fieldsArgument() {
+		cv := ContractVar{Name: field.Name, In: inBody, Key: field.Name, Required: isRequiredGeneratedRequestField(field)}
+		if key, found := m.argPathMap()[field.Name]; found {
+			cv.In, cv.Key, cv.Required = inPath, key, true
+		} else if key, found = m.argParamMap()[field.Name]; found {
+			cv.In, cv.Key = inQuery, key
+		} else if key, found = m.varHeaderMap()[field.Name]; found {
+			cv.In, cv.Key = inHeader, key
+		} else if key, found = m.varCookieMap()[field.Name]; found {
+			cv.In, cv.Key = inCookie, key
+		} else {
+			cv.Key = varJSONName(field)
+		}
+		cv.Type = builder.typeOf(m.svc.pkgPath, field.Type)
+		cm.Args = append(cm.Args, cv)
+	}
+	for _, field := range m.fieldsResult() {
+		cv := ContractVar{Name: field.Name, In: inBody, Key: field.Name, Required: isRequiredGeneratedResponseField(field)}
+		if key, found := m.varHeaderMap()[field.Name]; found {
+			cv.In, cv.Key = inHeader, key
+		} else if key, found = m.varCookieMap()[field.Name]; found {
+			cv.In, cv.Key = inCookie, key
+		} else {
+			cv.Key = varJSONName(field)
+		}
+		cv.Type = builder.typeOf(m.svc.pkgPath, field.Type)
+		cm.Results = append(cm.Results, cv)
+	}
+	return
+}
+
+// relPosition returns position with file name relative to module root.
+func (tr *Transport) relPosition(pos types.Position) types.Position {
+
+	if pos.Filename == "" || tr.modPath == "" {
+		return pos
+	}
+	if rel, err := filepath.Rel(filepath.Dir(tr.modPath), pos.Filename); err == nil {
+		pos.Filename = filepath.ToSlash(rel)
+	}
+	return pos
+}
+
+type contractBuilder struct {
+	tr       *Transport
+	contract *Contract
+}
+
+func (b contractBuilder) typeOf(pkg string, varType types.Type) *ContractType {
+
+	switch vType := varType.(type) {
+	case types.TName:
+		if types.IsBuiltin(vType) {
+			return &ContractType{Kind: kindBuiltin, Name: vType.TypeName}
+		}
+		return b.named(pkg, vType.TypeName)
+	case types.TImport:
+		if name, ok := vType.Next.(types.TName); ok && vType.Import != nil {
+			return b.named(vType.Import.Package, name.TypeName)
+		}
+		return b.typeOf(pkg, vType.Next)
+	case types.TPointer:
+		t := b.typeOf(pkg, vType.Next)
+		for i := 0; i < max(vType.NumberOfPointers, 1); i++ {
+			t = &ContractType{Kind: kindPointer, Elem: t}
+		}
+		return t
+	case types.TArray:
+		if vType.IsSlice {
+			return &ContractType{Kind: kindSlice, Elem: b.typeOf(pkg, vType.Next)}
+		}
+		return &ContractType{Kind: kindArray, Len: vType.ArrayLen, Elem: b.typeOf(pkg, vType.Next)}
+	case types.TEllipsis:
+		return &ContractType{Kind: kindSlice, Elem: b.typeOf(pkg, vType.Next)}
+	case types.TMap:
+		return &ContractType{Kind: kindMap, Key: b.typeOf(pkg, vType.Key), Elem: b.typeOf(pkg, vType.Value)}
+	case types.TChan:
+		return &ContractType{Kind: kindChan, Elem: b.typeOf(pkg, vType.Next)}
+	case types.TInstance:
+		return b.instance(pkg, vType)
+	case types.Struct:
+		return &ContractType{Kind: kindStruct, Fields: b.fields(pkg, vType.Fields)}
+	case types.TInterface:
+		return &ContractType{Kind: kindInterface, Name: "any"}
+	case types.Function:
+		return &ContractType{Kind: kindFunc, Name: "func"}
+	default:
+		return &ContractType{Kind: kindBuiltin, Name: fmt.Sprint(varType)}
+	}
+}
+
+func (b contractBuilder) named(pkg, name string) *ContractType {
+
+	key := pkg + "." + name
+	if _, found := b.contract.Types[key]; !found {
+		def := &ContractTypeDef{Name: name, Package: pkg, Opaque: true}
+		b.contract.Types[key] = def
+		if !isStdPackage(pkg) {
+			b.define(def)
+		}
+	}
+	return &ContractType{Kind: kindNamed, Name: name, Package: pkg}
+}
+
+// instance defines instantiation of generic struct as named type 'Page[example.com/dto.User]' with substituted fields.
+func (b contractBuilder) instance(pkg string, instance types.TInstance) *ContractType {
+
+	inst, defPkg, found := b.tr.instantiate(instance, pkg)
+	if !found {
+		return b.typeOf(pkg, instance.Next)
+	}
+	args := make([]string, 0, len(instance.Args))
+	for _, arg := range instance.Args {
+		args = append(args, b.typeOf(pkg, arg).String())
+	}
+	name := typeArgName(instance.Next) + "[" + strings.Join(args, ", ") + "]"
+	key := defPkg + "." + name
+	if _, found = b.contract.Types[key]; !found {
+		def := &ContractTypeDef{Name: name, Package: defPkg, Doc: docText(inst.Docs)}
+		b.contract.Types[key] = def
+		def.Fields = b.fields(defPkg, inst.Fields)
+	}
+	return &ContractType{Kind: kindNamed, Name: name, Package: defPkg}
+}
+
+func (b contractBuilder) define(def *ContractTypeDef) {
+
+	nextType, constants := b.tr.searchTypeWithConstants(def.Package, def.Name)
+	if nextType == nil {
+		return
+	}
+	def.Opaque = false
+	switch vType := nextType.(type) {
+	case types.Struct:
+		def.Doc = docText(vType.Docs)
+		def.Fields = b.fields(def.Package, vType.Fields)
+	default:
+		def.Underlying = b.typeOf(def.Package, nextType)
+	}
+	for _, constant := range flatConstants(constants) {
+		if typeName := types.TypeName(constant.Type); constant.Type != nil && typeName != nil && *typeName == def.Name {
+			def.Values = append(def.Values, ContractValue{Name: constant.Name, Value: constantValue(constant.Value)})
+		}
+	}
+	sort.Slice(def.Values, func(i, j int) bool { return def.Values[i].Name < def.Values[j].Name })
+}
+
+func (b contractBuilder) fields(pkg string, fields []types.StructField) (cf []ContractField) {
+
+	for _, field := range fields {
+		name, inline := jsonName(field)
+		if name == "-" {
+			continue
+		}
+		if field.Name == "" && len(field.Tags["json"]) == 0 {
+			inline = true
+		}
+		cf = append(cf, ContractField{
+			Name:     field.Name,
+			JSON:     name,
+			Doc:      docText(field.Docs),
+			Type:     b.typeOf(pkg, field.Type),
+			Inline:   inline,
+			Required: isRequiredJSONField(field),
+		})
+	}
+	return
+}
+
+// varJSONName returns key of argument or result in JSON body: name of variable or value of its json tag.
+func varJSONName(field types.StructField) string {
+
+	if tagValues := field.Tags["json"]; len(tagValues) > 0 && tagValues[0] != "" {
+		return tagValues[0]
+	}
+	return field.Name
+}
+
+func flatConstants(constants []types.Constant) (flat []types.Constant) {
+
+	for _, constant := range constants {
+		flat = append(flat, constant)
+		flat = append(flat, flatConstants(constant.Constants)...)
+	}
+	return
+}
+
+// constantValue returns value of constant as it is written in source code.
+func constantValue(value any) string {
+
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+// stdPackages caches membership of import paths in standard library.
+var stdPackages sync.Map
+
+// isStdPackage reports whether import path belongs to standard library: package is found in GOROOT.
+// Module path may have no dot ('module myapi'), so first element of path is checked only without GOROOT.
+func isStdPackage(pkg string) bool {
+
+	if std, found := stdPackages.Load(pkg); found {
+		return std.(bool)
+	}
+	var std bool
+	if _, err := os.Stat(filepath.Join(build.Default.GOROOT, "src")); err != nil {
+		std = !strings.Contains(strings.Split(pkg, "/")[0], ".")
+	} else if pkgInfo, err := build.Default.Import(pkg, "", build.FindOnly); err == nil {
+		std = pkgInfo.Goroot
+	}
+	stdPackages.Store(pkg, std)
+	return std
+}
+
+// docText returns text of doc comments without '@tg' annotations.
+func docText(docs []string) string {
+
+	var lines []string
+	for _, doc := range docs {
+		for _, line := range strings.Split(doc, "\n") {
+			line = strings.TrimSpace(line)
+			line = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(line, "//"), "/*"), "*/"))
+			if strings.HasPrefix(line, "@tg") {
+				continue
+			}
+			lines = append(lines, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,477 @@
+package generator
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/seniorGolang/tg/v2/pkg/tags"
+)
+
+type ChangeKind string
+
+const (
+	ChangeBreaking    ChangeKind = "breaking"
+	ChangeNonBreaking ChangeKind = "non-breaking"
+	ChangeDocs        ChangeKind = "docs"
+)
+
+type Change struct {
+	Kind    ChangeKind `json:"kind"`
+	Path    string     `json:"path"`
+	Message string     `json:"message"`
+}
+
+// DiffReport is a classified list of changes between two versions of contract.
+type DiffReport struct {
+	BaseVersion string   `json:"baseVersion,omitempty"`
+	HeadVersion string   `json:"headVersion,omitempty"`
+	MajorBump   bool     `json:"majorBump"`
+	Breaking    int      `json:"breaking"`
+	NonBreaking int      `json:"nonBreaking"`
+	Docs        int      `json:"docs"`
+	Changes     []Change `json:"changes"`
+}
+
+// Blocked reports whether there are breaking changes without bump of major version ('@tg version=').
+func (report DiffReport) Blocked() bool {
+	return report.Breaking != 0 && !report.MajorBump
+}
+
+const (
+	dirRequest  = "request"
+	dirResponse = "response"
+)
+
+// docTags are annotations, which change only documentation of contract.
+var docTags = []string{tagDesc, tagSummary, tagExample, tagSwaggerTags, tagTitle, tagDeprecated, tagAuthor, tagLicense, tagServers}
+
+// wireTags are annotations, which effect is compared by resolved routes, fields and types.
+var wireTags = []string{
+	tagMethodHTTP, tagHttpPath, tagHttpPrefix, tagHttpArg, tagHttpHeader, tagHttpCookies, tagHttpSuccess,
+	tagServerHTTP, tagServerJsonRPC, tagTag, tagRequired, tagAppVersion,
+}
+
+type contractDiff struct {
+	base    *Contract
+	head    *Contract
+	visited map[string]bool
+	report  DiffReport
+}
+
+// DiffContracts compares base and head versions of contract.
+func DiffContracts(base, head *Contract) (report DiffReport) {
+
+	diff := &contractDiff{base: base, head: head, visited: make(map[string]bool)}
+	diff.report.BaseVersion = base.Tags.Value(tagAppVersion)
+	diff.report.HeadVersion = head.Tags.Value(tagAppVersion)
+	diff.report.MajorBump = majorVersion(diff.report.HeadVersion) > majorVersion(diff.report.BaseVersion)
+	diff.compareTags("", base.Tags, head.Tags)
+	baseServices := make(map[string]ContractService)
+	for _, svc := range base.Services {
+		baseServices[svc.Name] = svc
+	}
+	headServices := make(map[string]ContractService)
+	for _, svc := range head.Services {
+		headServices[svc.Name] = svc
+		if _, found := baseServices[svc.Name]; !found {
+			diff.add(ChangeNonBreaking, svc.Name, "service added")
+		}
+	}
+	for _, svc := range base.Services {
+		headSvc, found := headServices[svc.Name]
+		if !found {
+			diff.add(ChangeBreaking, svc.Name, "service removed")
+			continue
+		}
+		diff.compareService(svc, headSvc)
+	}
+	sort.SliceStable(diff.report.Changes, func(i, j int) bool {
+		return diff.report.Changes[i].Path < diff.report.Changes[j].Path
+	})
+	return diff.report
+}
+
+func (diff *contractDiff) add(kind ChangeKind, path, format string, args ...any) {
+
+	change := Change{Kind: kind, Path: path, Message: fmt.Sprintf(format, args...)}
+	if slices.Contains(diff.report.Changes, change) {
+		return
+	}
+	diff.report.Changes = append(diff.report.Changes, change)
+	switch kind {
+	case ChangeBreaking:
+		diff.report.Breaking++
+	case ChangeNonBreaking:
+		diff.report.NonBreaking++
+	case ChangeDocs:
+		diff.report.Docs++
+	}
+}
+
+func (diff *contractDiff) compareService(base, head ContractService) {
+
+	path := base.Name
+	if base.Doc != head.Doc {
+		diff.add(ChangeDocs, path, "doc comment changed")
+	}
+	diff.compareTags(path, base.Tags, head.Tags)
+	switch {
+	case base.JsonRPC != nil && head.JsonRPC == nil:
+		diff.add(ChangeBreaking, path, "JSON-RPC server removed")
+	case base.JsonRPC == nil && head.JsonRPC != nil:
+		diff.add(ChangeNonBreaking, path, "JSON-RPC server added")
+	case base.JsonRPC != nil && base.JsonRPC.Path != head.JsonRPC.Path:
+		diff.add(ChangeBreaking, path, "batch path changed: %s -> %s", base.JsonRPC.Path, head.JsonRPC.Path)
+	}
+	headMethods := make(map[string]ContractMethod)
+	baseMethods := make(map[string]bool)
+	for _, method := range base.Methods {
+		baseMethods[method.Name] = true
+	}
+	for _, method := range head.Methods {
+		headMethods[method.Name] = method
+		if !baseMethods[method.Name] {
+			diff.add(ChangeNonBreaking, path+"."+method.Name, "method added")
+		}
+	}
+	for _, method := range base.Methods {
+		headMethod, found := headMethods[method.Name]
+		if !found {
+			diff.add(ChangeBreaking, path+"."+method.Name, "method removed")
+			continue
+		}
+		diff.compareMethod(path+"."+method.Name, method, headMethod)
+	}
+}
+
+func (diff *contractDiff) compareMethod(path string, base, head ContractMethod) {
+
+	if base.Doc != head.Doc {
+		diff.add(ChangeDocs, path, "doc comment changed")
+	}
+	diff.compareTags(path, base.Tags, head.Tags)
+	switch {
+	case base.JsonRPC != nil && head.JsonRPC == nil:
+		diff.add(ChangeBreaking, path, "JSON-RPC method removed")
+	case base.JsonRPC == nil && head.JsonRPC != nil:
+		diff.add(ChangeNonBreaking, path, "JSON-RPC method added")
+	case base.JsonRPC != nil:
+		if base.JsonRPC.Method != head.JsonRPC.Method {
+			diff.add(ChangeBreaking, path, "JSON-RPC method renamed: %s -> %s", base.JsonRPC.Method, head.JsonRPC.Method)
+		}
+		if base.JsonRPC.Path != head.JsonRPC.Path {
+			diff.add(ChangeBreaking, path, "JSON-RPC path changed: %s -> %s", base.JsonRPC.Path, head.JsonRPC.Path)
+		}
+	}
+	switch {
+	case base.HTTP != nil && head.HTTP == nil:
+		diff.add(ChangeBreaking, path, "HTTP route removed")
+	case base.HTTP == nil && head.HTTP != nil:
+		diff.add(ChangeNonBreaking, path, "HTTP route added")
+	case base.HTTP != nil:
+		if base.HTTP.Method != head.HTTP.Method {
+			diff.add(ChangeBreaking, path, "http-method changed: %s -> %s", base.HTTP.Method, head.HTTP.Method)
+		}
+		if routeParam.ReplaceAllString(base.HTTP.Path, ":") != routeParam.ReplaceAllString(head.HTTP.Path, ":") {
+			diff.add(ChangeBreaking, path, "http-path changed: %s -> %s", base.HTTP.Path, head.HTTP.Path)
+		}
+		if base.HTTP.Success != head.HTTP.Success {
+			diff.add(ChangeBreaking, path, "http-success changed: %d -> %d", base.HTTP.Success, head.HTTP.Success)
+		}
+	}
+	diff.compareVars(path, dirRequest, base.Args, head.Args)
+	diff.compareVars(path, dirResponse, base.Results, head.Results)
+}
+
+func (diff *contractDiff) compareVars(path, dir string, base, head []ContractVar) {
+
+	headVars := make(map[string]ContractVar)
+	baseVars := make(map[string]bool)
+	for _, v := range base {
+		baseVars[v.Name] = true
+	}
+	for _, v := range head {
+		headVars[v.Name] = v
+		if baseVars[v.Name] {
+			continue
+		}
+		switch {
+		case dir == dirRequest && v.Required:
+			diff.add(ChangeBreaking, path+"."+v.Name, "required argument added (%s '%s')", v.In, v.Key)
+		case dir == dirRequest:
+			diff.add(ChangeNonBreaking, path+"."+v.Name, "optional argument added (%s '%s')", v.In, v.Key)
+		default:
+			diff.add(ChangeNonBreaking, path+"."+v.Name, "result added (%s '%s')", v.In, v.Key)
+		}
+	}
+	for _, v := range base {
+		varPath := path + "." + v.Name
+		headVar, found := headVars[v.Name]
+		if !found {
+			if dir == dirRequest {
+				diff.add(ChangeNonBreaking, varPath, "argument removed (%s '%s')", v.In, v.Key)
+			} else {
+				diff.add(ChangeBreaking, varPath, "result removed (%s '%s')", v.In, v.Key)
+			}
+			continue
+		}
+		if v.In != headVar.In || v.Key != headVar.Key {
+			diff.add(ChangeBreaking, varPath, "moved: %s '%s' -> %s '%s'", v.In, v.Key, headVar.In, headVar.Key)
+		}
+		diff.compareRequired(varPath, dir, v.Required, headVar.Required)
+		diff.compareType(varPath, dir, v.Type, headVar.Type)
+	}
+}
+
+func (diff *contractDiff) compareRequired(path, dir string, base, head bool) {
+
+	switch {
+	case base == head:
+	case dir == dirRequest && head:
+		diff.add(ChangeBreaking, path, "became required")
+	case dir == dirResponse && !head:
+		diff.add(ChangeBreaking, path, "became optional")
+	default:
+		diff.add(ChangeNonBreaking, path, "required changed: %v -> %v", base, head)
+	}
+}
+
+func (diff *contractDiff) compareType(path, dir string, base, head *ContractType) {
+
+	if base == nil || head == nil {
+		return
+	}
+	if base.Kind != head.Kind {
+		switch {
+		case head.Kind == kindPointer && reflect.DeepEqual(base, head.Elem):
+			diff.addNullable(path, dir, true)
+		case base.Kind == kindPointer && reflect.DeepEqual(base.Elem, head):
+			diff.addNullable(path, dir, false)
+		default:
+			diff.add(ChangeBreaking, path, "type changed: %s -> %s", base, head)
+		}
+		return
+	}
+	switch base.Kind {
+	case kindNamed:
+		diff.compareNamed(path, dir, base, head)
+	case kindPointer, kindSlice, kindChan:
+		diff.compareType(path, dir, base.Elem, head.Elem)
+	case kindArray:
+		if base.Len != head.Len {
+			diff.add(ChangeBreaking, path, "type changed: %s -> %s", base, head)
+			return
+		}
+		diff.compareType(path, dir, base.Elem, head.Elem)
+	case kindMap:
+		diff.compareType(path+"[key]", dir, base.Key, head.Key)
+		diff.compareType(path, dir, base.Elem, head.Elem)
+	case kindStruct:
+		diff.compareFields(path, dir, base.Fields, head.Fields)
+	default:
+		if base.Name != head.Name {
+			diff.add(ChangeBreaking, path, "type changed: %s -> %s", base, head)
+		}
+	}
+}
+
+func (diff *contractDiff) addNullable(path, dir string, nullable bool) {
+
+	switch {
+	case dir == dirResponse && nullable:
+		diff.add(ChangeBreaking, path, "became nullable")
+	case dir == dirRequest && !nullable:
+		diff.add(ChangeBreaking, path, "became not nullable")
+	default:
+		diff.add(ChangeNonBreaking, path, "nullable changed: %v -> %v", !nullable, nullable)
+	}
+}
+
+func (diff *contractDiff) compareNamed(path, dir string, base, head *ContractType) {
+
+	baseDef, headDef := diff.base.Types[base.String()], diff.head.Types[head.String()]
+	if baseDef == nil || headDef == nil || baseDef.Opaque || headDef.Opaque {
+		if base.String() != head.String() {
+			diff.add(ChangeBreaking, path, "type changed: %s -> %s", base, head)
+		}
+		return
+	}
+	if base.String() != head.String() {
+		diff.add(ChangeNonBreaking, path, "type renamed: %s -> %s", base, head)
+	}
+	key := strings.Join([]string{dir, base.String(), head.String()}, "|")
+	if diff.visited[key] {
+		return
+	}
+	diff.visited[key] = true
+	path = head.String()
+	if baseDef.Doc != headDef.Doc {
+		diff.add(ChangeDocs, path, "doc comment changed")
+	}
+	switch {
+	case baseDef.Underlying == nil && headDef.Underlying == nil:
+		diff.compareFields(path, dir, baseDef.Fields, headDef.Fields)
+	case baseDef.Underlying != nil && headDef.Underlying != nil:
+		diff.compareType(path, dir, baseDef.Underlying, headDef.Underlying)
+	default:
+		diff.add(ChangeBreaking, path, "type changed: struct <-> non-struct")
+	}
+	diff.compareValues(path, dir, baseDef.Values, headDef.Values)
+}
+
+func (diff *contractDiff) compareFields(path, dir string, base, head []ContractField) {
+
+	headFields := make(map[string]ContractField)
+	baseFields := make(map[string]bool)
+	byName := make(map[string]ContractField)
+	for _, field := range base {
+		baseFields[field.JSON] = true
+	}
+	for _, field := range head {
+		headFields[field.JSON] = field
+		if field.Name != "" {
+			byName[field.Name] = field
+		}
+	}
+	for _, field := range head {
+		if baseFields[field.JSON] {
+			continue
+		}
+		if diff.renamedFrom(base, field) != "" {
+			continue
+		}
+		switch {
+		case dir == dirRequest && field.Required && !field.Inline:
+			diff.add(ChangeBreaking, path+"."+field.JSON, "required field added")
+		default:
+			diff.add(ChangeNonBreaking, path+"."+field.JSON, "field added")
+		}
+	}
+	for _, field := range base {
+		fieldPath := path + "." + field.JSON
+		headField, found := headFields[field.JSON]
+		if !found {
+			if renamed, ok := byName[field.Name]; ok && field.Name != "" {
+				diff.add(ChangeBreaking, fieldPath, "JSON name changed: %s -> %s", field.JSON, renamed.JSON)
+				continue
+			}
+			if dir == dirRequest {
+				diff.add(ChangeNonBreaking, fieldPath, "field removed")
+			} else {
+				diff.add(ChangeBreaking, fieldPath, "field removed")
+			}
+			continue
+		}
+		if field.Doc != headField.Doc {
+			diff.add(ChangeDocs, fieldPath, "doc comment changed")
+		}
+		if field.Inline != headField.Inline {
+			diff.add(ChangeBreaking, fieldPath, "inline changed: %v -> %v", field.Inline, headField.Inline)
+		}
+		diff.compareRequired(fieldPath, dir, field.Required, headField.Required)
+		diff.compareType(fieldPath, dir, field.Type, headField.Type)
+	}
+}
+
+// renamedFrom returns JSON name of base field with the same Go name as head field.
+func (diff *contractDiff) renamedFrom(base []ContractField, head ContractField) string {
+
+	for _, field := range base {
+		if head.Name != "" && field.Name == head.Name && field.JSON != head.JSON {
+			return field.JSON
+		}
+	}
+	return ""
+}
+
+func (diff *contractDiff) compareValues(path, dir string, base, head []ContractValue) {
+
+	baseValues := make(map[string]bool)
+	for _, value := range base {
+		baseValues[value.key()] = true
+	}
+	headValues := make(map[string]bool)
+	for _, value := range head {
+		headValues[value.key()] = true
+		if !baseValues[value.key()] && len(base) != 0 {
+			if dir == dirResponse {
+				diff.add(ChangeBreaking, path, "enum value %s added to response", value)
+			} else {
+				diff.add(ChangeNonBreaking, path, "enum value %s added", value)
+			}
+		}
+	}
+	for _, value := range base {
+		if !headValues[value.key()] {
+			if dir == dirRequest {
+				diff.add(ChangeBreaking, path, "enum value %s removed", value)
+			} else {
+				diff.add(ChangeNonBreaking, path, "enum value %s removed from response", value)
+			}
+		}
+	}
+}
+
+// key returns value of constant or its name for constants without explicit value (iota).
+func (value ContractValue) key() string {
+
+	if value.Value == "" {
+		return value.Name
+	}
+	return value.Value
+}
+
+func (value ContractValue) String() string {
+
+	if value.Value == "" {
+		return value.Name
+	}
+	return fmt.Sprintf("%s (%s)", value.Value, value.Name)
+}
+
+func (diff *contractDiff) compareTags(path string, base, head tags.DocTags) {
+
+	keys := make(map[string]bool)
+	for key := range base {
+		keys[key] = true
+	}
+	for key := range head {
+		keys[key] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+	for _, key := range sorted {
+		baseValue, inBase := base[key]
+		headValue, inHead := head[key]
+		if inBase == inHead && baseValue == headValue {
+			continue
+		}
+		tagName := key[strings.LastIndex(key, ".")+1:]
+		switch {
+		case slices.Contains(docTags, tagName):
+			diff.add(ChangeDocs, path, "annotation '%s' changed", key)
+		case slices.Contains(wireTags, tagName):
+		case !inHead:
+			diff.add(ChangeNonBreaking, path, "annotation '%s' removed", key)
+		case !inBase:
+			diff.add(ChangeNonBreaking, path, "annotation '%s' added", key)
+		default:
+			diff.add(ChangeNonBreaking, path, "annotation '%s' changed: %s -> %s", key, baseValue, headValue)
+		}
+	}
+}
+
+// majorVersion returns major part of version like 'v1.2.3' or '2.0'. Zero, when version is not set.
+func majorVersion(version string) int {
+
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	major, _ := strconv.Atoi(strings.SplitN(version, ".", 2)[0])
+	return major
+}
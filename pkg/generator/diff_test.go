@@ -0,0 +1,56 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/seniorGolang/tg/v2/pkg/tags"
+)
+
+func TestDiffContractsClassifiesChanges(t *testing.T) {
+
+	user := func(fields ...ContractField) map[string]*ContractTypeDef {
+		return map[string]*ContractTypeDef{"example.com/dto.User": {Name: "User", Package: "example.com/dto", Fields: fields}}
+	}
+	userType := &ContractType{Kind: kindNamed, Name: "User", Package: "example.com/dto"}
+	intType := &ContractType{Kind: kindBuiltin, Name: "int"}
+	stringType := &ContractType{Kind: kindBuiltin, Name: "string"}
+	contract := func(version, path string, types map[string]*ContractTypeDef, methods ...ContractMethod) *Contract {
+		return &Contract{
+			Tags:  tags.DocTags{tagAppVersion: version},
+			Types: types,
+			Services: []ContractService{{
+				Name:    "User",
+				Methods: append([]ContractMethod{{Name: "Get", HTTP: &ContractHTTP{Method: "GET", Path: path, Success: 200}, Results: []ContractVar{{Name: "user", In: inBody, Key: "user", Type: userType, Required: true}}}}, methods...),
+			}},
+		}
+	}
+	base := contract("v1.0.0", "/user/:id",
+		user(ContractField{Name: "ID", JSON: "id", Type: intType, Required: true}, ContractField{Name: "Name", JSON: "name", Type: stringType}),
+		ContractMethod{Name: "Delete", Tags: tags.DocTags{tagDesc: "delete"}},
+	)
+	head := contract("v1.1.0", "/user/:userID",
+		user(ContractField{Name: "ID", JSON: "id", Type: stringType, Required: true}, ContractField{Name: "Name", JSON: "fullName", Type: stringType}),
+	)
+	report := DiffContracts(base, head)
+	expected := map[string]ChangeKind{
+		"User.Delete: method removed":                                    ChangeBreaking,
+		"example.com/dto.User.id: type changed: int -> string":           ChangeBreaking,
+		"example.com/dto.User.name: JSON name changed: name -> fullName": ChangeBreaking,
+	}
+	if len(report.Changes) != len(expected) {
+		t.Fatalf("unexpected changes: %+v", report.Changes)
+	}
+	for _, change := range report.Changes {
+		if kind, found := expected[change.Path+": "+change.Message]; !found || kind != change.Kind {
+			t.Fatalf("unexpected change: %+v", change)
+		}
+	}
+	if !report.Blocked() {
+		t.Fatalf("breaking changes without major bump must block release")
+	}
+	head.Tags[tagAppVersion] = "v2.0.0"
+	head.Services[0].Methods[0].Doc = "get user"
+	if report = DiffContracts(base, head); report.Blocked() || report.Docs != 1 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+}
@@ -0,0 +1,81 @@
+package generator
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/seniorGolang/tg/v2/pkg/astra"
+	"github.com/seniorGolang/tg/v2/pkg/tags"
+)
+
+// FindServices returns directories of services packages matched by pattern. Pattern is a directory
+// or a directory with '/...' suffix, which matches all packages under it, as in go tool.
+// Package is a services package, when it contains interface annotated by '@tg'.
+// Directories 'vendor', 'testdata', 'node_modules' and names started with '.' or '_' are skipped.
+func FindServices(pattern string) (dirs []string, err error) {
+
+	root, recursive := strings.CutSuffix(filepath.ToSlash(pattern), "/...")
+	if root == "..." {
+		root, recursive = ".", true
+	}
+	root = filepath.FromSlash(root)
+	if !recursive {
+		if hasServices(root) {
+			dirs = append(dirs, filepath.Clean(root))
+		}
+		return
+	}
+	err = filepath.WalkDir(root, func(dir string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		if dir != root && skipDir(entry.Name()) {
+			return filepath.SkipDir
+		}
+		if hasServices(dir) {
+			dirs = append(dirs, dir)
+		}
+		return nil
+	})
+	sort.Strings(dirs)
+	return
+}
+
+func skipDir(name string) bool {
+	return name == "vendor" || name == "testdata" || name == "node_modules" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
+
+// hasServices checks, that package in dir has annotated interface. Files without '@tg' are not parsed.
+func hasServices(dir string) bool {
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".go") {
+			continue
+		}
+		filePath := filepath.Join(dir, file.Name())
+		if data, err := os.ReadFile(filePath); err != nil || !bytes.Contains(data, []byte("@tg")) {
+			continue
+		}
+		srcFile, err := astra.ParseFile(filePath, astra.IgnoreMethods, astra.IgnoreFunctions, astra.IgnoreStructs, astra.IgnoreTypes, astra.IgnoreConstants, astra.IgnoreVariables)
+		if err != nil {
+			continue
+		}
+		for _, iface := range srcFile.Interfaces {
+			if len(tags.ParseTags(iface.Docs)) != 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package generator
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestFindServices(t *testing.T) {
+
+	files := map[string]string{
+		"go.mod":                    "module example.com/api\n\ngo 1.22\n",
+		"users/service/user.go":     "package service\n\n// @tg jsonRPC-server\ntype User interface{}\n",
+		"shop/service/shop.go":      "// @tg version=1.0.0\npackage service\n\n// @tg http-server\ntype Shop interface{}\n",
+		"shop/dto/item.go":          "package dto\n\n// Item mentions @tg in comment of struct.\ntype Item struct{}\n",
+		"vendor/lib/service/lib.go": "package service\n\n// @tg jsonRPC-server\ntype Lib interface{}\n",
+		".cache/service/cached.go":  "package service\n\n// @tg jsonRPC-server\ntype Cached interface{}\n",
+	}
+	writeProject(t, files)
+	dirs, err := FindServices("./...")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := []string{filepath.Join("shop", "service"), filepath.Join("users", "service")}; !slices.Equal(dirs, expected) {
+		t.Fatalf("expected %v, got %v", expected, dirs)
+	}
+	if dirs, _ = FindServices("./shop/service"); len(dirs) != 1 {
+		t.Fatalf("directory pattern is not matched: %v", dirs)
+	}
+	if dirs, _ = FindServices("./shop/dto"); len(dirs) != 0 {
+		t.Fatalf("package without annotated interfaces is matched: %v", dirs)
+	}
+}
@@ -0,0 +1,61 @@
+package generator
+
+import (
+	"strings"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"github.com/seniorGolang/tg/v2/pkg/tags"
+)
+
+// description returns 'desc' annotation of method or text of its doc comment, annotation wins.
+func (m *method) description() string {
+	return description(m.Docs)
+}
+
+// description returns 'desc' annotation of declaration or text of its doc comment.
+func description(docs []string) string {
+	return tags.ParseTags(docs).Value(tagDesc, docText(docs))
+}
+
+// docComment returns godoc comment, each line of text is a separate line of comment. Nothing is returned for empty text.
+func docComment(text string) (code *Statement) {
+
+	code = Null()
+	if text == "" {
+		return
+	}
+	for _, line := range strings.Split(text, "\n") {
+		code.Comment(line).Line()
+	}
+	return
+}
+
+// jsDocText escapes end of comment in text, so text of doc cannot close JSDoc comment.
+func jsDocText(text string) string {
+	return strings.ReplaceAll(text, "*/", "*\\/")
+}
+
+// jsDocLines returns lines of JSDoc comment body, each line of text is prefixed by '*'.
+func jsDocLines(text string) (lines string) {
+
+	if text == "" {
+		return
+	}
+	for _, line := range strings.Split(jsDocText(text), "\n") {
+		lines += strings.TrimRight("* "+line, " ") + "\n"
+	}
+	return
+}
+
+// tsDoc returns TSDoc comment of text or empty string.
+func tsDoc(text string) string {
+
+	if text == "" {
+		return ""
+	}
+	if !strings.Contains(text, "\n") {
+		return "/** " + jsDocText(text) + " */\n"
+	}
+	return "/**\n" + jsDocLines(text) + "*/\n"
+}
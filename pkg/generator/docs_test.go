@@ -0,0 +1,105 @@
+package generator
+
+import (
+	"io/fs"
+	"strings"
+	"testing"
+
+	"github.com/seniorGolang/tg/v2/pkg/config"
+)
+
+func TestGenerateDocComments(t *testing.T) {
+
+	files := map[string]string{
+		"go.mod": "module example.com/api\n\ngo 1.22\n",
+		"dto/user.go": `package dto
+
+// User is an account of customer.
+type User struct {
+	// Name of user.
+	Name string ` + "`json:\"name\"`" + `
+	// @tg desc=` + "`Age in years`" + `
+	Age int ` + "`json:\"age\"`" + `
+}
+`,
+		"service/user.go": `// @tg version=1.0.0
+package service
+
+import (
+	"context"
+
+	"example.com/api/dto"
+)
+
+// Users manages accounts.
+// @tg jsonRPC-server
+type Users interface {
+	// Get returns user by id.
+	Get(ctx context.Context, id int) (user dto.User, err error)
+	// Save is described by annotation.
+	// @tg desc=` + "`Stores user`" + `
+	Save(ctx context.Context, user dto.User) (err error)
+	// Find returns users by mask 'team/*/name'.
+	Find(ctx context.Context, mask string) (users []dto.User, err error)
+}
+`,
+	}
+	memFS, _ := generateProject(t, files, Config{
+		Services: []config.Service{{
+			Dir:     "service",
+			Swagger: &config.Swagger{Out: "api/swagger.yaml"},
+			Client:  &config.Client{Out: "client", Go: true, JS: true, TS: true},
+		}},
+		Strict: true,
+	})
+	assertContains(t, memFS, map[string][]string{
+		"client/users-jsonrpc.go":  {"// Get returns user by id.\nfunc (cli *ClientUsers) Get(", "// Stores user\nfunc (cli *ClientUsers) Save(", "// Users manages accounts.\ntype ClientUsers struct"},
+		"client/users.ts":          {"/** Get returns user by id. */\nGet(", "/** Stores user */\nSave(", "/** Find returns users by mask 'team/*\\/name'. */\nFind(", "/** User is an account of customer. */\nexport interface User", "/** Name of user. */\nname:", "/** Age in years */\nage:"},
+		"client/jsonrpc-client.js": {"* Get returns user by id.\n", "* Stores user\n", "* Find returns users by mask 'team/*\\/name'.\n", "* User is an account of customer.\n* @typedef {Object} User", "* @property {string} name - Name of user.", "* @property {number} age - Age in years"},
+		"api/swagger.yaml":         {"description: Get returns user by id.", "description: Stores user", "description: User is an account of customer.", "description: Name of user.", "description: Age in years"},
+	})
+	for _, name := range memFS.Files() {
+		if data, _ := fs.ReadFile(memFS, name); strings.Contains(string(data), "described by annotation") {
+			t.Fatalf("%s contains doc comment instead of annotation:\n%s", name, data)
+		}
+	}
+}
+
+func TestDescription(t *testing.T) {
+
+	for _, test := range []struct {
+		docs     []string
+		expected string
+	}{
+		{docs: []string{"// Get returns user by id."}, expected: "Get returns user by id."},
+		{docs: []string{"// Get returns user", "// by id.", "// @tg http-method=GET"}, expected: "Get returns user\nby id."},
+		{docs: []string{"// Save is described by annotation.", "// @tg desc=`Stores user`"}, expected: "Stores user"},
+		{docs: []string{"/* Block comment */"}, expected: "Block comment"},
+		{expected: ""},
+	} {
+		if text := description(test.docs); text != test.expected {
+			t.Fatalf("description of %q is %q instead of %q", test.docs, text, test.expected)
+		}
+	}
+}
+
+func TestJSDoc(t *testing.T) {
+
+	for _, test := range []struct {
+		text  string
+		tsDoc string
+		jsDoc string
+	}{
+		{text: "", tsDoc: "", jsDoc: ""},
+		{text: "Get returns user.", tsDoc: "/** Get returns user. */\n", jsDoc: "* Get returns user.\n"},
+		{text: "Find users by mask 'team/*/name'.", tsDoc: "/** Find users by mask 'team/*\\/name'. */\n", jsDoc: "* Find users by mask 'team/*\\/name'.\n"},
+		{text: "Get returns user.\n\nUser is */ closed.", tsDoc: "/**\n* Get returns user.\n*\n* User is *\\/ closed.\n*/\n", jsDoc: "* Get returns user.\n*\n* User is *\\/ closed.\n"},
+	} {
+		if doc := tsDoc(test.text); doc != test.tsDoc {
+			t.Fatalf("TSDoc of %q is %q instead of %q", test.text, doc, test.tsDoc)
+		}
+		if lines := jsDocLines(test.text); lines != test.jsDoc {
+			t.Fatalf("JSDoc of %q is %q instead of %q", test.text, lines, test.jsDoc)
+		}
+	}
+}
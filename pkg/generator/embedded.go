@@ -0,0 +1,113 @@
+package generator
+
+import (
+	"slices"
+
+	"github.com/seniorGolang/tg/v2/pkg/astra/types"
+	"github.com/seniorGolang/tg/v2/pkg/diagnostic"
+	"github.com/seniorGolang/tg/v2/pkg/tags"
+)
+
+const (
+	ruleMethodConflict   = "method-conflict"
+	ruleEmbeddedNotFound = "embedded-not-found"
+)
+
+// inheritedMethod is a method of embedded interface. Tags are annotations of embedded interfaces,
+// outer interface overrides annotations of inner one. Origin is a package qualified name of interface,
+// which declares method, so interface embedded by several paths gives the same method.
+type inheritedMethod struct {
+	fn     *types.Function
+	tags   tags.DocTags
+	from   string
+	origin string
+}
+
+// embeddedMethods returns methods of interfaces embedded into iface declared in package pkg.
+// Interfaces of the same package are taken from local, interfaces of other packages are looked up by import path,
+// types of their methods are qualified by their package.
+func (svc *service) embeddedMethods(pkg string, iface types.Interface, local []types.Interface, visited []string) (methods []inheritedMethod) {
+
+	for _, embedded := range iface.Interfaces {
+		innerPkg, inner, found := pkg, types.Interface{}, false
+		switch embeddedType := embedded.Type.(type) {
+		case types.TName:
+			if pkg == svc.pkgPath {
+				for _, localIface := range local {
+					if localIface.Name == embeddedType.TypeName {
+						inner, found = localIface, true
+					}
+				}
+				break
+			}
+			inner, found = sourceInterface(svc.tr.svcDir, pkg, embeddedType.TypeName)
+		case types.TImport:
+			if embeddedType.Import != nil {
+				innerPkg = embeddedType.Import.Package
+				inner, found = sourceInterface(svc.tr.svcDir, innerPkg, embeddedType.Next.String())
+			}
+		}
+		if !found {
+			svc.diags = append(svc.diags, diagnostic.Errorf(embedded.Pos, ruleEmbeddedNotFound, "%s: embedded interface %s is not found", iface.Name, embedded.Type))
+			continue
+		}
+		key := innerPkg + "." + inner.Name
+		if slices.Contains(visited, key) {
+			continue
+		}
+		innerTags := tags.ParseTags(inner.Docs)
+		for _, fn := range inner.Methods {
+			method := *fn
+			if innerPkg != svc.pkgPath {
+				method.Args = qualifyVars(method.Args, innerPkg)
+				method.Results = qualifyVars(method.Results, innerPkg)
+			}
+			methods = append(methods, inheritedMethod{fn: &method, tags: innerTags, from: inner.Name, origin: key})
+		}
+		for _, nested := range svc.embeddedMethods(innerPkg, inner, local, append(visited, key)) {
+			nested.tags = make(tags.DocTags).Merge(nested.tags).Merge(innerTags)
+			methods = append(methods, nested)
+		}
+	}
+	return
+}
+
+// addMethods adds declared and inherited methods of interface, method inherited under name of existing method is a conflict,
+// unless it is the same method of interface embedded by several paths.
+func (svc *service) addMethods(local []types.Interface) {
+
+	key := svc.pkgPath + "." + svc.Name
+	declared := make(map[string]inheritedMethod)
+	for _, fn := range svc.Interface.Methods {
+		declared[fn.Name] = inheritedMethod{from: svc.Name, origin: key}
+		svc.methods = append(svc.methods, newMethod(svc.log, svc, fn))
+	}
+	for _, inherited := range svc.embeddedMethods(svc.pkgPath, svc.Interface, local, []string{key}) {
+		if owner, found := declared[inherited.fn.Name]; found {
+			if owner.origin != inherited.origin {
+				svc.diags = append(svc.diags, diagnostic.Errorf(inherited.fn.Pos, ruleMethodConflict, "%s: method %s of %s conflicts with method of %s", svc.Name, inherited.fn.Name, inherited.from, owner.from))
+			}
+			continue
+		}
+		declared[inherited.fn.Name] = inherited
+		svc.methods = append(svc.methods, newInheritedMethod(svc.log, svc, inherited.fn, inherited.tags))
+	}
+}
+
+func sourceInterface(root, pkg, name string) (iface types.Interface, found bool) {
+
+	retType, _ := searchSourceType(root, pkg, name)
+	if ifaceType, ok := retType.(types.TInterface); ok && ifaceType.Interface != nil {
+		return *ifaceType.Interface, true
+	}
+	return
+}
+
+func qualifyVars(vars []types.Variable, pkg string) (qualified []types.Variable) {
+
+	for _, v := range vars {
+		v.Type = qualifyType(v.Type, pkg)
+		qualified = append(qualified, v)
+	}
+	return
+}
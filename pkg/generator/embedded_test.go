@@ -0,0 +1,187 @@
+package generator
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/seniorGolang/tg/v2/pkg/astra/types"
+	"github.com/seniorGolang/tg/v2/pkg/config"
+	"github.com/seniorGolang/tg/v2/pkg/tags"
+)
+
+func TestGenerateEmbeddedInterfaces(t *testing.T) {
+
+	files := map[string]string{
+		"go.mod": "module example.com/api\n\ngo 1.22\n",
+		"billing/billing.go": `package billing
+
+import "context"
+
+type Invoice struct {
+	Amount int ` + "`json:\"amount\"`" + `
+}
+
+type Billing interface {
+	// @tg http-method=GET
+	Invoice(ctx context.Context, id int) (invoice Invoice, err error)
+}
+`,
+		"service/admin.go": `// @tg version=1.0.0
+package service
+
+import (
+	"context"
+
+	"example.com/api/billing"
+)
+
+// @tg http-server
+type Admin interface {
+	Users
+	billing.Billing
+}
+
+// @tg http-method=GET
+type Users interface {
+	// @tg http-method=POST
+	User(ctx context.Context, id int) (name string, err error)
+}
+
+// @tg http-server
+type Dup interface {
+	Users
+	User(ctx context.Context, id int) (name string, err error)
+}
+`,
+	}
+	memFS, result := generateProject(t, files, Config{
+		Services: []config.Service{
+			{
+				Dir:       "service",
+				Include:   []string{"Admin"},
+				Transport: &config.Transport{Out: "transport"},
+				Swagger:   &config.Swagger{Out: "api/swagger.yaml"},
+			},
+			{
+				Dir:     "service",
+				Include: []string{"Dup"},
+				Swagger: &config.Swagger{Out: "api/dup.yaml"},
+			},
+		},
+		Strict: true,
+	})
+	if rules := checkRules(result.Diagnostics); len(result.Diagnostics) != 1 || rules[ruleMethodConflict] != 1 {
+		t.Fatalf("expected method conflict: %v", result.Diagnostics)
+	}
+	assertContains(t, memFS, map[string][]string{
+		"transport/admin-http.go":       {`route.Get("/admin/user"`, `route.Get("/admin/invoice"`},
+		"transport/admin-middleware.go": {"type AdminInvoice func(ctx context.Context, id int) (invoice billing.Invoice, err error)"},
+		"api/swagger.yaml":              {"/admin/user:", "/admin/invoice:", "billing.Invoice:"},
+	})
+}
+
+func TestGenerateEmbeddedDiamond(t *testing.T) {
+
+	files := map[string]string{
+		"go.mod": "module example.com/api\n\ngo 1.22\n",
+		"service/admin.go": `// @tg version=1.0.0
+package service
+
+import "context"
+
+type Base interface {
+	Ping(ctx context.Context) (err error)
+}
+
+type Users interface {
+	Base
+}
+
+type Groups interface {
+	Base
+}
+
+// @tg http-server
+type Admin interface {
+	Users
+	Groups
+	Missing
+}
+`,
+	}
+	_, result := generateProject(t, files, Config{
+		Services: []config.Service{{
+			Dir:     "service",
+			Include: []string{"Admin"},
+			Swagger: &config.Swagger{Out: "api/swagger.yaml"},
+		}},
+	})
+	if rules := checkRules(result.Diagnostics); len(result.Diagnostics) != 1 || rules[ruleEmbeddedNotFound] != 1 {
+		t.Fatalf("expected only embedded interface not found: %v", result.Diagnostics)
+	}
+	if pos := result.Diagnostics[0].Pos; pos.Line != 22 {
+		t.Fatalf("not found is reported at %v instead of embedded field", pos)
+	}
+}
+
+func contractMethod(name string, docs ...string) *types.Function {
+
+	return &types.Function{
+		Base:    types.Base{Name: name, Docs: docs},
+		Args:    []types.Variable{{Base: types.Base{Name: "ctx"}, Type: types.TImport{Import: &types.Import{Base: types.Base{Name: "context"}, Package: "context"}, Next: types.TName{TypeName: "Context"}}}},
+		Results: []types.Variable{{Base: types.Base{Name: "err"}, Type: types.TName{TypeName: "error"}}},
+	}
+}
+
+func embeds(names ...string) (embedded []types.Variable) {
+
+	for _, name := range names {
+		embedded = append(embedded, types.Variable{Type: types.TName{TypeName: name}})
+	}
+	return
+}
+
+func TestAddMethods(t *testing.T) {
+
+	local := []types.Interface{
+		{Base: types.Base{Name: "Base"}, Methods: []*types.Function{contractMethod("Ping")}},
+		{Base: types.Base{Name: "Users", Docs: []string{"// @tg http-method=GET"}}, Interfaces: embeds("Base"), Methods: []*types.Function{contractMethod("User", "// @tg http-method=POST http-path=/user/:id"), contractMethod("Users")}},
+		{Base: types.Base{Name: "Groups"}, Interfaces: embeds("Base")},
+	}
+	tr := newCheckTransport()
+	newService := func(iface types.Interface) *service {
+		svc := &service{tr: tr, log: tr.log, Interface: iface, pkgPath: "example.com/api/service", tags: tags.ParseTags(iface.Docs)}
+		svc.addMethods(append(local, iface))
+		return svc
+	}
+
+	admin := newService(types.Interface{Base: types.Base{Name: "Admin"}, Interfaces: embeds("Users", "Groups"), Methods: []*types.Function{contractMethod("Own")}})
+	if len(admin.diags) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", admin.diags)
+	}
+	var names []string
+	methods := make(map[string]*method)
+	for _, m := range admin.methods {
+		names = append(names, m.Name)
+		methods[m.Name] = m
+	}
+	// declared methods go first, method of interface embedded by several paths is inherited once
+	if expected := []string{"Own", "User", "Users", "Ping"}; !slices.Equal(names, expected) {
+		t.Fatalf("methods are %v instead of %v", names, expected)
+	}
+	// annotations of embedded interface override annotations of its methods, as annotations of service do
+	if user := methods["User"]; user.tags.Value(tagMethodHTTP) != "GET" || user.tags.Value(tagHttpPath) != "/user/:id" {
+		t.Fatalf("unexpected annotations of method: %v", user.tags)
+	}
+	if value := methods["Ping"].tags.Value(tagMethodHTTP); value != "GET" {
+		t.Fatalf("annotation of embedded interface is not inherited by nested one: %s", value)
+	}
+
+	dup := newService(types.Interface{Base: types.Base{Name: "Dup"}, Interfaces: embeds("Users"), Methods: []*types.Function{contractMethod("User")}})
+	if rules := checkRules(dup.diags); len(dup.diags) != 1 || rules[ruleMethodConflict] != 1 {
+		t.Fatalf("expected method conflict: %v", dup.diags)
+	}
+	if len(dup.methods) != 3 {
+		t.Fatalf("conflicting method is added: %d methods", len(dup.methods))
+	}
+}
@@ -0,0 +1,160 @@
+package generator
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/seniorGolang/tg/v2/pkg/astra/types"
+	"github.com/seniorGolang/tg/v2/pkg/config"
+	"github.com/seniorGolang/tg/v2/pkg/diagnostic"
+)
+
+const ruleRender = "render"
+
+// Config describes run of Generate. Relative paths are resolved from Dir.
+type Config struct {
+	// Dir is a directory, which relative paths of services are resolved from. Empty Dir is a current directory.
+	// Types of contract are looked up in module of services package, so result does not depend on current directory.
+	Dir string
+	// Services and their targets, the same as 'services' of 'tg.yaml'.
+	Services []config.Service
+	// Strict fails target on any failed render step, output directory of target is restored.
+	Strict bool
+	// Version of generator, which is written into generated files.
+	Version string
+	// Loader of contract types: LoaderSource (default) or LoaderPackages.
+	Loader string
+	// Log receives progress of generation. Nothing is logged, when it is nil.
+	Log logrus.FieldLogger
+	// Output receives generated files instead of disk, for example MemFS.
+	Output Output
+	// Incremental skips targets and files, which are not changed since previous generation (see Transport.SetIncremental).
+	// It works with output on disk.
+	Incremental bool
+}
+
+// Result of Generate. Diagnostics contains problems of contract and failed render steps.
+type Result struct {
+	Diagnostics diagnostic.List
+}
+
+// Generate renders all targets of services. Error is returned, when services cannot be parsed,
+// config is invalid or target fails in strict mode, other problems are returned as diagnostics.
+func Generate(cfg Config) (result Result, err error) {
+
+	log := cfg.Log
+	if log == nil {
+		discard := logrus.New()
+		discard.SetOutput(io.Discard)
+		log = discard
+	}
+	for _, svc := range cfg.Services {
+		if err = svc.Validate(); err != nil {
+			return result, fmt.Errorf("%s: %w", svc.Dir, err)
+		}
+		if cfg.Dir != "" {
+			svc = svc.Resolve(cfg.Dir)
+		}
+		var tr Transport
+		if tr, err = NewTransportWithDefaults(log, cfg.Version, svc.Dir, svc.Tags(), svc.Ifaces()...); err != nil {
+			return result, fmt.Errorf("%s: %w", svc.Dir, err)
+		}
+		if err = tr.SetLoader(cfg.Loader); err != nil {
+			return
+		}
+		tr.SetStrict(cfg.Strict)
+		tr.SetIncremental(cfg.Incremental)
+		if cfg.Output != nil {
+			tr.SetOutput(cfg.Output)
+		}
+		if svc.Transport != nil {
+			if err = tr.SetBackend(svc.Transport.Backend); err != nil {
+				return result, fmt.Errorf("%s: %w", svc.Dir, err)
+			}
+		}
+		if err = tr.SetOverrides(svc.Overrides); err != nil {
+			return
+		}
+		result.Diagnostics = append(result.Diagnostics, tr.Check()...)
+		err = tr.RenderTargets(svc)
+		result.Diagnostics = append(result.Diagnostics, tr.state.diags...)
+		if err != nil {
+			return
+		}
+	}
+	result.Diagnostics.Sort()
+	return
+}
+
+// RenderTargets renders targets of service: transport, swagger, clients, azure and plugins.
+func (tr *Transport) RenderTargets(svc config.Service) (err error) {
+
+	if svc.Transport != nil {
+		if err = tr.RenderServer(svc.Transport.Out); err != nil {
+			return
+		}
+	}
+	if svc.Swagger != nil {
+		if err = tr.RenderSwagger(svc.Swagger.Out, svc.Ifaces()...); err != nil {
+			return
+		}
+		if svc.Swagger.Redoc != "" {
+			tr.RenderRedoc(svc.Swagger.Out, svc.Swagger.Redoc)
+		}
+	}
+	if svc.Client != nil {
+		if err = tr.renderClients(*svc.Client); err != nil {
+			return
+		}
+	}
+	if svc.Azure != nil {
+		if err = tr.RenderAzure(svc.Azure.AppName, svc.Azure.RoutePrefix, svc.Azure.Out, svc.Azure.LogLevel, svc.Azure.EnableHealth); err != nil {
+			return
+		}
+	}
+	for _, plugin := range svc.Plugins {
+		if err = tr.RenderPlugin(plugin.Name, plugin.Out, plugin.Options); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (tr *Transport) renderClients(client config.Client) (err error) {
+
+	if client.Go {
+		if err = tr.RenderClient(client.Out); err != nil {
+			return
+		}
+	}
+	if client.NPM != "" {
+		if err = tr.RenderPackageNPM(client.Out, client.NPM); err != nil {
+			return
+		}
+	}
+	if client.JS {
+		if err = tr.RenderClientJS(client.Out); err != nil {
+			return
+		}
+	}
+	if client.TS {
+		err = tr.RenderClientTS(client.Out)
+	}
+	return
+}
+
+// RenderRedoc bundles swagger file into HTML by 'redoc-cli'. It works only with output on disk.
+func (tr *Transport) RenderRedoc(swaggerFile, redocFile string) {
+
+	if !tr.onDisk() {
+		return
+	}
+	tr.log.Infof("write to %s", redocFile)
+	if output, err := exec.Command("redoc-cli", "bundle", swaggerFile, "-o", redocFile).Output(); err != nil { // nolint:gosec
+		tr.log.WithError(err).Error(string(output))
+		tr.state.diags = append(tr.state.diags, diagnostic.Errorf(types.Position{Filename: redocFile}, ruleRender, "redoc-cli: %v", err))
+	}
+}
@@ -0,0 +1,156 @@
+package generator
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/seniorGolang/tg/v2/pkg/config"
+)
+
+// writeProject writes files of project to temporary directory and makes it working one.
+func writeProject(t *testing.T, files map[string]string) (dir string) {
+
+	dir = t.TempDir()
+	for name, content := range files {
+		writeFile(t, filepath.Join(dir, name), content)
+	}
+	t.Chdir(dir)
+	return
+}
+
+// generateProject writes files of project and generates services of cfg into memory.
+func generateProject(t *testing.T, files map[string]string, cfg Config) (memFS *MemFS, result Result) {
+
+	memFS = NewMemFS(writeProject(t, files))
+	cfg.Output = memFS
+	var err error
+	if result, err = Generate(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return
+}
+
+// assertContains checks, that files contain all texts, which are expected for them.
+func assertContains(t *testing.T, fsys fs.FS, expected map[string][]string) {
+
+	for name, contains := range expected {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			t.Fatalf("read %s: %v", name, err)
+		}
+		for _, text := range contains {
+			if !strings.Contains(string(data), text) {
+				t.Fatalf("%s does not contain '%s':\n%s", name, text, data)
+			}
+		}
+	}
+}
+
+func writeFile(t *testing.T, filePath, content string) {
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0777); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filePath, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGenerateMemFS(t *testing.T) {
+
+	files := map[string]string{
+		"go.mod": "module example.com/api\n\ngo 1.22\n",
+		"service/user.go": `// @tg version=1.0.0
+package service
+
+import "context"
+
+// @tg jsonRPC-server log
+type User interface {
+	GetName(ctx context.Context, id int) (name string, err error)
+}
+`,
+	}
+	dir := writeProject(t, files)
+	memFS := NewMemFS(dir)
+	result, err := Generate(Config{
+		Services: []config.Service{{
+			Dir:       "service",
+			Transport: &config.Transport{Out: "transport"},
+			Swagger:   &config.Swagger{Out: "api/swagger.yaml"},
+		}},
+		Strict: true,
+		Output: memFS,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Diagnostics) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", result.Diagnostics)
+	}
+	for _, name := range []string{"transport/server.go", "transport/user-logger.go", "api/swagger.yaml"} {
+		if !slices.Contains(memFS.Files(), name) {
+			t.Fatalf("%s is not generated: %v", name, memFS.Files())
+		}
+	}
+	if data, err := fs.ReadFile(memFS, "transport/user-logger.go"); err != nil || len(data) == 0 {
+		t.Fatalf("read from fs: %v", err)
+	}
+	if _, err = os.Stat(filepath.Join(dir, "transport")); !os.IsNotExist(err) {
+		t.Fatalf("files are written to disk: %v", err)
+	}
+}
+
+func TestGenerateDir(t *testing.T) {
+
+	files := map[string]string{
+		"go.mod": "module example.com/api\n\ngo 1.22\n",
+		"service/user.go": `// @tg version=1.0.0
+package service
+
+import (
+	"context"
+
+	"example.com/api/dto"
+)
+
+// @tg jsonRPC-server
+type User interface {
+	GetUser(ctx context.Context, id int) (user dto.User, err error)
+}
+`,
+		"dto/user.go": "package dto\n\ntype User struct {\n\tUserName string `json:\"userName\"`\n}\n",
+	}
+	dir := writeProject(t, files)
+	t.Chdir(t.TempDir())
+	for _, loader := range []string{LoaderSource, LoaderPackages} {
+		memFS := NewMemFS(dir)
+		result, err := Generate(Config{
+			Dir: dir,
+			Services: []config.Service{{
+				Dir:       "service",
+				Transport: &config.Transport{Out: "transport"},
+				Swagger:   &config.Swagger{Out: "api/swagger.yaml"},
+			}},
+			Loader: loader,
+			Strict: true,
+			Output: memFS,
+		})
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", loader, err)
+		}
+		if len(result.Diagnostics) != 0 {
+			t.Fatalf("%s: unexpected diagnostics: %v", loader, result.Diagnostics)
+		}
+		if !slices.Contains(memFS.Files(), "transport/server.go") {
+			t.Fatalf("%s: transport is not generated: %v", loader, memFS.Files())
+		}
+		if data, _ := fs.ReadFile(memFS, "api/swagger.yaml"); !strings.Contains(string(data), "userName") {
+			t.Fatalf("%s: type from other package is not resolved:\n%s", loader, data)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package generator
+
+import (
+	"path"
+	"strings"
+
+	"github.com/seniorGolang/tg/v2/pkg/astra/types"
+)
+
+// instantiate returns generic struct of instantiation like `Page[User]` with type parameters replaced by arguments.
+// Fields of struct are resolved in package of definition defPkg, so arguments declared in package pkg are qualified by import.
+func (tr *Transport) instantiate(instance types.TInstance, pkg string) (inst types.Struct, defPkg string, found bool) {
+
+	var name string
+	switch next := instance.Next.(type) {
+	case types.TImport:
+		if next.Import == nil {
+			return
+		}
+		defPkg, name = next.Import.Package, next.Next.String()
+	case types.TName:
+		defPkg, name = pkg, next.TypeName
+	default:
+		return
+	}
+	if inst, found = tr.searchType(defPkg, name).(types.Struct); !found || len(inst.TypeParams) != len(instance.Args) {
+		return inst, defPkg, false
+	}
+	params := make(map[string]types.Type, len(inst.TypeParams))
+	for i, param := range inst.TypeParams {
+		params[param.Name] = qualifyType(instance.Args[i], pkg)
+	}
+	fields := make([]types.StructField, 0, len(inst.Fields))
+	for _, field := range inst.Fields {
+		field.Type = mapTypeNames(field.Type, func(name types.TName) types.Type {
+			if arg, isParam := params[name.TypeName]; isParam {
+				return arg
+			}
+			return name
+		})
+		fields = append(fields, field)
+	}
+	inst.Name = instanceName(instance)
+	inst.TypeParams = nil
+	inst.Fields = fields
+	return inst, defPkg, true
+}
+
+// qualifyType replaces names of types from package pkg by imports of this package.
+func qualifyType(varType types.Type, pkg string) types.Type {
+
+	if pkg == "" {
+		return varType
+	}
+	return mapTypeNames(varType, func(name types.TName) types.Type {
+		if types.IsBuiltin(name) {
+			return name
+		}
+		return types.TImport{Import: &types.Import{Base: types.Base{Name: path.Base(pkg)}, Package: pkg}, Next: name}
+	})
+}
+
+// mapTypeNames replaces type names, which are not qualified by import, with result of fn.
+func mapTypeNames(varType types.Type, fn func(name types.TName) types.Type) types.Type {
+
+	switch vType := varType.(type) {
+	case types.TName:
+		return fn(vType)
+	case types.TPointer:
+		vType.Next = mapTypeNames(vType.Next, fn)
+		return vType
+	case types.TArray:
+		vType.Next = mapTypeNames(vType.Next, fn)
+		return vType
+	case types.TEllipsis:
+		vType.Next = mapTypeNames(vType.Next, fn)
+		return vType
+	case types.TChan:
+		vType.Next = mapTypeNames(vType.Next, fn)
+		return vType
+	case types.TMap:
+		vType.Key = mapTypeNames(vType.Key, fn)
+		vType.Value = mapTypeNames(vType.Value, fn)
+		return vType
+	case types.TInstance:
+		args := make([]types.Type, 0, len(vType.Args))
+		for _, arg := range vType.Args {
+			args = append(args, mapTypeNames(arg, fn))
+		}
+		vType.Next = mapTypeNames(vType.Next, fn)
+		vType.Args = args
+		return vType
+	case types.Struct:
+		fields := make([]types.StructField, 0, len(vType.Fields))
+		for _, field := range vType.Fields {
+			field.Type = mapTypeNames(field.Type, fn)
+			fields = append(fields, field)
+		}
+		vType.Fields = fields
+		return vType
+	default:
+		return varType
+	}
+}
+
+// instanceName returns name of instantiation, which is valid identifier: `Page[User]` is 'Page_User'.
+func instanceName(instance types.TInstance) string {
+
+	names := []string{typeArgName(instance.Next)}
+	for _, arg := range instance.Args {
+		names = append(names, typeArgName(arg))
+	}
+	return strings.Join(names, "_")
+}
+
+func typeArgName(varType types.Type) string {
+
+	switch vType := varType.(type) {
+	case types.TName:
+		return vType.TypeName
+	case types.TImport:
+		return typeArgName(vType.Next)
+	case types.TPointer:
+		return typeArgName(vType.Next)
+	case types.TArray:
+		return typeArgName(vType.Next) + "List"
+	case types.TMap:
+		return "Map" + typeArgName(vType.Key) + typeArgName(vType.Value)
+	case types.TInstance:
+		return instanceName(vType)
+	default:
+		return "Any"
+	}
+}
@@ -0,0 +1,97 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/seniorGolang/tg/v2/pkg/astra/types"
+	"github.com/seniorGolang/tg/v2/pkg/config"
+)
+
+func TestGenerateGenericDTO(t *testing.T) {
+
+	files := map[string]string{
+		"go.mod": "module example.com/api\n\ngo 1.22\n",
+		"dto/dto.go": `package dto
+
+type User struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+
+type Page[T any] struct {
+	Items []T    ` + "`json:\"items\"`" + `
+	Next  string ` + "`json:\"next,omitempty\"`" + `
+}
+`,
+		"service/user.go": `// @tg version=1.0.0
+package service
+
+import (
+	"context"
+
+	"example.com/api/dto"
+)
+
+// @tg jsonRPC-server
+type User interface {
+	List(ctx context.Context, cursor string) (page dto.Page[dto.User], err error)
+}
+`,
+	}
+	memFS, _ := generateProject(t, files, Config{
+		Services: []config.Service{{
+			Dir:       "service",
+			Transport: &config.Transport{Out: "transport"},
+			Swagger:   &config.Swagger{Out: "api/swagger.yaml"},
+			Client:    &config.Client{Out: "client", TS: true},
+		}},
+		Strict: true,
+	})
+	assertContains(t, memFS, map[string][]string{
+		"transport/user-exchange.go": {"Page dto.Page[dto.User]"},
+		"api/swagger.yaml":           {"dto.Page_User:", "$ref: '#/components/schemas/dto.Page_User'", "$ref: '#/components/schemas/dto.User'"},
+		"client/user.ts":             {"page: Page<User>", "export interface Page<T> {", "items?: T[]"},
+	})
+}
+
+func TestInstanceName(t *testing.T) {
+
+	user := types.TImport{Import: &types.Import{Base: types.Base{Name: "dto"}, Package: "example.com/api/dto"}, Next: types.TName{TypeName: "User"}}
+	page := types.TName{TypeName: "Page"}
+	for _, test := range []struct {
+		instance types.TInstance
+		expected string
+	}{
+		{instance: types.TInstance{Next: page, Args: []types.Type{user}}, expected: "Page_User"},
+		{instance: types.TInstance{Next: page, Args: []types.Type{types.TPointer{Next: user, NumberOfPointers: 1}}}, expected: "Page_User"},
+		{instance: types.TInstance{Next: page, Args: []types.Type{types.TArray{Next: user, IsSlice: true}}}, expected: "Page_UserList"},
+		{instance: types.TInstance{Next: page, Args: []types.Type{types.TMap{Key: types.TName{TypeName: "string"}, Value: user}}}, expected: "Page_MapstringUser"},
+		{instance: types.TInstance{Next: page, Args: []types.Type{types.TInstance{Next: page, Args: []types.Type{user}}}}, expected: "Page_Page_User"},
+		{instance: types.TInstance{Next: types.TName{TypeName: "Pair"}, Args: []types.Type{user, types.TInterface{}}}, expected: "Pair_User_Any"},
+	} {
+		if name := instanceName(test.instance); name != test.expected {
+			t.Fatalf("name of %s is %s instead of %s", test.instance, name, test.expected)
+		}
+	}
+}
+
+func TestQualifyType(t *testing.T) {
+
+	for _, test := range []struct {
+		varType  types.Type
+		expected string
+	}{
+		{varType: types.TName{TypeName: "User"}, expected: "dto.User"},
+		{varType: types.TName{TypeName: "string"}, expected: "string"},
+		{varType: types.TPointer{Next: types.TName{TypeName: "User"}, NumberOfPointers: 1}, expected: "*dto.User"},
+		{varType: types.TMap{Key: types.TName{TypeName: "string"}, Value: types.TArray{Next: types.TName{TypeName: "User"}, IsSlice: true}}, expected: "map[string][]dto.User"},
+		{varType: types.TInstance{Next: types.TName{TypeName: "Page"}, Args: []types.Type{types.TName{TypeName: "User"}}}, expected: "dto.Page[dto.User]"},
+		{varType: types.TImport{Import: &types.Import{Base: types.Base{Name: "time"}, Package: "time"}, Next: types.TName{TypeName: "Time"}}, expected: "time.Time"},
+	} {
+		if qualified := qualifyType(test.varType, "example.com/api/dto").String(); qualified != test.expected {
+			t.Fatalf("%s is qualified as %s instead of %s", test.varType, qualified, test.expected)
+		}
+	}
+	if varType := (types.TName{TypeName: "User"}); qualifyType(varType, "") != varType {
+		t.Fatal("type is qualified by empty package")
+	}
+}
@@ -0,0 +1,803 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"github.com/seniorGolang/tg/v2/pkg/astra/types"
+	"github.com/seniorGolang/tg/v2/pkg/utils"
+)
+
+// kinds of protobuf types, which Go types of contract are mapped to.
+const (
+	protoScalar    = "scalar"
+	protoBytes     = "bytes"
+	protoUUID      = "uuid"
+	protoTimestamp = "timestamp"
+	protoDuration  = "duration"
+	protoStruct    = "message"
+	protoPointer   = "pointer"
+	protoOptional  = "optional"
+	protoRepeated  = "repeated"
+	protoMap       = "map"
+)
+
+// protoScalars maps builtin Go types to protobuf types and Go types of generated protobuf code.
+var protoScalars = map[string][2]string{
+	"bool":    {"bool", "bool"},
+	"string":  {"string", "string"},
+	"int":     {"int64", "int64"},
+	"int8":    {"int32", "int32"},
+	"int16":   {"int32", "int32"},
+	"int32":   {"int32", "int32"},
+	"rune":    {"int32", "int32"},
+	"int64":   {"int64", "int64"},
+	"uint":    {"uint64", "uint64"},
+	"uint8":   {"uint32", "uint32"},
+	"byte":    {"uint32", "uint32"},
+	"uint16":  {"uint32", "uint32"},
+	"uint32":  {"uint32", "uint32"},
+	"uint64":  {"uint64", "uint64"},
+	"float32": {"float", "float32"},
+	"float64": {"double", "float64"},
+}
+
+var reProtoIdent = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// protoRef is a protobuf type of Go type of contract. Type of contract is kept at each level of nesting:
+// it is a Go type, which values are converted from and to.
+type protoRef struct {
+	kind   string
+	name   string
+	goType string
+	ct     *ContractType
+	key    *protoRef
+	elem   *protoRef
+}
+
+type protoField struct {
+	name   string
+	doc    string
+	goPath []string
+	ref    *protoRef
+}
+
+type protoMessage struct {
+	name   string
+	doc    string
+	ct     *ContractType
+	fields []protoField
+}
+
+type protoMethod struct {
+	method   *method
+	request  *protoMessage
+	response *protoMessage
+}
+
+// grpcSchema is a protobuf contract of services with 'grpc-server' annotation.
+type grpcSchema struct {
+	tr       *Transport
+	pkgName  string
+	goPkg    string
+	builder  contractBuilder
+	methods  map[string][]protoMethod
+	messages map[string]*protoMessage
+	owners   map[string]string
+	uses     map[string]bool
+}
+
+// newGRPCSchema builds protobuf contract. Package 'pb' of contract is a subpackage of services package:
+// it is shared by transport and clients, protobuf types cannot be registered twice in one binary.
+func (tr *Transport) newGRPCSchema() (schema *grpcSchema, err error) {
+
+	schema = &grpcSchema{
+		tr:       tr,
+		builder:  contractBuilder{tr: tr, contract: &Contract{Types: make(map[string]*ContractTypeDef)}},
+		methods:  make(map[string][]protoMethod),
+		messages: make(map[string]*protoMessage),
+		owners:   make(map[string]string),
+		uses:     make(map[string]bool),
+	}
+	var failed bool
+	for _, serviceName := range tr.serviceKeys() {
+		svc := tr.services[serviceName]
+		if !svc.tags.Contains(tagServerGRPC) {
+			continue
+		}
+		if schema.pkgName == "" {
+			schema.pkgName = protoPackageName(path.Base(svc.pkgPath))
+			schema.goPkg = path.Join(svc.pkgPath, "pb")
+		}
+		for _, method := range svc.methods {
+			var pm protoMethod
+			if pm, err = schema.method(method); err != nil {
+				tr.showErrorAt(method.Pos, err, "renderGRPC")
+				failed = true
+				continue
+			}
+			schema.methods[svc.Name] = append(schema.methods[svc.Name], pm)
+		}
+	}
+	if failed {
+		return nil, fmt.Errorf("contract cannot be expressed in protobuf")
+	}
+	return schema, nil
+}
+
+func (schema *grpcSchema) method(m *method) (pm protoMethod, err error) {
+
+	pm.method = m
+	name := m.svc.Name + m.Name
+	if pm.request, err = schema.vars(name+"Request", m.svc.pkgPath, m.argsWithoutContext()); err != nil {
+		return pm, fmt.Errorf("%s.%s: argument %w", m.svc.Name, m.Name, err)
+	}
+	if pm.response, err = schema.vars(name+"Response", m.svc.pkgPath, m.resultsWithoutError()); err != nil {
+		return pm, fmt.Errorf("%s.%s: result %w", m.svc.Name, m.Name, err)
+	}
+	return
+}
+
+// vars returns message of request or response, which fields are arguments or results of method.
+func (schema *grpcSchema) vars(name, pkg string, vars []types.Variable) (msg *protoMessage, err error) {
+
+	if err = schema.own(name, ""); err != nil {
+		return
+	}
+	msg = &protoMessage{name: name}
+	schema.messages[name] = msg
+	for _, v := range vars {
+		field := protoField{name: protoFieldName(v.Name), goPath: []string{utils.ToLowerCamel(v.Name)}}
+		if field.ref, err = schema.resolve(schema.builder.typeOf(pkg, v.Type)); err != nil {
+			return nil, fmt.Errorf("'%s': %w", v.Name, err)
+		}
+		if err = msg.add(field); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+func (msg *protoMessage) add(field protoField) error {
+
+	if !reProtoIdent.MatchString(field.name) {
+		return fmt.Errorf("name '%s' of field of message %s cannot be expressed in protobuf", field.name, msg.name)
+	}
+	for _, f := range msg.fields {
+		if f.name == field.name {
+			return fmt.Errorf("message %s has fields %s and %s with the same name '%s'", msg.name, strings.Join(f.goPath, "."), strings.Join(field.goPath, "."), field.name)
+		}
+	}
+	msg.fields = append(msg.fields, field)
+	return nil
+}
+
+// own reserves name of message for type by key, types of different packages may have the same name.
+func (schema *grpcSchema) own(name, key string) error {
+
+	if owner, found := schema.owners[name]; found && owner != key {
+		if owner == "" {
+			owner = "request or response of method"
+		}
+		if key == "" {
+			key = "request or response of method"
+		}
+		return fmt.Errorf("message %s is defined by %s and %s", name, owner, key)
+	}
+	schema.owners[name] = key
+	return nil
+}
+
+func (schema *grpcSchema) resolve(ct *ContractType) (ref *protoRef, err error) {
+
+	switch ct.Kind {
+	case kindBuiltin:
+		scalar, found := protoScalars[ct.Name]
+		if !found {
+			return nil, fmt.Errorf("type %s cannot be expressed in protobuf", ct)
+		}
+		return &protoRef{kind: protoScalar, name: scalar[0], goType: scalar[1], ct: ct}, nil
+	case kindNamed:
+		return schema.named(ct)
+	case kindPointer:
+		var elem *protoRef
+		if elem, err = schema.resolve(ct.Elem); err != nil {
+			return
+		}
+		switch elem.kind {
+		case protoStruct, protoTimestamp, protoDuration:
+			return &protoRef{kind: protoPointer, name: elem.name, ct: ct, elem: elem}, nil
+		case protoScalar, protoUUID:
+			return &protoRef{kind: protoOptional, name: elem.name, ct: ct, elem: elem}, nil
+		}
+		return nil, fmt.Errorf("type %s cannot be expressed in protobuf: pointer to %s", ct, elem.kind)
+	case kindSlice:
+		if ct.Elem.Kind == kindBuiltin && (ct.Elem.Name == "byte" || ct.Elem.Name == "uint8") {
+			return &protoRef{kind: protoBytes, name: "bytes", ct: ct}, nil
+		}
+		var elem *protoRef
+		if elem, err = schema.resolve(ct.Elem); err != nil {
+			return
+		}
+		if err = repeatable(ct, elem); err != nil {
+			return
+		}
+		return &protoRef{kind: protoRepeated, name: elem.name, ct: ct, elem: elem}, nil
+	case kindMap:
+		var key, elem *protoRef
+		if key, err = schema.resolve(ct.Key); err != nil {
+			return
+		}
+		if key.kind != protoScalar || key.ct.Kind != kindBuiltin || strings.HasPrefix(key.name, "float") || key.name == "double" {
+			return nil, fmt.Errorf("type %s cannot be expressed in protobuf: key of map must be string, integer or bool", ct)
+		}
+		if elem, err = schema.resolve(ct.Elem); err != nil {
+			return
+		}
+		if err = repeatable(ct, elem); err != nil {
+			return
+		}
+		return &protoRef{kind: protoMap, name: fmt.Sprintf("map<%s, %s>", key.name, elem.name), ct: ct, key: key, elem: elem}, nil
+	case kindArray:
+		return nil, fmt.Errorf("type %s cannot be expressed in protobuf: use slice instead of array", ct)
+	case kindStruct:
+		return nil, fmt.Errorf("type %s cannot be expressed in protobuf: anonymous struct", ct)
+	}
+	return nil, fmt.Errorf("type %s cannot be expressed in protobuf", ct)
+}
+
+// repeatable checks element of repeated field or value of map: protobuf has no nested collections and optional elements.
+func repeatable(ct *ContractType, elem *protoRef) error {
+
+	switch elem.kind {
+	case protoRepeated, protoMap:
+		return fmt.Errorf("type %s cannot be expressed in protobuf: nested collections", ct)
+	case protoOptional:
+		return fmt.Errorf("type %s cannot be expressed in protobuf: collection of pointers to %s", ct, elem.elem.ct)
+	}
+	return nil
+}
+
+func (schema *grpcSchema) named(ct *ContractType) (ref *protoRef, err error) {
+
+	switch {
+	case ct.Package == packageTime && ct.Name == "Time":
+		schema.uses[protoTimestamp] = true
+		return &protoRef{kind: protoTimestamp, name: "google.protobuf.Timestamp", ct: ct}, nil
+	case ct.Package == packageTime && ct.Name == "Duration":
+		schema.uses[protoDuration] = true
+		return &protoRef{kind: protoDuration, name: "google.protobuf.Duration", ct: ct}, nil
+	case ct.Package == schema.tr.tags.Value(tagPackageUUID, packageUUID) && ct.Name == "UUID":
+		schema.uses[protoUUID] = true
+		return &protoRef{kind: protoUUID, name: "string", ct: ct}, nil
+	case strings.Contains(ct.Name, "["):
+		return nil, fmt.Errorf("type %s cannot be expressed in protobuf: instantiation of generic type", ct)
+	}
+	def := schema.builder.contract.Types[ct.Package+"."+ct.Name]
+	if def == nil || def.Opaque {
+		return nil, fmt.Errorf("type %s cannot be expressed in protobuf: definition is not found", ct)
+	}
+	if def.Underlying == nil {
+		return schema.message(ct, def)
+	}
+	var under *protoRef
+	if under, err = schema.resolve(def.Underlying); err != nil {
+		return nil, fmt.Errorf("%s: %w", ct, err)
+	}
+	switch under.kind {
+	case protoScalar, protoBytes, protoRepeated, protoMap:
+		named := *under
+		named.ct = ct
+		return &named, nil
+	}
+	return nil, fmt.Errorf("type %s cannot be expressed in protobuf: named type of %s", ct, def.Underlying)
+}
+
+func (schema *grpcSchema) message(ct *ContractType, def *ContractTypeDef) (ref *protoRef, err error) {
+
+	key := ct.Package + "." + ct.Name
+	ref = &protoRef{kind: protoStruct, name: ct.Name, ct: ct}
+	if _, found := schema.messages[ct.Name]; found && schema.owners[ct.Name] == key {
+		return
+	}
+	if err = schema.own(ct.Name, key); err != nil {
+		return nil, err
+	}
+	msg := &protoMessage{name: ct.Name, doc: def.Doc, ct: ct}
+	schema.messages[ct.Name] = msg
+	if err = schema.fields(msg, def, nil); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// fields adds fields of struct to message, fields of embedded structs are added as fields of message.
+func (schema *grpcSchema) fields(msg *protoMessage, def *ContractTypeDef, goPath []string) (err error) {
+
+	for _, field := range def.Fields {
+		fieldPath := goPath
+		if field.Name != "" {
+			fieldPath = append(append([]string{}, goPath...), field.Name)
+		}
+		if field.Inline {
+			var inline *ContractTypeDef
+			if field.Type.Kind == kindNamed {
+				inline = schema.builder.contract.Types[field.Type.Package+"."+field.Type.Name]
+			}
+			if inline == nil || inline.Opaque || inline.Underlying != nil {
+				return fmt.Errorf("field %s of %s: type %s cannot be expressed in protobuf: inline field must be struct", field.Name, msg.ct, field.Type)
+			}
+			if err = schema.fields(msg, inline, fieldPath); err != nil {
+				return
+			}
+			continue
+		}
+		pf := protoField{name: protoFieldName(field.JSON), doc: field.Doc, goPath: fieldPath}
+		if pf.ref, err = schema.resolve(field.Type); err != nil {
+			return fmt.Errorf("field %s of %s: %w", field.Name, msg.ct, err)
+		}
+		if err = msg.add(pf); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// protoPackageName returns name of protobuf package from name of Go package.
+func protoPackageName(name string) string {
+
+	name = strings.ToLower(strings.Map(func(r rune) rune {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return '_'
+		}
+		return r
+	}, name))
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "pb" + name
+	}
+	return name
+}
+
+// protoFieldName returns name of field in protobuf style: 'userID' is 'user_id'.
+func protoFieldName(name string) string {
+
+	var sb strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case unicode.IsUpper(r):
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				sb.WriteByte('_')
+			}
+			sb.WriteRune(unicode.ToLower(r))
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			sb.WriteRune(r)
+		default:
+			sb.WriteByte('_')
+		}
+	}
+	return sb.String()
+}
+
+// protoGoName returns name of field in Go code generated by protoc-gen-go.
+func protoGoName(name string) string {
+
+	var out []byte
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		switch {
+		case c == '_' && i == 0:
+			out = append(out, 'X')
+		case c == '_' && i+1 < len(name) && name[i+1] >= 'a' && name[i+1] <= 'z':
+		case c >= '0' && c <= '9':
+			out = append(out, c)
+		default:
+			if c >= 'a' && c <= 'z' {
+				c -= 'a' - 'A'
+			}
+			out = append(out, c)
+			for ; i+1 < len(name) && name[i+1] >= 'a' && name[i+1] <= 'z'; i++ {
+				out = append(out, name[i+1])
+			}
+		}
+	}
+	return string(out)
+}
+
+func (schema *grpcSchema) protoFile() string {
+
+	var sb strings.Builder
+	line := func(format string, args ...any) {
+		sb.WriteString(fmt.Sprintf(format, args...) + "\n")
+	}
+	comment := func(indent, text string) {
+		if text == "" {
+			return
+		}
+		for _, s := range strings.Split(text, "\n") {
+			line("%s// %s", indent, s)
+		}
+	}
+	line("// %s", doNotEdit)
+	line("")
+	line(`syntax = "proto3";`)
+	line("")
+	line("package %s;", schema.pkgName)
+	line("")
+	if schema.uses[protoDuration] || schema.uses[protoTimestamp] {
+		if schema.uses[protoDuration] {
+			line(`import "google/protobuf/duration.proto";`)
+		}
+		if schema.uses[protoTimestamp] {
+			line(`import "google/protobuf/timestamp.proto";`)
+		}
+		line("")
+	}
+	line(`option go_package = "%s";`, schema.goPkg)
+	var messages []*protoMessage
+	for _, serviceName := range schema.tr.serviceKeys() {
+		svc := schema.tr.services[serviceName]
+		if !svc.tags.Contains(tagServerGRPC) {
+			continue
+		}
+		line("")
+		comment("", docText(svc.Docs))
+		line("service %s {", svc.Name)
+		for _, pm := range schema.methods[svc.Name] {
+			comment("  ", pm.method.description())
+			line("  rpc %s(%s) returns (%s);", pm.method.Name, pm.request.name, pm.response.name)
+			messages = append(messages, pm.request, pm.response)
+		}
+		line("}")
+	}
+	var dtos []*protoMessage
+	for _, msg := range schema.messages {
+		if msg.ct != nil {
+			dtos = append(dtos, msg)
+		}
+	}
+	sort.Slice(dtos, func(i, j int) bool { return dtos[i].name < dtos[j].name })
+	for _, msg := range append(messages, dtos...) {
+		line("")
+		comment("", msg.doc)
+		line("message %s {", msg.name)
+		for i, field := range msg.fields {
+			comment("  ", field.doc)
+			switch field.ref.kind {
+			case protoOptional, protoRepeated:
+				line("  %s %s %s = %d;", field.ref.kind, field.ref.name, field.name, i+1)
+			default:
+				line("  %s %s = %d;", field.ref.name, field.name, i+1)
+			}
+		}
+		line("}")
+	}
+	return sb.String()
+}
+
+// renderGRPC renders protobuf contract of services into 'pb' package and converters of types between Go and protobuf.
+// Server adapters are rendered into transport, clients into client package.
+func (tr *Transport) renderGRPC(outDir string, server bool) (err error) {
+
+	var schema *grpcSchema
+	if schema, err = tr.newGRPCSchema(); err != nil {
+		return
+	}
+	pbDir := path.Join(tr.svcDir, "pb")
+	if err = tr.output().MkdirAll(pbDir, 0777); err != nil {
+		return
+	}
+	protoName := schema.pkgName + ".proto"
+	if err = tr.output().WriteFile(path.Join(pbDir, protoName), []byte(schema.protoFile()), 0600); err != nil {
+		return
+	}
+	pbFile := tr.newSrc("pb")
+	pbFile.PackageComment(doNotEdit)
+	pbFile.Comment("//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative " + protoName)
+	if err = pbFile.Save(path.Join(pbDir, "pb.go")); err != nil {
+		return
+	}
+	if err = schema.renderConverters(outDir, server); err != nil {
+		return
+	}
+	for _, serviceName := range tr.serviceKeys() {
+		svc := tr.services[serviceName]
+		if !svc.tags.Contains(tagServerGRPC) {
+			continue
+		}
+		if server {
+			svc.showError(svc.renderGRPC(outDir, schema), "renderGRPC")
+			continue
+		}
+		svc.showError(svc.renderClientGRPC(outDir, schema), "renderClientGRPC")
+	}
+	return
+}
+
+func (schema *grpcSchema) renderConverters(outDir string, server bool) (err error) {
+
+	srcFile := schema.tr.newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	srcFile.ImportName(schema.goPkg, "pb")
+	srcFile.ImportName(packageGRPCCodes, "codes")
+	srcFile.ImportName(packageGRPCStatus, "status")
+	srcFile.ImportName(packageTimestampPB, "timestamppb")
+	srcFile.ImportName(packageDurationPB, "durationpb")
+
+	if server {
+		srcFile.ImportName(packageGRPC, "grpc")
+		srcFile.Line().Add(schema.registerGRPCFunc())
+		srcFile.Line().Add(schema.grpcErrorFunc())
+	}
+	if schema.uses[protoTimestamp] {
+		srcFile.Line().Func().Id("timeToProto").Params(Id("t").Qual(packageTime, "Time")).Params(Op("*").Qual(packageTimestampPB, "Timestamp")).Block(
+			If(Id("t").Dot("IsZero").Call()).Block(Return(Nil())),
+			Return(Qual(packageTimestampPB, "New").Call(Id("t"))),
+		)
+		srcFile.Line().Func().Id("timeFromProto").Params(Id("ts").Op("*").Qual(packageTimestampPB, "Timestamp")).Params(Qual(packageTime, "Time")).Block(
+			If(Id("ts").Op("==").Nil()).Block(Return(Qual(packageTime, "Time").Values())),
+			Return(Id("ts").Dot("AsTime").Call()),
+		)
+	}
+	if schema.uses[protoUUID] {
+		uuidPkg := schema.tr.tags.Value(tagPackageUUID, packageUUID)
+		srcFile.Line().Func().Id("uuidFromProto").Params(Id("s").String()).Params(Id("id").Qual(uuidPkg, "UUID"), Err().Error()).Block(
+			If(Id("s").Op("==").Lit("")).Block(Return()),
+			Return(Qual(uuidPkg, "Parse").Call(Id("s"))),
+		)
+	}
+	var names []string
+	for name, msg := range schema.messages {
+		if msg.ct != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		msg := schema.messages[name]
+		srcFile.Line().Func().Id("toProto" + name).Params(Id("in").Add(goTypeOf(msg.ct))).Params(Id("out").Op("*").Qual(schema.goPkg, name)).BlockFunc(func(bg *Group) {
+			bg.Id("out").Op("=").Op("&").Qual(schema.goPkg, name).Values()
+			for _, field := range msg.fields {
+				schema.toProto(bg, fieldOf("out", field.name), goPathOf("in", field.goPath), field.ref)
+			}
+			bg.Return()
+		})
+		srcFile.Line().Func().Id("fromProto"+name).Params(Id("in").Op("*").Qual(schema.goPkg, name)).Params(Id("out").Add(goTypeOf(msg.ct)), Err().Error()).BlockFunc(func(bg *Group) {
+			bg.If(Id("in").Op("==").Nil()).Block(Return())
+			for _, field := range msg.fields {
+				schema.fromProto(bg, goPathOf("out", field.goPath), fieldOf("in", field.name), field.ref, func() Code { return Return() })
+			}
+			bg.Return()
+		})
+	}
+	return srcFile.Save(path.Join(outDir, "grpc.go"))
+}
+
+// registerGRPCFunc returns RegisterGRPC of Server: services of server are served by gRPC with the same middlewares.
+func (schema *grpcSchema) registerGRPCFunc() Code {
+
+	return Func().Params(Id("srv").Op("*").Id("Server")).Id("RegisterGRPC").Params(Id("registrar").Qual(packageGRPC, "ServiceRegistrar")).BlockFunc(func(bg *Group) {
+		for _, serviceName := range schema.tr.serviceKeys() {
+			if !schema.tr.services[serviceName].tags.Contains(tagServerGRPC) {
+				continue
+			}
+			bg.If(Id("srv").Dot("http" + serviceName).Op("!=").Nil()).Block(
+				Qual(schema.goPkg, "Register"+serviceName+"Server").Call(Id("registrar"), Op("&").Id("grpc"+serviceName).Values(Dict{
+					Id("svc"): Id("srv").Dot("http" + serviceName).Dot("Service").Call(),
+				})),
+			)
+		}
+	})
+}
+
+// grpcErrorFunc returns function, which converts error of service to error of gRPC: code of error is mapped to gRPC code.
+func (schema *grpcSchema) grpcErrorFunc() Code {
+
+	codes := []struct{ http, grpc string }{
+		{"StatusBadRequest", "InvalidArgument"},
+		{"StatusUnauthorized", "Unauthenticated"},
+		{"StatusForbidden", "PermissionDenied"},
+		{"StatusNotFound", "NotFound"},
+		{"StatusConflict", "AlreadyExists"},
+		{"StatusTooManyRequests", "ResourceExhausted"},
+		{"StatusNotImplemented", "Unimplemented"},
+		{"StatusServiceUnavailable", "Unavailable"},
+		{"StatusGatewayTimeout", "DeadlineExceeded"},
+	}
+	return Func().Id("grpcError").Params(Err().Error()).Error().Block(
+		If(List(Id("_"), Id("ok")).Op(":=").Qual(packageGRPCStatus, "FromError").Call(Err()).Op(";").Id("ok")).Block(
+			Return(Err()),
+		),
+		Id("code").Op(":=").Qual(packageGRPCCodes, "Unknown"),
+		If(List(Id("errCoder"), Id("ok")).Op(":=").Err().Op(".").Call(Id("withErrorCode")).Op(";").Id("ok")).Block(
+			Switch(Id("errCoder").Dot("Code").Call()).BlockFunc(func(sg *Group) {
+				for _, c := range codes {
+					sg.Case(Qual(packageHttp, c.http)).Block(Id("code").Op("=").Qual(packageGRPCCodes, c.grpc))
+				}
+				sg.Default().Block(Id("code").Op("=").Qual(packageGRPCCodes, "Internal"))
+			}),
+		),
+		Return(Qual(packageGRPCStatus, "Error").Call(Id("code"), Err().Dot("Error").Call())),
+	)
+}
+
+func fieldOf(object, protoName string) func() *Statement {
+	return func() *Statement { return Id(object).Dot(protoGoName(protoName)) }
+}
+
+func goPathOf(object string, goPath []string) func() *Statement {
+
+	return func() *Statement {
+		code := Id(object)
+		for _, name := range goPath {
+			code = code.Dot(name)
+		}
+		return code
+	}
+}
+
+// goTypeOf returns Go type of contract type.
+func goTypeOf(ct *ContractType) *Statement {
+
+	switch ct.Kind {
+	case kindNamed:
+		return Qual(ct.Package, ct.Name)
+	case kindPointer:
+		return Op("*").Add(goTypeOf(ct.Elem))
+	case kindSlice:
+		return Index().Add(goTypeOf(ct.Elem))
+	case kindMap:
+		return Map(goTypeOf(ct.Key)).Add(goTypeOf(ct.Elem))
+	}
+	return Id(ct.Name)
+}
+
+// pbTypeOf returns Go type of protobuf type in code generated by protoc-gen-go.
+func (schema *grpcSchema) pbTypeOf(ref *protoRef) *Statement {
+
+	switch ref.kind {
+	case protoScalar:
+		return Id(ref.goType)
+	case protoUUID:
+		return String()
+	case protoBytes:
+		return Index().Byte()
+	case protoTimestamp:
+		return Op("*").Qual(packageTimestampPB, "Timestamp")
+	case protoDuration:
+		return Op("*").Qual(packageDurationPB, "Duration")
+	case protoStruct:
+		return Op("*").Qual(schema.goPkg, ref.name)
+	case protoPointer:
+		return schema.pbTypeOf(ref.elem)
+	case protoOptional:
+		return Op("*").Add(schema.pbTypeOf(ref.elem))
+	case protoRepeated:
+		return Index().Add(schema.pbTypeOf(ref.elem))
+	}
+	return Map(schema.pbTypeOf(ref.key)).Add(schema.pbTypeOf(ref.elem))
+}
+
+// convertScalar returns value of scalar converted to type, when types differ.
+func convertScalar(value *Statement, from, to *ContractType, toType *Statement) *Statement {
+
+	if from.Kind == kindBuiltin && to.Kind == kindBuiltin && from.Name == to.Name {
+		return value
+	}
+	return toType.Call(value)
+}
+
+// toProto adds statements, which convert Go value of src to protobuf value of dst.
+func (schema *grpcSchema) toProto(bg *Group, dst, src func() *Statement, ref *protoRef) {
+
+	switch ref.kind {
+	case protoScalar:
+		pbType := &ContractType{Kind: kindBuiltin, Name: ref.goType}
+		bg.Add(dst()).Op("=").Add(convertScalar(src(), ref.ct, pbType, Id(ref.goType)))
+	case protoBytes:
+		if ref.ct.Kind == kindNamed {
+			bg.Add(dst()).Op("=").Index().Byte().Call(src())
+			return
+		}
+		bg.Add(dst()).Op("=").Add(src())
+	case protoUUID:
+		bg.Add(dst()).Op("=").Add(src()).Dot("String").Call()
+	case protoTimestamp:
+		bg.Add(dst()).Op("=").Id("timeToProto").Call(src())
+	case protoDuration:
+		bg.Add(dst()).Op("=").Qual(packageDurationPB, "New").Call(src())
+	case protoStruct:
+		bg.Add(dst()).Op("=").Id("toProto" + ref.name).Call(src())
+	case protoPointer:
+		bg.If(src().Op("!=").Nil()).BlockFunc(func(ig *Group) {
+			if ref.elem.kind == protoTimestamp {
+				ig.Add(dst()).Op("=").Qual(packageTimestampPB, "New").Call(Op("*").Add(src()))
+				return
+			}
+			schema.toProto(ig, dst, func() *Statement { return Op("*").Add(src()) }, ref.elem)
+		})
+	case protoOptional:
+		value := func() *Statement { return Op("*").Add(src()) }
+		if ref.elem.kind == protoUUID {
+			value = func() *Statement { return Parens(Op("*").Add(src())) }
+		}
+		bg.If(src().Op("!=").Nil()).BlockFunc(func(ig *Group) {
+			ig.Var().Id("item").Add(schema.pbTypeOf(ref.elem))
+			schema.toProto(ig, func() *Statement { return Id("item") }, value, ref.elem)
+			ig.Add(dst()).Op("=").Op("&").Id("item")
+		})
+	case protoRepeated:
+		bg.If(src().Op("!=").Nil()).BlockFunc(func(ig *Group) {
+			ig.Add(dst()).Op("=").Make(schema.pbTypeOf(ref), Len(src()))
+			ig.For(Id("i").Op(":=").Range().Add(src())).BlockFunc(func(fg *Group) {
+				schema.toProto(fg, func() *Statement { return dst().Index(Id("i")) }, func() *Statement { return src().Index(Id("i")) }, ref.elem)
+			})
+		})
+	case protoMap:
+		bg.If(src().Op("!=").Nil()).BlockFunc(func(ig *Group) {
+			ig.Add(dst()).Op("=").Make(schema.pbTypeOf(ref), Len(src()))
+			ig.For(List(Id("key"), Id("value")).Op(":=").Range().Add(src())).BlockFunc(func(fg *Group) {
+				key := convertScalar(Id("key"), ref.key.ct, &ContractType{Kind: kindBuiltin, Name: ref.key.goType}, Id(ref.key.goType))
+				schema.toProto(fg, func() *Statement { return dst().Index(key.Clone()) }, func() *Statement { return Id("value") }, ref.elem)
+			})
+		})
+	}
+}
+
+// fromProto adds statements, which convert protobuf value of src to Go value of dst. Statement of onError is added,
+// when conversion fails, error is in 'err'.
+func (schema *grpcSchema) fromProto(bg *Group, dst, src func() *Statement, ref *protoRef, onError func() Code) {
+
+	switch ref.kind {
+	case protoScalar:
+		bg.Add(dst()).Op("=").Add(convertScalar(src(), &ContractType{Kind: kindBuiltin, Name: ref.goType}, ref.ct, goTypeOf(ref.ct)))
+	case protoBytes:
+		if ref.ct.Kind == kindNamed {
+			bg.Add(dst()).Op("=").Add(goTypeOf(ref.ct)).Call(src())
+			return
+		}
+		bg.Add(dst()).Op("=").Add(src())
+	case protoUUID:
+		bg.If(List(dst(), Err()).Op("=").Id("uuidFromProto").Call(src()).Op(";").Err().Op("!=").Nil()).Block(onError())
+	case protoTimestamp:
+		bg.Add(dst()).Op("=").Id("timeFromProto").Call(src())
+	case protoDuration:
+		bg.Add(dst()).Op("=").Add(src()).Dot("AsDuration").Call()
+	case protoStruct:
+		bg.If(List(dst(), Err()).Op("=").Id("fromProto" + ref.name).Call(src()).Op(";").Err().Op("!=").Nil()).Block(onError())
+	case protoPointer, protoOptional:
+		value := src
+		if ref.kind == protoOptional {
+			value = func() *Statement { return Op("*").Add(src()) }
+		}
+		bg.If(src().Op("!=").Nil()).BlockFunc(func(ig *Group) {
+			ig.Var().Id("item").Add(goTypeOf(ref.elem.ct))
+			schema.fromProto(ig, func() *Statement { return Id("item") }, value, ref.elem, onError)
+			ig.Add(dst()).Op("=").Op("&").Id("item")
+		})
+	case protoRepeated:
+		bg.If(src().Op("!=").Nil()).BlockFunc(func(ig *Group) {
+			ig.Add(dst()).Op("=").Make(goTypeOf(ref.ct), Len(src()))
+			ig.For(Id("i").Op(":=").Range().Add(src())).BlockFunc(func(fg *Group) {
+				schema.fromProto(fg, func() *Statement { return dst().Index(Id("i")) }, func() *Statement { return src().Index(Id("i")) }, ref.elem, onError)
+			})
+		})
+	case protoMap:
+		bg.If(src().Op("!=").Nil()).BlockFunc(func(ig *Group) {
+			ig.Add(dst()).Op("=").Make(goTypeOf(ref.ct), Len(src()))
+			ig.For(List(Id("key"), Id("value")).Op(":=").Range().Add(src())).BlockFunc(func(fg *Group) {
+				key := convertScalar(Id("key"), &ContractType{Kind: kindBuiltin, Name: ref.key.goType}, ref.key.ct, goTypeOf(ref.key.ct))
+				schema.fromProto(fg, func() *Statement { return dst().Index(key.Clone()) }, func() *Statement { return Id("value") }, ref.elem, onError)
+			})
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/seniorGolang/tg/v2/pkg/config"
+)
+
+func generateGRPC(t *testing.T, module string, contract string) (*MemFS, error) {
+
+	files := map[string]string{
+		"go.mod":          "module " + module + "\n\ngo 1.22\n",
+		"service/user.go": contract,
+		"dto/user.go": `package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Status string
+
+type Meta struct {
+	CreatedAt time.Time ` + "`json:\"createdAt\"`" + `
+}
+
+// User is a user of service.
+type User struct {
+	Meta
+	ID     uuid.UUID         ` + "`json:\"id\"`" + `
+	Status Status            ` + "`json:\"status\"`" + `
+	Phone  *string           ` + "`json:\"phone,omitempty\"`" + `
+	Groups []*Group          ` + "`json:\"groups\"`" + `
+	Labels map[string]string ` + "`json:\"labels\"`" + `
+	TTL    time.Duration     ` + "`json:\"ttl\"`" + `
+}
+
+type Group struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+`,
+	}
+	dir := writeProject(t, files)
+	memFS := NewMemFS(dir)
+	_, err := Generate(Config{
+		Services: []config.Service{{
+			Dir:       "service",
+			Transport: &config.Transport{Out: "transport"},
+			Client:    &config.Client{Out: "client", Go: true},
+		}},
+		Strict: true,
+		Output: memFS,
+	})
+	return memFS, err
+}
+
+func TestGenerateGRPC(t *testing.T) {
+
+	memFS, err := generateGRPC(t, "example.com/api", `package service
+
+import (
+	"context"
+
+	"example.com/api/dto"
+)
+
+// @tg grpc-server log metrics
+type User interface {
+	// Get returns user by id.
+	Get(ctx context.Context, id int, ids ...int64) (user dto.User, err error)
+}
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertGenerated(t, memFS, "transport/pb", map[string]string{
+		"service/pb/service.proto": `package service;
+
+import "google/protobuf/duration.proto";
+import "google/protobuf/timestamp.proto";
+
+option go_package = "example.com/api/service/pb";
+
+service User {
+  // Get returns user by id.
+  rpc Get(UserGetRequest) returns (UserGetResponse);
+}
+
+message UserGetRequest {
+  int64 id = 1;
+  repeated int64 ids = 2;
+}
+
+message UserGetResponse {
+  User user = 1;
+}
+
+message Group {
+  string name = 1;
+}
+
+// User is a user of service.
+message User {
+  google.protobuf.Timestamp created_at = 1;
+  string id = 2;
+  string status = 3;
+  optional string phone = 4;
+  repeated Group groups = 5;
+  map<string, string> labels = 6;
+  google.protobuf.Duration ttl = 7;
+}
+`,
+		"service/pb/pb.go":       "//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative service.proto",
+		"transport/user-grpc.go": "if user, err = srv.svc.Get(ctx, id, ids...); err != nil {",
+		"transport/grpc.go":      "func (srv *Server) RegisterGRPC(registrar grpc.ServiceRegistrar) {",
+		"client/user-grpc.go":    "func (cli *ClientUserGRPC) Get(ctx context.Context, id int, ids ...int64) (user dto.User, err error) {",
+		"client/grpc.go":         "func fromProtoUser(in *pb.User) (out dto.User, err error) {",
+	})
+}
+
+func TestGenerateGRPCLocalModule(t *testing.T) {
+
+	memFS, err := generateGRPC(t, "myapi", `package service
+
+import (
+	"context"
+
+	"myapi/dto"
+)
+
+// @tg grpc-server
+type User interface {
+	Get(ctx context.Context, id int) (user dto.User, err error)
+}
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertGenerated(t, memFS, "transport/pb", map[string]string{
+		"service/pb/service.proto": "option go_package = \"myapi/service/pb\";",
+		"client/grpc.go":           "func fromProtoUser(in *pb.User) (out dto.User, err error) {",
+	})
+}
+
+func TestGenerateGRPCUnsupported(t *testing.T) {
+
+	_, err := generateGRPC(t, "example.com/api", `package service
+
+import "context"
+
+// @tg grpc-server
+type User interface {
+	Matrix(ctx context.Context, rows [][]string) (err error)
+	Events(ctx context.Context) (events chan string, err error)
+}
+`)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	for _, message := range []string{
+		"User.Matrix: argument 'rows': type [][]string cannot be expressed in protobuf: nested collections",
+		"User.Events: result 'events': type chan string cannot be expressed in protobuf",
+	} {
+		if !strings.Contains(err.Error(), message) {
+			t.Fatalf("error does not contain %q: %v", message, err)
+		}
+	}
+}
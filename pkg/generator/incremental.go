@@ -0,0 +1,226 @@
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/seniorGolang/tg/v2/pkg/tags"
+)
+
+// manifestName is a name of file in output directory, which keeps hashes of inputs and files of generated targets.
+const manifestName = ".tg-manifest.json"
+
+const manifestVersion = 1
+
+// Targets of generation, they are keys of manifest. Files of plugin are recorded to remove them by next run.
+const (
+	targetTransport = "transport"
+	targetSwagger   = "swagger"
+	targetClient    = "client"
+	targetClientJS  = "client-js"
+	targetClientTS  = "client-ts"
+	targetNPM       = "npm"
+	targetAzure     = "azure"
+	targetPlugin    = "plugin"
+)
+
+type manifest struct {
+	Version int                       `json:"version"`
+	Targets map[string]manifestTarget `json:"targets"`
+}
+
+// manifestTarget keeps hash of target inputs and hashes of its files by paths relative to output directory.
+type manifestTarget struct {
+	Inputs string            `json:"inputs"`
+	Files  map[string]string `json:"files"`
+}
+
+// SetIncremental enables incremental generation: target is skipped, when its inputs (source files of contract,
+// options and version of generator) and generated files are not changed since previous generation, only changed files are written.
+// It works with output on disk.
+func (tr *Transport) SetIncremental(incremental bool) {
+	tr.state.incremental = incremental
+}
+
+func (tr *Transport) incremental() bool {
+
+	if !tr.state.incremental || tr.state.verify {
+		return false
+	}
+	_, inMemory := tr.output().(*memOutput)
+	return tr.onDisk() || inMemory
+}
+
+// renderIncremental renders target with manifest in outDir. Manifest is not updated, when render step is failed,
+// so target is rendered again by next run.
+func (tr *Transport) renderIncremental(target, outDir string, options any, render func() error) (err error) {
+
+	if !tr.incremental() {
+		return render()
+	}
+	outDir = absPath(outDir)
+	inputs := tr.inputsHash(target, outDir, options)
+	cache := tr.readManifest(outDir)
+	if tr.upToDate(cache, outDir, target, inputs) {
+		tr.log.WithField("target", target).Infof("%s is up to date", outDir)
+		return
+	}
+	var out *memOutput
+	tr.state.errs = nil
+	if out, err = tr.renderInMemory(render); err != nil {
+		return
+	}
+	if _, err = out.apply(tr.output()); err != nil {
+		return
+	}
+	delete(cache.Targets, target)
+	if len(tr.state.errs) == 0 {
+		files := make(map[string]string, len(out.files))
+		for filePath, data := range out.files {
+			if relPath, err := filepath.Rel(outDir, filePath); err == nil {
+				files[filepath.ToSlash(relPath)] = hash(data)
+			}
+		}
+		cache.Targets[target] = manifestTarget{Inputs: inputs, Files: files}
+	}
+	return tr.writeManifest(outDir, cache)
+}
+
+func (tr *Transport) inputsHash(target, outDir string, options any) string {
+
+	overrides := make(map[string]string, len(tr.state.overrides))
+	for artifact, snippet := range tr.state.overrides {
+		overrides[artifact] = snippet.code
+		for _, spec := range snippet.imports {
+			overrides[artifact] += "\n" + spec.name + " " + spec.path
+		}
+	}
+	data, _ := json.Marshal(struct {
+		Version   string            `json:"version"`
+		Target    string            `json:"target"`
+		Out       string            `json:"out"`
+		Options   any               `json:"options,omitempty"`
+		Overrides map[string]string `json:"overrides,omitempty"`
+		Tags      tags.DocTags      `json:"tags,omitempty"`
+		Sources   map[string]string `json:"sources"`
+		Contract  *Contract         `json:"contract"`
+	}{
+		Version:   tr.version,
+		Target:    target,
+		Out:       tr.moduleRel(outDir),
+		Options:   options,
+		Overrides: overrides,
+		Tags:      tr.tags,
+		Sources:   tr.sourcesHash(),
+		Contract:  tr.Contract(),
+	})
+	return hash(data)
+}
+
+// moduleRel returns slash-separated path relative to root of module of service, so hash of inputs
+// does not depend on place of checkout. Path is kept absolute, when module is unknown.
+func (tr *Transport) moduleRel(filePath string) string {
+
+	if tr.modPath != "" {
+		if relPath, err := filepath.Rel(filepath.Dir(tr.modPath), absPath(filePath)); err == nil {
+			return filepath.ToSlash(relPath)
+		}
+	}
+	return filepath.ToSlash(absPath(filePath))
+}
+
+// sourcesHash returns hashes of Go files in source directories of service: contract does not keep
+// everything, what affects generated code (annotations of fields and types, tags of fields and so on).
+func (tr *Transport) sourcesHash() (sources map[string]string) {
+
+	sources = make(map[string]string)
+	for _, dir := range tr.SourceDirs() {
+		files, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, file := range files {
+			if file.IsDir() || filepath.Ext(file.Name()) != ".go" || strings.HasSuffix(file.Name(), "_test.go") {
+				continue
+			}
+			filePath := filepath.Join(dir, file.Name())
+			if data, err := os.ReadFile(filePath); err == nil {
+				sources[tr.moduleRel(filePath)] = hash(data)
+			}
+		}
+	}
+	return
+}
+
+func (tr *Transport) readManifest(outDir string) (cache manifest) {
+
+	if data, err := tr.readFile(filepath.Join(outDir, manifestName)); err == nil {
+		if err = json.Unmarshal(data, &cache); err != nil || cache.Version != manifestVersion {
+			cache = manifest{}
+		}
+	}
+	cache.Version = manifestVersion
+	if cache.Targets == nil {
+		cache.Targets = make(map[string]manifestTarget)
+	}
+	return
+}
+
+// upToDate checks inputs of target and files on disk, so changed or removed generated file is rendered again.
+func (tr *Transport) upToDate(cache manifest, outDir, target, inputs string) bool {
+
+	entry, found := cache.Targets[target]
+	if !found || entry.Inputs != inputs {
+		return false
+	}
+	for relPath, fileHash := range entry.Files {
+		data, err := tr.readFile(filepath.Join(outDir, filepath.FromSlash(relPath)))
+		if err != nil || hash(data) != fileHash {
+			return false
+		}
+	}
+	return true
+}
+
+func (tr *Transport) writeManifest(outDir string, cache manifest) (err error) {
+
+	if len(cache.Targets) == 0 {
+		if _, readErr := tr.readFile(filepath.Join(outDir, manifestName)); readErr == nil {
+			return tr.output().Remove(filepath.Join(outDir, manifestName))
+		}
+		return
+	}
+	var data []byte
+	if data, err = json.MarshalIndent(cache, "", "  "); err != nil {
+		return
+	}
+	if err = tr.output().MkdirAll(outDir, 0777); err != nil {
+		return
+	}
+	return tr.output().WriteFile(filepath.Join(outDir, manifestName), append(data, '\n'), 0600)
+}
+
+// readFile reads file from output in memory, when file is generated or removed there, otherwise from disk.
+func (tr *Transport) readFile(filePath string) ([]byte, error) {
+
+	if out, inMemory := tr.output().(*memOutput); inMemory {
+		filePath = absPath(filePath)
+		if data, found := out.files[filePath]; found {
+			return data, nil
+		}
+		if out.removed[filePath] {
+			return nil, os.ErrNotExist
+		}
+	}
+	return os.ReadFile(filePath)
+}
+
+func hash(data []byte) string {
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,175 @@
+package generator
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/seniorGolang/tg/v2/pkg/config"
+)
+
+func TestGenerateIncremental(t *testing.T) {
+
+	contract := `// @tg version=1.0.0
+package service
+
+import "context"
+
+// @tg jsonRPC-server
+type User interface {
+	GetName(ctx context.Context, id int) (name string, err error)
+}
+`
+	files := map[string]string{
+		"go.mod":          "module example.com/api\n\ngo 1.22\n",
+		"service/user.go": contract,
+	}
+	writeProject(t, files)
+	cfg := Config{
+		Services: []config.Service{{
+			Dir:       "service",
+			Transport: &config.Transport{Out: "transport"},
+			Swagger:   &config.Swagger{Out: "api/swagger.yaml"},
+		}},
+		Strict:      true,
+		Incremental: true,
+	}
+	generate := func() {
+		t.Helper()
+		if _, err := Generate(cfg); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	generate()
+	for _, name := range []string{"transport/" + manifestName, "api/" + manifestName} {
+		if _, err := os.Stat(name); err != nil {
+			t.Fatalf("manifest is not written: %v", err)
+		}
+	}
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for _, name := range []string{"transport/server.go", "transport/user-exchange.go", "api/swagger.yaml"} {
+		if err := os.Chtimes(name, past, past); err != nil {
+			t.Fatal(err)
+		}
+	}
+	modTime := func(name string) time.Time {
+		t.Helper()
+		info, err := os.Stat(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return info.ModTime()
+	}
+	generate()
+	if !modTime("transport/server.go").Equal(past) || !modTime("api/swagger.yaml").Equal(past) {
+		t.Fatal("files of unchanged targets are rewritten")
+	}
+	if err := os.WriteFile("transport/server.go", []byte("package transport\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes("transport/server.go", past, past); err != nil {
+		t.Fatal(err)
+	}
+	generate()
+	if data, _ := os.ReadFile("transport/server.go"); !strings.Contains(string(data), "func New(") {
+		t.Fatalf("changed generated file is not restored:\n%s", data)
+	}
+	if !modTime("transport/user-exchange.go").Equal(past) {
+		t.Fatal("unchanged file is rewritten")
+	}
+	contract = strings.Replace(contract, "(name string", "(fullName string", 1)
+	if err := os.WriteFile("service/user.go", []byte(contract), 0600); err != nil {
+		t.Fatal(err)
+	}
+	generate()
+	if data, _ := os.ReadFile("transport/user-exchange.go"); !strings.Contains(string(data), "FullName") {
+		t.Fatalf("target is not regenerated after change of contract:\n%s", data)
+	}
+}
+
+func TestGenerateIncrementalSources(t *testing.T) {
+
+	dto := `package dto
+
+type User struct {
+	// @tg example=alice
+	Name string ` + "`json:\"name\"`" + `
+}
+`
+	files := map[string]string{
+		"go.mod": "module myapi\n\ngo 1.22\n",
+		"service/user.go": `package service
+
+import (
+	"context"
+
+	"myapi/dto"
+)
+
+// @tg jsonRPC-server
+type User interface {
+	Get(ctx context.Context, id int) (user dto.User, err error)
+}
+`,
+		"dto/user.go": dto,
+	}
+	writeProject(t, files)
+	cfg := Config{
+		Services: []config.Service{{
+			Dir:     "service",
+			Swagger: &config.Swagger{Out: "api/swagger.yaml"},
+		}},
+		Strict:      true,
+		Incremental: true,
+	}
+	for _, example := range []string{"alice", "bob"} {
+		if err := os.WriteFile("dto/user.go", []byte(strings.Replace(dto, "alice", example, 1)), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Generate(cfg); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if data, _ := os.ReadFile("api/swagger.yaml"); !strings.Contains(string(data), "example: "+example) {
+			t.Fatalf("swagger is not regenerated after change of annotation:\n%s", data)
+		}
+	}
+}
+
+func TestGenerateIncrementalRelocated(t *testing.T) {
+
+	files := map[string]string{
+		"go.mod": "module example.com/api\n\ngo 1.22\n",
+		"service/user.go": `package service
+
+import "context"
+
+// @tg jsonRPC-server
+type User interface {
+	Get(ctx context.Context, id int) (name string, err error)
+}
+`,
+	}
+	cfg := Config{
+		Services: []config.Service{{
+			Dir:       "service",
+			Transport: &config.Transport{Out: "transport"},
+		}},
+		Incremental: true,
+	}
+	var manifests []string
+	for range 2 {
+		writeProject(t, files)
+		if _, err := Generate(cfg); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		data, err := os.ReadFile("transport/" + manifestName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		manifests = append(manifests, string(data))
+	}
+	if manifests[0] != manifests[1] {
+		t.Fatalf("manifest depends on place of project:\n%s\n%s", manifests[0], manifests[1])
+	}
+}
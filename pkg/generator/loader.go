@@ -0,0 +1,385 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	gotypes "go/types"
+	"strings"
+	"sync"
+
+	"github.com/fatih/structtag"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/seniorGolang/tg/v2/pkg/astra/types"
+	"github.com/seniorGolang/tg/v2/pkg/diagnostic"
+)
+
+// Loaders of types, which are used by contract.
+const (
+	// LoaderSource parses source files of package directory, which is found by import path.
+	LoaderSource = "source"
+	// LoaderPackages loads packages by 'go/packages' with full type information,
+	// so 'replace' directives, vendoring, build tags and aliases are resolved as by Go compiler.
+	LoaderPackages = "packages"
+)
+
+// ruleLoadPackage reports package, which cannot be loaded by LoaderPackages. Its types are parsed from sources.
+const ruleLoadPackage = "load-package"
+
+// SetLoader selects loader of types for transport and its copies, loaded packages are kept by transport.
+// Default loader is LoaderSource.
+func (tr *Transport) SetLoader(loader string) (err error) {
+
+	switch loader {
+	case "", LoaderSource:
+		tr.state.loader = nil
+	case LoaderPackages:
+		tr.state.loader = &packagesLoader{packages: make(map[string]*packageLoad)}
+	default:
+		return fmt.Errorf("unknown loader '%s', expected %s or %s", loader, LoaderSource, LoaderPackages)
+	}
+	return
+}
+
+// loadType returns declaration of type and constants of its package by LoaderPackages.
+// Found is false, when loader is not selected, package cannot be loaded or type is not declared in package.
+// Package, which cannot be loaded, is reported once.
+func (tr *Transport) loadType(pkgPath, name string) (retType types.Type, constants []types.Constant, found bool) {
+
+	if tr.state.loader == nil || pkgPath == "" {
+		return
+	}
+	pkg, err := tr.state.loader.load(tr.svcDir, pkgPath)
+	if err != nil {
+		tr.log.WithError(err).WithField("package", pkgPath).Warn("load package")
+		tr.state.diags = append(tr.state.diags, diagnostic.Warnf(tr.position(), ruleLoadPackage, "%v, types are parsed from sources", err))
+	}
+	if pkg == nil {
+		return
+	}
+	return pkg.lookup(name)
+}
+
+type packagesLoader struct {
+	mx       sync.Mutex
+	packages map[string]*packageLoad
+}
+
+// packageLoad is a result of load of package, the same package is loaded once.
+type packageLoad struct {
+	once sync.Once
+	pkg  *loadedPackage
+}
+
+type loadedPackage struct {
+	*packages.Package
+	docs      map[token.Pos][]string
+	constants []types.Constant
+}
+
+// load loads package by import path in module of directory, empty directory is a current one.
+// Error is returned by the call, which loads package, the next calls return nil package.
+func (loader *packagesLoader) load(dir, pkgPath string) (pkg *loadedPackage, err error) {
+
+	load := loader.entry(dir, pkgPath)
+	load.once.Do(func() {
+		var loaded *packages.Package
+		if loaded, err = loadPackage(dir, pkgPath); err != nil {
+			return
+		}
+		load.pkg = newLoadedPackage(loaded)
+		loader.keepDeps(dir, loaded)
+	})
+	return load.pkg, err
+}
+
+func (loader *packagesLoader) entry(dir, pkgPath string) (load *packageLoad) {
+
+	loader.mx.Lock()
+	defer loader.mx.Unlock()
+	key := dir + ":" + pkgPath
+	if load = loader.packages[key]; load == nil {
+		load = &packageLoad{}
+		loader.packages[key] = load
+	}
+	return
+}
+
+// keepDeps caches dependencies of loaded package, they are loaded with it. So packages of contract are loaded
+// by a few calls of 'go list' instead of a call per package.
+func (loader *packagesLoader) keepDeps(dir string, loaded *packages.Package) {
+
+	packages.Visit([]*packages.Package{loaded}, nil, func(dep *packages.Package) {
+		if dep == loaded || dep.Types == nil || len(dep.Errors) != 0 {
+			return
+		}
+		load := loader.entry(dir, dep.PkgPath)
+		load.once.Do(func() { load.pkg = newLoadedPackage(dep) })
+	})
+}
+
+// loadPackage loads syntax and types of package with its dependencies. Types of dependencies are taken from
+// their sources, because 'go/packages' cannot import export data of dependencies without them.
+func loadPackage(dir, pkgPath string) (pkg *packages.Package, err error) {
+
+	cfg := &packages.Config{Dir: dir, Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps}
+	var pkgs []*packages.Package
+	if pkgs, err = packages.Load(cfg, pkgPath); err != nil {
+		return nil, fmt.Errorf("load package %s: %w", pkgPath, err)
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("load package %s: %d packages are found", pkgPath, len(pkgs))
+	}
+	if len(pkgs[0].Errors) != 0 {
+		problems := make([]string, 0, len(pkgs[0].Errors))
+		for _, pkgErr := range pkgs[0].Errors {
+			problems = append(problems, pkgErr.Error())
+		}
+		return nil, fmt.Errorf("load package %s: %s", pkgPath, strings.Join(problems, "; "))
+	}
+	if pkgs[0].Types == nil {
+		return nil, fmt.Errorf("load package %s: no type information", pkgPath)
+	}
+	return pkgs[0], nil
+}
+
+func newLoadedPackage(loaded *packages.Package) (pkg *loadedPackage) {
+
+	pkg = &loadedPackage{Package: loaded, docs: make(map[token.Pos][]string)}
+	pkg.collectDocs()
+	pkg.collectConstants()
+	return
+}
+
+func (pkg *loadedPackage) lookup(name string) (retType types.Type, constants []types.Constant, found bool) {
+
+	typeName, ok := pkg.Types.Scope().Lookup(name).(*gotypes.TypeName)
+	if !ok {
+		return
+	}
+	return pkg.declaration(typeName), pkg.constants, true
+}
+
+func (pkg *loadedPackage) position(pos token.Pos) types.Position {
+
+	p := pkg.Fset.Position(pos)
+	return types.Position{Filename: p.Filename, Line: p.Line, Column: p.Column}
+}
+
+// collectDocs collects comments of type declarations and struct fields by position of their names.
+func (pkg *loadedPackage) collectDocs() {
+
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				pkg.docs[typeSpec.Name.Pos()] = comments(genDecl.Doc, typeSpec.Doc, typeSpec.Comment)
+				ast.Inspect(typeSpec.Type, func(node ast.Node) bool {
+					if field, ok := node.(*ast.Field); ok {
+						for _, fieldName := range field.Names {
+							pkg.docs[fieldName.Pos()] = comments(field.Doc, field.Comment)
+						}
+						if len(field.Names) == 0 {
+							pkg.docs[embeddedPos(field.Type)] = comments(field.Doc, field.Comment)
+						}
+					}
+					return true
+				})
+			}
+		}
+	}
+}
+
+func (pkg *loadedPackage) collectConstants() {
+
+	scope := pkg.Types.Scope()
+	for _, name := range scope.Names() {
+		if constant, ok := scope.Lookup(name).(*gotypes.Const); ok {
+			pkg.constants = append(pkg.constants, types.Constant{
+				Base:  types.Base{Name: name},
+				Type:  typeRef(constant.Type()),
+				Value: constant.Val().ExactString(),
+			})
+		}
+	}
+}
+
+// declaration converts declaration of named type: structs and interfaces are expanded, other types are converted by underlying type.
+func (pkg *loadedPackage) declaration(typeName *gotypes.TypeName) types.Type {
+
+	if typeName.IsAlias() {
+		return typeRef(gotypes.Unalias(typeName.Type()))
+	}
+	named, ok := typeName.Type().(*gotypes.Named)
+	if !ok {
+		return typeRef(typeName.Type())
+	}
+	base := types.Base{Name: typeName.Name(), Docs: pkg.docs[typeName.Pos()], Pos: pkg.position(typeName.Pos())}
+	switch underlying := named.Underlying().(type) {
+	case *gotypes.Struct:
+		retType := pkg.structType(underlying)
+		retType.Base = base
+		for i := 0; i < named.TypeParams().Len(); i++ {
+			param := named.TypeParams().At(i)
+			retType.TypeParams = append(retType.TypeParams, types.Variable{Base: types.Base{Name: param.Obj().Name()}, Type: typeRef(param.Constraint())})
+		}
+		return retType
+	case *gotypes.Interface:
+		retType := interfaceRef(underlying)
+		retType.Interface.Base = base
+		return retType
+	default:
+		return typeRef(underlying)
+	}
+}
+
+func (pkg *loadedPackage) structType(structType *gotypes.Struct) (retType types.Struct) {
+
+	for i := 0; i < structType.NumFields(); i++ {
+		field := structType.Field(i)
+		structField := types.StructField{
+			Variable: types.Variable{Base: types.Base{Name: field.Name(), Docs: pkg.docs[field.Pos()], Pos: pkg.position(field.Pos())}, Type: pkg.fieldType(field.Type())},
+			RawTags:  "`" + structType.Tag(i) + "`",
+		}
+		if field.Embedded() {
+			structField.Name = ""
+		}
+		if tags, err := structtag.Parse(structType.Tag(i)); err == nil && tags.Len() != 0 {
+			structField.Tags = make(map[string][]string)
+			for _, tag := range tags.Tags() {
+				structField.Tags[tag.Key] = append([]string{tag.Name}, tag.Options...)
+			}
+		}
+		retType.Fields = append(retType.Fields, structField)
+	}
+	return
+}
+
+// fieldType converts type of field, anonymous structs keep comments of their fields.
+func (pkg *loadedPackage) fieldType(fieldType gotypes.Type) types.Type {
+
+	if structType, ok := fieldType.(*gotypes.Struct); ok {
+		return pkg.structType(structType)
+	}
+	return typeRef(fieldType)
+}
+
+// typeRef converts reference to type: named types are qualified by import of their package.
+func typeRef(goType gotypes.Type) types.Type {
+
+	switch t := gotypes.Unalias(goType).(type) {
+	case *gotypes.Basic:
+		return types.TName{TypeName: t.Name()}
+	case *gotypes.Named:
+		obj := t.Obj()
+		if obj.Pkg() == nil {
+			return types.TName{TypeName: obj.Name()}
+		}
+		var ref types.Type = types.TImport{Import: &types.Import{Base: types.Base{Name: obj.Pkg().Name()}, Package: obj.Pkg().Path()}, Next: types.TName{TypeName: obj.Name()}}
+		if t.TypeArgs().Len() == 0 {
+			return ref
+		}
+		instance := types.TInstance{Next: ref}
+		for i := 0; i < t.TypeArgs().Len(); i++ {
+			instance.Args = append(instance.Args, typeRef(t.TypeArgs().At(i)))
+		}
+		return instance
+	case *gotypes.TypeParam:
+		return types.TName{TypeName: t.Obj().Name()}
+	case *gotypes.Pointer:
+		next := typeRef(t.Elem())
+		if pointer, ok := next.(types.TPointer); ok {
+			return types.TPointer{Next: pointer.Next, NumberOfPointers: pointer.NumberOfPointers + 1}
+		}
+		return types.TPointer{Next: next, NumberOfPointers: 1}
+	case *gotypes.Slice:
+		return types.TArray{Next: typeRef(t.Elem()), IsSlice: true}
+	case *gotypes.Array:
+		return types.TArray{Next: typeRef(t.Elem()), ArrayLen: int(t.Len())}
+	case *gotypes.Map:
+		return types.TMap{Key: typeRef(t.Key()), Value: typeRef(t.Elem())}
+	case *gotypes.Chan:
+		direction := types.ChanDirAny
+		switch t.Dir() {
+		case gotypes.SendOnly:
+			direction = types.ChanDirSend
+		case gotypes.RecvOnly:
+			direction = types.ChanDirRecv
+		}
+		return types.TChan{Next: typeRef(t.Elem()), Direction: direction}
+	case *gotypes.Struct:
+		var pkg loadedPackage
+		return pkg.structType(t)
+	case *gotypes.Interface:
+		return interfaceRef(t)
+	case *gotypes.Signature:
+		return signature(t)
+	default:
+		return types.TName{TypeName: goType.String()}
+	}
+}
+
+// interfaceRef converts interface with its full method set.
+func interfaceRef(iface *gotypes.Interface) types.TInterface {
+
+	retType := types.TInterface{Interface: &types.Interface{}}
+	for i := 0; i < iface.NumMethods(); i++ {
+		method := iface.Method(i)
+		fn := signature(method.Type().(*gotypes.Signature))
+		fn.Name = method.Name()
+		retType.Interface.Methods = append(retType.Interface.Methods, &fn)
+	}
+	return retType
+}
+
+func signature(sig *gotypes.Signature) (fn types.Function) {
+
+	variables := func(tuple *gotypes.Tuple, variadic bool) (vars []types.Variable) {
+		for i := 0; i < tuple.Len(); i++ {
+			varType := typeRef(tuple.At(i).Type())
+			if slice, ok := varType.(types.TArray); ok && variadic && i == tuple.Len()-1 {
+				varType = types.TEllipsis{Next: slice.Next}
+			}
+			vars = append(vars, types.Variable{Base: types.Base{Name: tuple.At(i).Name()}, Type: varType})
+		}
+		return
+	}
+	fn.Args = variables(sig.Params(), sig.Variadic())
+	fn.Results = variables(sig.Results(), false)
+	return
+}
+
+// embeddedPos returns position of type name of embedded field, which is position of field in 'go/types'.
+func embeddedPos(expr ast.Expr) token.Pos {
+
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedPos(e.X)
+	case *ast.SelectorExpr:
+		return e.Sel.Pos()
+	case *ast.IndexExpr:
+		return embeddedPos(e.X)
+	case *ast.IndexListExpr:
+		return embeddedPos(e.X)
+	default:
+		return expr.Pos()
+	}
+}
+
+func comments(groups ...*ast.CommentGroup) (docs []string) {
+
+	for _, group := range groups {
+		if group == nil {
+			continue
+		}
+		for _, comment := range group.List {
+			docs = append(docs, comment.Text)
+		}
+	}
+	return
+}
@@ -0,0 +1,114 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/seniorGolang/tg/v2/pkg/astra/types"
+)
+
+func TestPackagesLoader(t *testing.T) {
+
+	files := map[string]string{
+		"go.mod": "module example.com/api\n\ngo 1.22\n",
+		"dto/a_account.go": `//go:build legacy
+
+package dto
+
+type Account struct {
+	Legacy int
+}
+`,
+		"dto/account.go": `//go:build !legacy
+
+package dto
+
+// Account of user.
+type Account struct {
+	// ID of account.
+	ID string ` + "`json:\"id\"`" + `
+	Base
+}
+
+type Base struct {
+	Created int64 ` + "`json:\"created\"`" + `
+}
+
+type Person = Account
+
+type Status string
+
+const StatusActive Status = "active"
+`,
+		"dto/event.go": `package dto
+
+import (
+	"time"
+
+	"example.com/api/kind"
+)
+
+type Event struct {
+	At   time.Time
+	Kind kind.Kind
+}
+`,
+		"kind/kind.go":     "package kind\n\ntype Kind int\n",
+		"broken/broken.go": "package broken\n\ntype Broken struct {\n\tField Missing\n}\n",
+	}
+	dir := writeProject(t, files)
+	tr := &Transport{log: logrus.New(), svcDir: dir, state: &renderState{}}
+	if err := tr.SetLoader(LoaderPackages); err != nil {
+		t.Fatal(err)
+	}
+
+	account, ok := tr.searchType("example.com/api/dto", "Account").(types.Struct)
+	if !ok {
+		t.Fatalf("Account is not a struct: %#v", tr.searchType("example.com/api/dto", "Account"))
+	}
+	if len(account.Fields) != 2 || account.Fields[0].Name != "ID" || account.Fields[0].Tags["json"][0] != "id" {
+		t.Fatalf("unexpected fields of Account: %v", account.Fields)
+	}
+	if account.Fields[1].Name != "" || account.Fields[1].Type.String() != "dto.Base" {
+		t.Fatalf("embedded field is not kept: %v", account.Fields[1])
+	}
+	if docText(account.Docs) != "Account of user." || docText(account.Fields[0].Docs) != "ID of account." {
+		t.Fatalf("docs are lost: %v %v", account.Docs, account.Fields[0].Docs)
+	}
+	if alias := tr.searchType("example.com/api/dto", "Person"); alias == nil || alias.String() != "dto.Account" {
+		t.Fatalf("alias is not resolved: %v", alias)
+	}
+	_, constants := tr.searchTypeWithConstants("example.com/api/dto", "Status")
+	if len(constants) != 1 || constants[0].Name != "StatusActive" || constants[0].Value != `"active"` {
+		t.Fatalf("unexpected constants: %v", constants)
+	}
+	event, ok := tr.searchType("example.com/api/dto", "Event").(types.Struct)
+	if !ok || len(event.Fields) != 2 || event.Fields[0].Type.String() != "time.Time" || event.Fields[1].Type.String() != "kind.Kind" {
+		t.Fatalf("types of imported packages are not resolved: %#v", tr.searchType("example.com/api/dto", "Event"))
+	}
+	if _, found := tr.state.loader.packages[dir+":example.com/api/kind"]; !found {
+		t.Fatal("dependencies of loaded package are not kept")
+	}
+	if _, _, found := tr.loadType("example.com/api/dto", "Missing"); found {
+		t.Fatal("missing type is found")
+	}
+	if len(tr.Diagnostics()) != 0 {
+		t.Fatalf("unexpected diagnostics: %v", tr.Diagnostics())
+	}
+	for range 2 {
+		if _, _, found := tr.loadType("example.com/api/broken", "Broken"); found {
+			t.Fatal("type of broken package is found")
+		}
+	}
+	if rules := checkRules(tr.Diagnostics()); len(tr.Diagnostics()) != 1 || rules[ruleLoadPackage] != 1 {
+		t.Fatalf("broken package is not reported once: %v", tr.Diagnostics())
+	}
+	if message := tr.Diagnostics()[0].Message; !strings.Contains(message, "undefined: Missing") {
+		t.Fatalf("errors of package are not reported: %s", message)
+	}
+	if _, _, found := (&Transport{state: &renderState{}}).loadType("example.com/api/dto", "Account"); found {
+		t.Fatal("loader of one transport is used by another")
+	}
+}
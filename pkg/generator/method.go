@@ -38,12 +38,18 @@ type method struct {
 }
 
 func newMethod(log logrus.FieldLogger, svc *service, fn *types.Function) (m *method) {
+	return newInheritedMethod(log, svc, fn, nil)
+}
+
+// newInheritedMethod creates method of embedded interface, annotations of embedded interfaces
+// override annotations of method and are overridden by annotations of service.
+func newInheritedMethod(log logrus.FieldLogger, svc *service, fn *types.Function, ifaceTags tags.DocTags) (m *method) {
 
 	m = &method{
 		Function: fn,
 		log:      log,
 		svc:      svc,
-		tags:     tags.ParseTags(fn.Docs).Merge(svc.tags),
+		tags:     tags.ParseTags(fn.Docs).Merge(ifaceTags).Merge(svc.tags),
 	}
 	m.argFields = m.varsToFields(m.argsWithoutContext(), m.tags, m.argCookieMap(), m.varHeaderMap())
 	m.resultFields = m.varsToFields(m.resultsWithoutError(), m.tags, m.retCookieMap(), m.varHeaderMap())
@@ -140,6 +146,11 @@ func (m *method) isJsonRPC() bool {
 	return m.svc.tags.Contains(tagServerJsonRPC) && !m.tags.Contains(tagMethodHTTP)
 }
 
+// isNotify reports whether clients have helpers, which call method as notification.
+func (m *method) isNotify() bool {
+	return m.isJsonRPC() && m.tags.Contains(tagNotify)
+}
+
 func (m *method) handlerQual() (pkgPath, handler string) {
 
 	if !m.tags.Contains(tagHandler) {
@@ -210,7 +221,7 @@ func (m *method) urlArgs(errStatement func(arg, header string) *Statement) (g *S
 
 	return m.argFromString("urlParam", m.argPathMap(),
 		func(srcName string) Code {
-			return Id(_ctx_).Dot("Params").Call(Lit(srcName))
+			return m.svc.tr.pathParam(_ctx_, Lit(srcName))
 		},
 		errStatement,
 	)
@@ -220,7 +231,7 @@ func (m *method) urlParams(errStatement func(arg, header string) *Statement) (bl
 
 	return m.argFromString("urlParam", m.argParamMap(),
 		func(srcName string) Code {
-			return Id(_ctx_).Dot("Query").Call(Lit(srcName))
+			return m.svc.tr.queryParam(_ctx_, Lit(srcName))
 		},
 		errStatement,
 	)
@@ -231,7 +242,7 @@ func (m *method) httpArgHeaders(ftx string, errStatement func(arg, header string
 	return m.argFromString("header", m.varHeaderMap(),
 		func(srcName string) Code {
 			srcName = strings.TrimPrefix(srcName, "!")
-			return String().Call(Id(ftx).Dot("Request").Call().Dot("Header").Dot("Peek").Call(Lit(srcName)))
+			return m.svc.tr.requestHeader(ftx, Lit(srcName))
 		},
 		errStatement,
 	)
@@ -242,7 +253,7 @@ func (m *method) httpCookies(ftx string, errStatement func(arg, header string) *
 	return m.argFromString("cookie", m.varCookieMap(),
 		func(srcName string) Code {
 			srcName = strings.TrimPrefix(srcName, "!")
-			return Id(ftx).Dot("Cookies").Call(Lit(srcName))
+			return m.svc.tr.requestCookie(ftx, Lit(srcName))
 		},
 		errStatement,
 	)
@@ -268,7 +279,7 @@ func (m *method) argFromString(typeName string, varMap map[string]string, strCod
 			argType := vArg.Type
 			argTypeName := argType.String()
 			if len(argTokens) > 1 {
-				argType = nestedType(vArg.Type, "", argTokens)
+				argType = m.svc.tr.nestedType(vArg.Type, "", argTokens)
 				argTypeName = argType.String()
 			}
 			switch t := argType.(type) { // nolint:gocritic
@@ -311,7 +322,7 @@ func (m *method) httpRetHeaders(ftx string) (block *Statement) {
 				}
 				continue
 			}
-			block.Id(ftx).Dot("Set").Call(Lit(header), Qual(packageFmt, "Sprint").Call(Id("response").Dot(utils.ToCamel(ret))))
+			block.Add(m.svc.tr.setResponseHeader(ftx, Lit(header), Qual(packageFmt, "Sprint").Call(Id("response").Dot(utils.ToCamel(ret)))))
 		}
 	}
 	return block
@@ -0,0 +1,280 @@
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing/fstest"
+)
+
+// Output is a destination of generated files: disk, in-memory MemFS or custom storage.
+type Output interface {
+	MkdirAll(dir string, perm fs.FileMode) error
+	WriteFile(filePath string, data []byte, perm fs.FileMode) error
+	Remove(filePath string) error
+}
+
+type diskOutput struct{}
+
+func (diskOutput) MkdirAll(dir string, perm fs.FileMode) error {
+	return os.MkdirAll(dir, perm)
+}
+
+func (diskOutput) WriteFile(filePath string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(filePath, data, perm)
+}
+
+func (diskOutput) Remove(filePath string) error {
+	return os.Remove(filePath)
+}
+
+// memOutput keeps generated files in memory. Paths are the same as they would be on disk.
+type memOutput struct {
+	files   map[string][]byte
+	perms   map[string]fs.FileMode
+	removed map[string]bool
+}
+
+func newMemOutput() *memOutput {
+	return &memOutput{
+		files:   make(map[string][]byte),
+		perms:   make(map[string]fs.FileMode),
+		removed: make(map[string]bool),
+	}
+}
+
+func (out *memOutput) MkdirAll(string, fs.FileMode) error {
+	return nil
+}
+
+func (out *memOutput) WriteFile(filePath string, data []byte, perm fs.FileMode) error {
+
+	filePath = absPath(filePath)
+	out.files[filePath] = bytes.Clone(data)
+	out.perms[filePath] = perm
+	delete(out.removed, filePath)
+	return nil
+}
+
+func (out *memOutput) Remove(filePath string) error {
+
+	filePath = absPath(filePath)
+	if _, found := out.files[filePath]; found {
+		delete(out.files, filePath)
+		return nil
+	}
+	if _, err := os.Stat(filePath); err != nil {
+		return err
+	}
+	out.removed[filePath] = true
+	return nil
+}
+
+// MemFS is an Output, which keeps generated files in memory. It implements fs.FS for reading of result,
+// names are slash-separated paths relative to root directory.
+type MemFS struct {
+	root  string
+	files map[string][]byte
+}
+
+// NewMemFS returns empty MemFS. Generated files must be placed inside root, empty root is a current directory.
+func NewMemFS(root string) *MemFS {
+	return &MemFS{root: absPath(root), files: make(map[string][]byte)}
+}
+
+func (m *MemFS) MkdirAll(string, fs.FileMode) error {
+	return nil
+}
+
+func (m *MemFS) WriteFile(filePath string, data []byte, perm fs.FileMode) (err error) {
+
+	var name string
+	if name, err = m.name(filePath); err != nil {
+		return &fs.PathError{Op: "write", Path: filePath, Err: err}
+	}
+	m.files[name] = bytes.Clone(data)
+	return
+}
+
+// Remove removes file from memory. Files, which were not generated, are left as is.
+func (m *MemFS) Remove(filePath string) error {
+
+	if name, err := m.name(filePath); err == nil {
+		delete(m.files, name)
+	}
+	return nil
+}
+
+func (m *MemFS) Open(name string) (fs.File, error) {
+
+	mapFS := make(fstest.MapFS, len(m.files))
+	for fileName, data := range m.files {
+		mapFS[fileName] = &fstest.MapFile{Data: data, Mode: 0644}
+	}
+	return mapFS.Open(name)
+}
+
+// Files returns names of generated files in sorted order.
+func (m *MemFS) Files() []string {
+	return sortedKeys(m.files)
+}
+
+func (m *MemFS) name(filePath string) (name string, err error) {
+
+	if name, err = filepath.Rel(m.root, absPath(filePath)); err != nil {
+		return
+	}
+	if name = filepath.ToSlash(name); !fs.ValidPath(name) {
+		return "", fmt.Errorf("file is outside of %s", m.root)
+	}
+	return
+}
+
+// FileDiff is a difference between generated file and file on disk.
+type FileDiff struct {
+	Path string
+	Diff string
+}
+
+// changed returns sorted paths of generated files, which differ from files on disk, and of removed files.
+func (out *memOutput) changed() (paths []string) {
+
+	for filePath, data := range out.files {
+		if current, _ := os.ReadFile(filePath); !bytes.Equal(current, data) {
+			paths = append(paths, filePath)
+		}
+	}
+	for filePath := range out.removed {
+		paths = append(paths, filePath)
+	}
+	sort.Strings(paths)
+	return
+}
+
+// diff compares generated files with files on disk.
+func (out *memOutput) diff() (diffs []FileDiff) {
+
+	for _, filePath := range out.changed() {
+		current, _ := os.ReadFile(filePath)
+		diffs = append(diffs, FileDiff{Path: filePath, Diff: unifiedDiff(relPath(filePath), current, out.files[filePath])})
+	}
+	return
+}
+
+func (tr *Transport) output() Output {
+
+	if tr.state == nil || tr.state.out == nil {
+		return diskOutput{}
+	}
+	return tr.state.out
+}
+
+// apply writes changed files to output and removes files, which are removed by render.
+func (out *memOutput) apply(target Output) (changed []string, err error) {
+
+	for _, filePath := range out.changed() {
+		if out.removed[filePath] {
+			if err = target.Remove(filePath); err != nil {
+				return
+			}
+			changed = append(changed, filePath)
+			continue
+		}
+		if err = target.MkdirAll(filepath.Dir(filePath), 0777); err != nil {
+			return
+		}
+		if err = target.WriteFile(filePath, out.files[filePath], out.perms[filePath]); err != nil {
+			return
+		}
+		changed = append(changed, filePath)
+	}
+	return
+}
+
+func (tr *Transport) renderInMemory(render func() error) (out *memOutput, err error) {
+
+	out = newMemOutput()
+	prevOut := tr.state.out
+	tr.state.out = out
+	defer func() { tr.state.out = prevOut }()
+	err = render()
+	return
+}
+
+// Verify runs render with output in memory and returns differences between generated files and files on disk.
+// Files on disk are not changed.
+func (tr *Transport) Verify(render func() error) (diffs []FileDiff, err error) {
+
+	var out *memOutput
+	tr.state.verify = true
+	defer func() { tr.state.verify = false }()
+	if out, err = tr.renderInMemory(render); err != nil {
+		return
+	}
+	return out.diff(), nil
+}
+
+// RenderChanged runs render with output in memory and writes to output only files, which differ from files on disk.
+// Paths of written and removed files are returned, manifest of incremental generation is written, but it is not returned:
+// it changes with sources, when generated files are the same. Nothing is written, when render fails.
+func (tr *Transport) RenderChanged(render func() error) (changed []string, err error) {
+
+	var out *memOutput
+	if out, err = tr.renderInMemory(render); err != nil {
+		return
+	}
+	var applied []string
+	if applied, err = out.apply(tr.output()); err != nil {
+		return
+	}
+	for _, filePath := range applied {
+		if filepath.Base(filePath) != manifestName {
+			changed = append(changed, filePath)
+		}
+	}
+	return
+}
+
+// SetOutput redirects generated files from disk to out.
+func (tr *Transport) SetOutput(out Output) {
+	tr.state.out = out
+}
+
+func (tr *Transport) onDisk() bool {
+
+	_, onDisk := tr.output().(diskOutput)
+	return onDisk
+}
+
+func absPath(filePath string) string {
+
+	if abs, err := filepath.Abs(filePath); err == nil {
+		return abs
+	}
+	return filePath
+}
+
+func relPath(filePath string) string {
+
+	if wd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(wd, filePath); err == nil && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+	}
+	return filePath
+}
+
+func splitLines(data []byte) (lines []string) {
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return
+}
@@ -0,0 +1,247 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+)
+
+// Artifacts of generated code, which can be replaced by template from 'tg.yaml'.
+const (
+	OverrideErrorResponse = "error-response"
+	OverrideLogger        = "logger"
+	OverrideHealth        = "health"
+	OverrideMetrics       = "metrics"
+	OverrideClient        = "client"
+	OverrideHTTPClient    = "http-client"
+)
+
+type overrideDecl struct {
+	recv      string
+	name      string
+	signature string
+	service   bool
+}
+
+// overrideDecls describes declaration, which template of artifact must define.
+// For artifacts of service '%s' is replaced by name of service.
+var overrideDecls = map[string]overrideDecl{
+	OverrideErrorResponse: {name: "sendError", signature: "func(ctx *fiber.Ctx, err error) error"},
+	OverrideLogger:        {recv: "logger%s", name: "logFields", signature: "func(ctx context.Context, ev *zerolog.Event, fields map[string]interface{}, begin time.Time)", service: true},
+	OverrideHealth:        {recv: "*Server", name: "ServeHealth", signature: "func(address string, response interface{})"},
+	OverrideMetrics:       {recv: "*Server", name: "ServeMetrics", signature: "func(log zerolog.Logger, path string, address string)"},
+	OverrideClient:        {name: "New", signature: "func(endpoint string, opts ...Option) *ClientJsonRPC"},
+	OverrideHTTPClient:    {name: "NewClient%s", signature: "func(endpoint string, opts ...httpclient.Option) *Client%s", service: true},
+}
+
+// backendOverrideDecls replaces declarations of overrideDecls, which depend on backend.
+var backendOverrideDecls = map[string]map[string]overrideDecl{
+	BackendFiberV3: {
+		OverrideErrorResponse: {name: "sendError", signature: "func(ctx fiber.Ctx, err error) error"},
+	},
+	BackendNetHTTP: {
+		OverrideErrorResponse: {name: "sendError", signature: "func(w http.ResponseWriter, r *http.Request, err error)"},
+	},
+}
+
+// OverrideData is passed to template of artifact. Service is set for artifacts of service.
+type OverrideData struct {
+	Service  *ContractService
+	Contract *Contract
+}
+
+type overrideSnippet struct {
+	imports []importSpec
+	code    string
+}
+
+type importSpec struct {
+	name string
+	path string
+}
+
+var reAny = regexp.MustCompile(`\bany\b`)
+
+// SetOverrides replaces generated artifacts by templates. Key is a name of artifact, value is a path of template file.
+// Templates are executed at once, so any error of template is returned before generation.
+func (tr *Transport) SetOverrides(overrides map[string]string) (err error) {
+
+	tr.state.overrides = make(map[string]overrideSnippet)
+	if len(overrides) == 0 {
+		return
+	}
+	contract := tr.Contract()
+	for _, name := range sortedKeys(overrides) {
+		decl, found := overrideDecls[name]
+		if !found {
+			return fmt.Errorf("override %s: unknown artifact, expected one of: %s", name, strings.Join(sortedKeys(overrideDecls), ", "))
+		}
+		if backendDecl, ok := backendOverrideDecls[tr.backend()][name]; ok {
+			decl = backendDecl
+		}
+		var tmpl *template.Template
+		if tmpl, err = loadOverride(name, overrides[name]); err != nil {
+			return
+		}
+		if !decl.service {
+			if err = tr.executeOverride(tmpl, name, decl, OverrideData{Contract: contract}); err != nil {
+				return
+			}
+			continue
+		}
+		for i := range contract.Services {
+			svcDecl := decl
+			svcDecl.recv = strings.ReplaceAll(decl.recv, "%s", contract.Services[i].Name)
+			svcDecl.name = strings.ReplaceAll(decl.name, "%s", contract.Services[i].Name)
+			svcDecl.signature = strings.ReplaceAll(decl.signature, "%s", contract.Services[i].Name)
+			if err = tr.executeOverride(tmpl, name, svcDecl, OverrideData{Service: &contract.Services[i], Contract: contract}); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+func loadOverride(name, filePath string) (tmpl *template.Template, err error) {
+
+	var text []byte
+	if text, err = os.ReadFile(filePath); err != nil {
+		return nil, fmt.Errorf("override %s: %w", name, err)
+	}
+	if tmpl, err = template.New(filePath).Option("missingkey=error").Parse(string(text)); err != nil {
+		return nil, fmt.Errorf("override %s: %w", name, err)
+	}
+	return
+}
+
+func (tr *Transport) executeOverride(tmpl *template.Template, name string, decl overrideDecl, data OverrideData) (err error) {
+
+	key := name
+	if data.Service != nil {
+		key = name + ":" + data.Service.Name
+	}
+	var code bytes.Buffer
+	if err = tmpl.Execute(&code, data); err != nil {
+		return fmt.Errorf("override %s: %w", key, err)
+	}
+	var snippet overrideSnippet
+	if snippet, err = parseOverride(tmpl.Name(), code.String(), decl); err != nil {
+		return fmt.Errorf("override %s: %w", key, err)
+	}
+	tr.state.overrides[key] = snippet
+	return
+}
+
+// parseOverride checks, that code is a valid list of Go declarations with expected declaration of artifact.
+// Package clause is added to the first line, so positions of errors match lines of template result.
+func parseOverride(fileName, code string, decl overrideDecl) (snippet overrideSnippet, err error) {
+
+	const header = "package override;"
+	fileSet := token.NewFileSet()
+	var file *ast.File
+	if file, err = parser.ParseFile(fileSet, fileName, header+code, parser.ParseComments); err != nil {
+		return snippet, fmt.Errorf("template result is not valid Go code: %w", err)
+	}
+	var found *ast.FuncDecl
+	for _, d := range file.Decls {
+		if fn, ok := d.(*ast.FuncDecl); ok && fn.Name.Name == decl.name && recvType(fn) == decl.recv {
+			found = fn
+		}
+	}
+	expected := decl.name
+	if decl.recv != "" {
+		expected = fmt.Sprintf("(%s) %s", decl.recv, decl.name)
+	}
+	if found == nil {
+		return snippet, fmt.Errorf("template must declare func %s%s", expected, strings.TrimPrefix(decl.signature, "func"))
+	}
+	if signature := funcSignature(found.Type); signature != normalSignature(decl.signature) {
+		return snippet, fmt.Errorf("func %s has signature %s, expected %s", expected, signature, normalSignature(decl.signature))
+	}
+	start := len(header)
+	for _, spec := range file.Imports {
+		var importPath string
+		if importPath, err = strconv.Unquote(spec.Path.Value); err != nil {
+			return
+		}
+		var importName string
+		if spec.Name != nil {
+			importName = spec.Name.Name
+		}
+		snippet.imports = append(snippet.imports, importSpec{name: importName, path: importPath})
+	}
+	for _, d := range file.Decls {
+		if gen, ok := d.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+			start = fileSet.Position(gen.End()).Offset
+		}
+	}
+	snippet.code = strings.TrimSpace((header + code)[start:])
+	return
+}
+
+func recvType(fn *ast.FuncDecl) string {
+
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return ""
+	}
+	return types.ExprString(fn.Recv.List[0].Type)
+}
+
+// funcSignature returns signature without names of parameters.
+func funcSignature(fn *ast.FuncType) string {
+
+	list := func(fields *ast.FieldList) (items []string) {
+		if fields == nil {
+			return
+		}
+		for _, field := range fields.List {
+			for range max(len(field.Names), 1) {
+				items = append(items, reAny.ReplaceAllString(types.ExprString(field.Type), "interface{}"))
+			}
+		}
+		return
+	}
+	signature := "func(" + strings.Join(list(fn.Params), ", ") + ")"
+	switch results := list(fn.Results); len(results) {
+	case 0:
+	case 1:
+		signature += " " + results[0]
+	default:
+		signature += " (" + strings.Join(results, ", ") + ")"
+	}
+	return signature
+}
+
+func normalSignature(signature string) string {
+
+	expr, err := parser.ParseExpr(signature)
+	if err != nil {
+		panic(err)
+	}
+	return funcSignature(expr.(*ast.FuncType))
+}
+
+// overridable returns code of artifact: declarations from template or generated code, when artifact is not overridden.
+func (tr *Transport) overridable(src *goFile, name string, svc *service, generated Code) Code {
+
+	key := name
+	if svc != nil {
+		key = name + ":" + svc.Name
+	}
+	snippet, found := tr.state.overrides[key]
+	if !found {
+		return generated
+	}
+	src.imports = append(src.imports, snippet.imports...)
+	return Op(snippet.code)
+}
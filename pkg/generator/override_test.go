@@ -0,0 +1,37 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseOverride(t *testing.T) {
+
+	decl := overrideDecls[OverrideHealth]
+	code := `import "net/http"
+
+// ServeHealth serves readiness probe.
+func (srv *Server) ServeHealth(address string, response any) {
+	_ = http.StatusOK
+}
+`
+	snippet, err := parseOverride("health.tmpl", code, decl)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(snippet.imports) != 1 || snippet.imports[0].path != "net/http" {
+		t.Fatalf("unexpected imports: %v", snippet.imports)
+	}
+	if !strings.HasPrefix(snippet.code, "// ServeHealth serves readiness probe.") {
+		t.Fatalf("unexpected code:\n%s", snippet.code)
+	}
+	if _, err = parseOverride("health.tmpl", "func (srv *Server) ServeHealth(address string) {}", decl); err == nil || !strings.Contains(err.Error(), "expected func(string, interface{})") {
+		t.Fatalf("signature mismatch is not detected: %v", err)
+	}
+	if _, err = parseOverride("health.tmpl", "func ServeHealth(address string, response any) {}", decl); err == nil || !strings.Contains(err.Error(), "must declare") {
+		t.Fatalf("missing declaration is not detected: %v", err)
+	}
+	if _, err = parseOverride("health.tmpl", "func (srv *Server) ServeHealth(", decl); err == nil || !strings.Contains(err.Error(), "health.tmpl:1:") {
+		t.Fatalf("syntax error is not reported with position: %v", err)
+	}
+}
@@ -4,12 +4,12 @@ import (
 	"embed"
 	"fmt"
 	"io/fs"
-	"os"
 	"path"
 	"path/filepath"
 	"strings"
 
 	"github.com/seniorGolang/tg/v2/pkg/mod"
+	"github.com/seniorGolang/tg/v2/pkg/utils"
 )
 
 //go:embed ts/*
@@ -18,7 +18,7 @@ var tsFiles embed.FS
 //go:embed pkg/*
 var pkgFiles embed.FS
 
-func pkgCopyTo(pkg, dst string) (err error) {
+func pkgCopyTo(out Output, pkg, dst string) (err error) {
 
 	pkgPath := path.Join("pkg", pkg)
 	var entries []fs.DirEntry
@@ -30,18 +30,18 @@ func pkgCopyTo(pkg, dst string) (err error) {
 		if fileContent, err = pkgFiles.ReadFile(fmt.Sprintf("%s/%s", pkgPath, entry.Name())); err != nil {
 			return err
 		}
-		if err = os.MkdirAll(path.Join(dst, pkg), 0700); err != nil {
+		if err = out.MkdirAll(path.Join(dst, path.Base(pkg)), 0700); err != nil {
 			return err
 		}
-		filename := path.Join(dst, pkg, entry.Name())
-		if err = os.WriteFile(filename, fileContent, 0600); err != nil {
+		filename := path.Join(dst, path.Base(pkg), entry.Name())
+		if err = out.WriteFile(filename, fileContent, 0600); err != nil {
 			return err
 		}
 	}
 	return
 }
 
-func tsCopyTo(pkg, dst string) (err error) {
+func tsCopyTo(out Output, pkg, dst string) (err error) {
 
 	pkgPath := path.Join("ts", pkg)
 	var entries []fs.DirEntry
@@ -53,23 +53,29 @@ func tsCopyTo(pkg, dst string) (err error) {
 		if fileContent, err = tsFiles.ReadFile(fmt.Sprintf("%s/%s", pkgPath, entry.Name())); err != nil {
 			return err
 		}
-		if err = os.MkdirAll(path.Join(dst, pkg), 0700); err != nil {
+		if err = out.MkdirAll(path.Join(dst, path.Base(pkg)), 0700); err != nil {
 			return err
 		}
-		filename := path.Join(dst, pkg, entry.Name())
-		if err = os.WriteFile(filename, fileContent, 0600); err != nil {
+		filename := path.Join(dst, path.Base(pkg), entry.Name())
+		if err = out.WriteFile(filename, fileContent, 0600); err != nil {
 			return err
 		}
 	}
 	return
 }
 
+// pkgPath returns import path of directory. Directory may belong to another module of workspace,
+// so module path is taken from the nearest 'go.mod' of directory.
 func (tr *Transport) pkgPath(dir string) (pkgPath string) {
 
 	var pkgDir string
+	modulePath := tr.module.Module.Mod.String()
 	dirAbs, _ := filepath.Abs(dir)
 	if modPath, err := mod.GoModPath(dir); err == nil {
 		pkgDir = filepath.ToSlash(strings.TrimPrefix(dirAbs, filepath.Dir(modPath)))
+		if dirModule := utils.GetModulePath(modPath); dirModule != "" {
+			modulePath = dirModule
+		}
 	}
-	return tr.module.Module.Mod.String() + pkgDir
+	return modulePath + pkgDir
 }
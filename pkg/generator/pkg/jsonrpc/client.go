@@ -19,8 +19,10 @@ type ClientRPC struct {
 	options    options
 	endpoint   string
 	httpClient *http.Client
+	ws         *wsTransport
 }
 
+// NewClient returns client of endpoint, requests to endpoint with scheme 'ws' or 'wss' are sent by WebSocket.
 func NewClient(endpoint string, opts ...Option) (client *ClientRPC) {
 
 	client = &ClientRPC{
@@ -36,5 +38,17 @@ func NewClient(endpoint string, opts ...Option) (client *ClientRPC) {
 			TLSClientConfig: client.options.tlsConfig,
 		}
 	}
+	if isWebSocket(endpoint) {
+		client.ws = newWSTransport(endpoint, &client.options)
+	}
 	return client
 }
+
+// Close closes WebSocket connection of client, next request opens it again.
+func (client *ClientRPC) Close() (err error) {
+
+	if client.ws != nil {
+		return client.ws.close()
+	}
+	return
+}
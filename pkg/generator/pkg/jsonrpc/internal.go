@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/rs/zerolog/log"
@@ -42,6 +43,10 @@ func (client *ClientRPC) newRequest(ctx context.Context, reqBody interface{}) (r
 
 func (client *ClientRPC) doCall(ctx context.Context, request *RequestRPC) (rpcResponse *ResponseRPC, err error) {
 
+	if client.ws != nil {
+		return client.doCallWS(ctx, request)
+	}
+
 	var httpRequest *http.Request
 	if httpRequest, err = client.newRequest(ctx, request); err != nil {
 		err = fmt.Errorf("rpc call %v() on %v: %v", request.Method, client.endpoint, err.Error())
@@ -113,6 +118,53 @@ func (client *ClientRPC) doCall(ctx context.Context, request *RequestRPC) (rpcRe
 	return
 }
 
+func (client *ClientRPC) doNotify(ctx context.Context, request *RequestRPC) (err error) {
+
+	if client.ws != nil {
+		return client.doNotifyWS(ctx, request)
+	}
+
+	var httpRequest *http.Request
+	if httpRequest, err = client.newRequest(ctx, request); err != nil {
+		err = fmt.Errorf("rpc notify %v() on %v: %v", request.Method, client.endpoint, err.Error())
+		return
+	}
+	if client.options.before != nil {
+		ctx = client.options.before(ctx, httpRequest)
+	}
+	if client.options.logRequests {
+		if cmd, cmdErr := toCurl(httpRequest); cmdErr == nil {
+			log.Ctx(ctx).Debug().Str("method", request.Method).Str("curl", cmd.String()).Msg("notify")
+		}
+	}
+	defer func() {
+		if err != nil && client.options.logOnError {
+			if cmd, cmdErr := toCurl(httpRequest); cmdErr == nil {
+				log.Ctx(ctx).Error().Str("method", request.Method).Str("curl", cmd.String()).Msg("notify")
+			}
+		}
+	}()
+	var httpResponse *http.Response
+	if httpResponse, err = client.httpClient.Do(httpRequest); err != nil {
+		err = fmt.Errorf("rpc notify %v() on %v: %v", request.Method, httpRequest.URL.String(), err.Error())
+		return
+	}
+	defer func() { _ = httpResponse.Body.Close() }()
+	_, _ = io.Copy(io.Discard, httpResponse.Body)
+	if client.options.after != nil {
+		if err = client.options.after(ctx, httpResponse); err != nil {
+			return
+		}
+	}
+	if httpResponse.StatusCode >= 400 {
+		return &HTTPError{
+			Code: httpResponse.StatusCode,
+			err:  fmt.Errorf("rpc notify %v() on %v status code: %v", request.Method, httpRequest.URL.String(), httpResponse.StatusCode),
+		}
+	}
+	return
+}
+
 func (client *ClientRPC) doBatchCall(ctx context.Context, rpcRequests []*RequestRPC) (rpcResponses ResponsesRPC, err error) {
 
 	defer func() {
@@ -131,6 +183,10 @@ func (client *ClientRPC) doBatchCall(ctx context.Context, rpcRequests []*Request
 			}
 		}
 	}()
+	if client.ws != nil {
+		rpcResponses, err = client.doBatchCallWS(ctx, rpcRequests)
+		return
+	}
 	var httpRequest *http.Request
 	if httpRequest, err = client.newRequest(ctx, rpcRequests); err != nil {
 		err = fmt.Errorf("rpc batch call on %v: %v", client.endpoint, err.Error())
@@ -162,6 +218,10 @@ func (client *ClientRPC) doBatchCall(ctx context.Context, rpcRequests []*Request
 			return
 		}
 	}
+	// server does not answer batch of notifications
+	if !RequestsRPC(rpcRequests).hasID() && httpResponse.StatusCode < 400 {
+		return
+	}
 	decoder := json.NewDecoder(httpResponse.Body)
 	if !client.options.allowUnknownFields {
 		decoder.DisallowUnknownFields()
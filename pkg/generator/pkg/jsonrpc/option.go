@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 	"net/http"
+	"time"
 )
 
 type options struct {
@@ -14,6 +15,7 @@ type options struct {
 	clientHTTP         *http.Client
 	headersFromCtx     []interface{}
 	customHeaders      map[string]string
+	responseTimeout    time.Duration
 	before             func(ctx context.Context, req *http.Request) context.Context
 	after              func(ctx context.Context, res *http.Response) error
 }
@@ -41,6 +43,8 @@ func AfterRequest(after func(ctx context.Context, res *http.Response) error) Opt
 	}
 }
 
+// HeaderFromCtx sends values of context by keys as headers. WebSocket connection sends headers by handshake only,
+// so values are taken from context of request, which opens connection.
 func HeaderFromCtx(headers ...any) Option {
 	return func(ops *options) {
 		ops.headersFromCtx = append(ops.headersFromCtx, headers...)
@@ -76,3 +80,11 @@ func LogOnError() Option {
 		ops.logOnError = true
 	}
 }
+
+// ResponseTimeout limits waiting for response by WebSocket (30 seconds by default), deadline of context is used,
+// when it is earlier.
+func ResponseTimeout(timeout time.Duration) Option {
+	return func(ops *options) {
+		ops.responseTimeout = timeout
+	}
+}
@@ -18,6 +18,11 @@ func (client *ClientRPC) Call(ctx context.Context, method string, params ...inte
 	return client.doCall(ctx, request)
 }
 
+// Notify calls method as notification: server executes it without response, so only error of delivery is returned.
+func (client *ClientRPC) Notify(ctx context.Context, method string, params ...interface{}) (err error) {
+	return client.doNotify(ctx, NewNotification(method, params...))
+}
+
 func (client *ClientRPC) CallRaw(ctx context.Context, request *RequestRPC) (response *ResponseRPC, err error) {
 	return client.doCall(ctx, request)
 }
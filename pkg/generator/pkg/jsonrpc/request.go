@@ -4,8 +4,9 @@ import (
 	"github.com/google/uuid"
 )
 
+// RequestRPC is notification, when ID is NilID: id is omitted and server does not answer it.
 type RequestRPC struct {
-	ID      ID          `json:"id"`
+	ID      ID          `json:"id,omitzero"`
 	Method  string      `json:"method"`
 	Params  interface{} `json:"params,omitempty"`
 	JSONRPC string      `json:"jsonrpc"`
@@ -34,3 +35,24 @@ func NewRequestWithID(id ID, method string, params ...interface{}) *RequestRPC {
 	}
 	return request
 }
+
+func NewNotification(method string, params ...interface{}) *RequestRPC {
+
+	request := &RequestRPC{
+		ID:      NilID,
+		Method:  method,
+		Params:  Params(params...),
+		JSONRPC: Version,
+	}
+	return request
+}
+
+func (requests RequestsRPC) hasID() bool {
+
+	for _, request := range requests {
+		if request.ID != NilID {
+			return true
+		}
+	}
+	return false
+}
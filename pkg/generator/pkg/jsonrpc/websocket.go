@@ -0,0 +1,327 @@
+package jsonrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rs/zerolog/log"
+)
+
+var errConnClosed = errors.New("websocket connection closed")
+
+// defaultResponseTimeout limits waiting for response by WebSocket, when ResponseTimeout is not set.
+const defaultResponseTimeout = 30 * time.Second
+
+// wsTransport sends requests by one WebSocket connection. Connection is dialed by first request
+// and dialed again by next request, when it is broken. Responses are matched to requests by id,
+// response of batch is matched by id of first request. Headers of context are sent by handshake only.
+type wsTransport struct {
+	endpoint string
+	options  *options
+	dialer   websocket.Dialer
+
+	mutex   sync.Mutex
+	conn    *websocket.Conn
+	pending map[ID]chan wsResponse
+
+	writeMutex sync.Mutex
+}
+
+func isWebSocket(endpoint string) bool {
+	return strings.HasPrefix(endpoint, "ws://") || strings.HasPrefix(endpoint, "wss://")
+}
+
+func newWSTransport(endpoint string, options *options) *wsTransport {
+
+	return &wsTransport{
+		endpoint: endpoint,
+		options:  options,
+		pending:  make(map[ID]chan wsResponse),
+		dialer: websocket.Dialer{
+			Proxy:            http.ProxyFromEnvironment,
+			TLSClientConfig:  options.tlsConfig,
+			HandshakeTimeout: websocket.DefaultDialer.HandshakeTimeout,
+		},
+	}
+}
+
+func (t *wsTransport) connect(ctx context.Context) (conn *websocket.Conn, err error) {
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.conn != nil {
+		return t.conn, nil
+	}
+	header := make(http.Header)
+	for k, v := range t.options.customHeaders {
+		header.Set(k, v)
+	}
+	for _, key := range t.options.headersFromCtx {
+		if value := ctx.Value(key); value != nil {
+			if k := toString(key); k != "" {
+				if v := toString(value); v != "" {
+					header.Set(k, v)
+				}
+			}
+		}
+	}
+	if conn, _, err = t.dialer.DialContext(ctx, t.endpoint, header); err != nil {
+		return
+	}
+	t.conn = conn
+	go t.read(conn)
+	return
+}
+
+func (t *wsTransport) read(conn *websocket.Conn) {
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			t.drop(conn)
+			return
+		}
+		id, found := responseID(message)
+		if !found {
+			// error without id cannot be matched to request (error of parsing or size of batch),
+			// so it fails all requests, which wait for responses
+			if rpcErr := responseError(message); rpcErr != nil {
+				t.fail(rpcErr)
+			}
+			continue
+		}
+		t.mutex.Lock()
+		waiter, found := t.pending[id]
+		delete(t.pending, id)
+		t.mutex.Unlock()
+		if found {
+			waiter <- wsResponse{message: message}
+		}
+	}
+}
+
+// fail completes requests, which wait for responses, with error.
+func (t *wsTransport) fail(err error) {
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	for id, waiter := range t.pending {
+		waiter <- wsResponse{err: err}
+		delete(t.pending, id)
+	}
+}
+
+// drop closes broken connection and fails requests, which wait for responses.
+func (t *wsTransport) drop(conn *websocket.Conn) {
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.conn != conn {
+		return
+	}
+	t.conn = nil
+	for id, waiter := range t.pending {
+		close(waiter)
+		delete(t.pending, id)
+	}
+	_ = conn.Close()
+}
+
+func (t *wsTransport) close() (err error) {
+
+	t.mutex.Lock()
+	conn := t.conn
+	t.mutex.Unlock()
+	if conn == nil {
+		return
+	}
+	t.writeMutex.Lock()
+	err = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	t.writeMutex.Unlock()
+	t.drop(conn)
+	return
+}
+
+// send writes request to connection without waiting for response.
+func (t *wsTransport) send(ctx context.Context, request any) (err error) {
+
+	var body []byte
+	if body, err = json.Marshal(request); err != nil {
+		return
+	}
+	var conn *websocket.Conn
+	if conn, err = t.connect(ctx); err != nil {
+		return
+	}
+	t.writeMutex.Lock()
+	err = conn.WriteMessage(websocket.TextMessage, body)
+	t.writeMutex.Unlock()
+	if err != nil {
+		t.drop(conn)
+	}
+	return
+}
+
+func (t *wsTransport) roundTrip(ctx context.Context, id ID, request any) (message []byte, err error) {
+
+	timeout := t.options.responseTimeout
+	if timeout <= 0 {
+		timeout = defaultResponseTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	waiter := make(chan wsResponse, 1)
+	t.mutex.Lock()
+	t.pending[id] = waiter
+	t.mutex.Unlock()
+	defer func() {
+		if err != nil {
+			t.mutex.Lock()
+			delete(t.pending, id)
+			t.mutex.Unlock()
+		}
+	}()
+	if err = t.send(ctx, request); err != nil {
+		return
+	}
+	select {
+	case <-ctx.Done():
+		err = ctx.Err()
+	case response, ok := <-waiter:
+		if !ok {
+			err = errConnClosed
+			return
+		}
+		message, err = response.message, response.err
+	}
+	return
+}
+
+// wsResponse is message of response or error, which completes request.
+type wsResponse struct {
+	message []byte
+	err     error
+}
+
+// responseID returns id of response or id of first response of batch.
+func responseID(message []byte) (id ID, found bool) {
+
+	var response struct {
+		ID *ID `json:"id"`
+	}
+	if message = bytes.TrimSpace(message); len(message) != 0 && message[0] == '[' {
+		var responses []json.RawMessage
+		if err := json.Unmarshal(message, &responses); err != nil || len(responses) == 0 {
+			return
+		}
+		message = responses[0]
+	}
+	if err := json.Unmarshal(message, &response); err != nil || response.ID == nil {
+		return
+	}
+	return *response.ID, true
+}
+
+// responseError returns error of response or nil, when message is not error response.
+func responseError(message []byte) (rpcErr *RPCError) {
+
+	var response struct {
+		Error *RPCError `json:"error"`
+	}
+	if err := json.Unmarshal(message, &response); err != nil {
+		return
+	}
+	return response.Error
+}
+
+func (client *ClientRPC) decode(message []byte, out any) (err error) {
+
+	decoder := json.NewDecoder(bytes.NewReader(message))
+	if !client.options.allowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+	decoder.UseNumber()
+	return decoder.Decode(out)
+}
+
+func (client *ClientRPC) doCallWS(ctx context.Context, request *RequestRPC) (rpcResponse *ResponseRPC, err error) {
+
+	if client.options.logRequests {
+		log.Ctx(ctx).Debug().Str("method", request.Method).Str("endpoint", client.endpoint).Msg("call")
+	}
+	defer func() {
+		if err != nil && client.options.logOnError {
+			log.Ctx(ctx).Error().Err(err).Str("method", request.Method).Str("endpoint", client.endpoint).Msg("call")
+		}
+	}()
+	var message []byte
+	if message, err = client.ws.roundTrip(ctx, request.ID, request); err != nil {
+		err = fmt.Errorf("rpc call %v() on %v: %v", request.Method, client.endpoint, err.Error())
+		return
+	}
+	if err = client.decode(message, &rpcResponse); err != nil {
+		err = fmt.Errorf("rpc call %v() on %v. could not decode message to rpc response: %v", request.Method, client.endpoint, err.Error())
+	}
+	return
+}
+
+func (client *ClientRPC) doBatchCallWS(ctx context.Context, rpcRequests []*RequestRPC) (rpcResponses ResponsesRPC, err error) {
+
+	if client.options.logRequests {
+		log.Ctx(ctx).Debug().Str("method", "batch").Int("count", len(rpcRequests)).Str("endpoint", client.endpoint).Msg("call")
+	}
+	defer func() {
+		if err != nil && client.options.logOnError {
+			log.Ctx(ctx).Error().Err(err).Str("method", "batch").Int("count", len(rpcRequests)).Str("endpoint", client.endpoint).Msg("call")
+		}
+	}()
+	// response of batch is matched by first request, which is not notification
+	var id ID
+	for _, request := range rpcRequests {
+		if request.ID != NilID {
+			id = request.ID
+			break
+		}
+	}
+	if id == NilID {
+		if err = client.ws.send(ctx, rpcRequests); err != nil {
+			err = fmt.Errorf("rpc batch call on %v: %v", client.endpoint, err.Error())
+		}
+		return
+	}
+	var message []byte
+	if message, err = client.ws.roundTrip(ctx, id, rpcRequests); err != nil {
+		err = fmt.Errorf("rpc batch call on %v: %v", client.endpoint, err.Error())
+		return
+	}
+	if err = client.decode(message, &rpcResponses); err != nil {
+		err = fmt.Errorf("rpc batch call on %v. could not decode message to rpc response: %v", client.endpoint, err.Error())
+	}
+	return
+}
+
+func (client *ClientRPC) doNotifyWS(ctx context.Context, request *RequestRPC) (err error) {
+
+	if client.options.logRequests {
+		log.Ctx(ctx).Debug().Str("method", request.Method).Str("endpoint", client.endpoint).Msg("notify")
+	}
+	if err = client.ws.send(ctx, request); err != nil {
+		err = fmt.Errorf("rpc notify %v() on %v: %v", request.Method, client.endpoint, err.Error())
+		if client.options.logOnError {
+			log.Ctx(ctx).Error().Err(err).Str("method", request.Method).Str("endpoint", client.endpoint).Msg("notify")
+		}
+	}
+	return
+}
@@ -0,0 +1,181 @@
+package tracer
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/contrib"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	instrumentationName = "tg"
+
+	MetricNameHttpServerDuration       = "http.server.duration"
+	MetricNameHttpServerRequestSize    = "http.server.request.size"
+	MetricNameHttpServerResponseSize   = "http.server.response.size"
+	MetricNameHttpServerActiveRequests = "http.server.active_requests"
+
+	UnitDimensionless = "1"
+	UnitBytes         = "By"
+	UnitMilliseconds  = "ms"
+)
+
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	size   int64
+}
+
+func (w *responseRecorder) WriteHeader(status int) {
+
+	if w.status == 0 {
+		w.status = status
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseRecorder) Write(data []byte) (n int, err error) {
+
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	n, err = w.ResponseWriter.Write(data)
+	w.size += int64(n)
+	return
+}
+
+func (w *responseRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Hijack lets WebSocket to take over connection of traced request.
+func (w *responseRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+
+	if w.status == 0 {
+		w.status = http.StatusSwitchingProtocols
+	}
+	return http.NewResponseController(w.ResponseWriter).Hijack()
+}
+
+func Middleware(opts ...Option) func(next http.Handler) http.Handler {
+
+	cfg := config{
+		collectClientIP: true,
+	}
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+	if cfg.TracerProvider == nil {
+		cfg.TracerProvider = otel.GetTracerProvider()
+	}
+	tracer := cfg.TracerProvider.Tracer(
+		instrumentationName,
+		trace.WithInstrumentationVersion(contrib.Version()),
+	)
+	if cfg.MeterProvider == nil {
+		cfg.MeterProvider = otel.GetMeterProvider()
+	}
+	meter := cfg.MeterProvider.Meter(
+		instrumentationName,
+		metric.WithInstrumentationVersion(contrib.Version()),
+	)
+	httpServerDuration, err := meter.Float64Histogram(MetricNameHttpServerDuration, metric.WithUnit(UnitMilliseconds), metric.WithDescription("measures the duration inbound HTTP requests"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	httpServerRequestSize, err := meter.Int64Histogram(MetricNameHttpServerRequestSize, metric.WithUnit(UnitBytes), metric.WithDescription("measures the size of HTTP request messages"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	httpServerResponseSize, err := meter.Int64Histogram(MetricNameHttpServerResponseSize, metric.WithUnit(UnitBytes), metric.WithDescription("measures the size of HTTP response messages"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	httpServerActiveRequests, err := meter.Int64UpDownCounter(MetricNameHttpServerActiveRequests, metric.WithUnit(UnitDimensionless), metric.WithDescription("measures the number of concurrent HTTP requests that are currently in-flight"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	if cfg.Propagators == nil {
+		cfg.Propagators = otel.GetTextMapPropagator()
+	}
+	if cfg.SpanNameFormatter == nil {
+		cfg.SpanNameFormatter = defaultSpanNameFormatter
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+
+			if cfg.Next != nil && cfg.Next(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			savedCtx, cancel := context.WithCancel(r.Context())
+			defer cancel()
+			start := time.Now()
+			requestMetricsAttrs := httpServerMetricAttributesFromRequest(r, cfg)
+			httpServerActiveRequests.Add(savedCtx, 1, metric.WithAttributes(requestMetricsAttrs...))
+			responseMetricAttrs := make([]attribute.KeyValue, 0, len(requestMetricsAttrs))
+			copy(responseMetricAttrs, requestMetricsAttrs)
+			var reqHeaderAttrs []attribute.KeyValue
+			for k, v := range r.Header {
+				if strings.HasPrefix(strings.ToLower(k), "x-") && len(v) != 0 {
+					reqHeaderAttrs = append(reqHeaderAttrs, attribute.String(fmt.Sprintf("header.%s", k), v[0]))
+				}
+			}
+			for _, cookie := range r.Cookies() {
+				if strings.HasPrefix(strings.ToLower(cookie.Name), "x-") {
+					reqHeaderAttrs = append(reqHeaderAttrs, attribute.String(fmt.Sprintf("cookie.%s", cookie.Name), cookie.Value))
+				}
+			}
+			ctx := cfg.Propagators.Extract(savedCtx, propagation.HeaderCarrier(r.Header))
+			options := []trace.SpanStartOption{
+				trace.WithAttributes(httpServerTraceAttributesFromRequest(r, cfg)...),
+				trace.WithSpanKind(trace.SpanKindServer),
+			}
+			ctx, span := tracer.Start(ctx, r.URL.Path, options...)
+			defer span.End()
+			tracingHeaders := make(propagation.HeaderCarrier)
+			cfg.Propagators.Inject(ctx, tracingHeaders)
+			for _, headerKey := range tracingHeaders.Keys() {
+				w.Header().Set(headerKey, tracingHeaders.Get(headerKey))
+			}
+			recorder := &responseRecorder{ResponseWriter: w}
+			next.ServeHTTP(recorder, r.WithContext(ctx))
+			if recorder.status == 0 {
+				recorder.status = http.StatusOK
+			}
+			responseAttrs := append(
+				semconv.HTTPAttributesFromHTTPStatusCode(recorder.status),
+				append(reqHeaderAttrs, semconv.HTTPRouteKey.String(r.URL.Path))...,
+			)
+			requestSize := max(r.ContentLength, 0)
+			responseMetricAttrs = append(responseMetricAttrs, responseAttrs...)
+			httpServerActiveRequests.Add(savedCtx, -1, metric.WithAttributes(requestMetricsAttrs...))
+			httpServerDuration.Record(savedCtx, float64(time.Since(start).Microseconds())/1000, metric.WithAttributes(responseMetricAttrs...))
+			httpServerRequestSize.Record(savedCtx, requestSize, metric.WithAttributes(responseMetricAttrs...))
+			httpServerResponseSize.Record(savedCtx, recorder.size, metric.WithAttributes(responseMetricAttrs...))
+			span.SetAttributes(
+				append(
+					responseAttrs,
+					semconv.HTTPResponseContentLengthKey.Int64(recorder.size),
+				)...)
+			span.SetName(cfg.SpanNameFormatter(r))
+			spanStatus, spanMessage := semconv.SpanStatusFromHTTPStatusCodeAndSpanKind(recorder.status, trace.SpanKindServer)
+			span.SetStatus(spanStatus, spanMessage)
+		})
+	}
+}
+
+func defaultSpanNameFormatter(r *http.Request) string {
+	return r.URL.Path
+}
@@ -0,0 +1,108 @@
+package tracer
+
+import (
+	"encoding/base64"
+	"net"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+)
+
+func httpServerMetricAttributesFromRequest(r *http.Request, cfg config) []attribute.KeyValue {
+
+	attrs := []attribute.KeyValue{
+		httpFlavorAttribute(r),
+		semconv.HTTPMethodKey.String(r.Method),
+		semconv.HTTPSchemeKey.String(scheme(r)),
+		semconv.NetHostNameKey.String(hostname(r)),
+	}
+	if cfg.Port != nil {
+		attrs = append(attrs, semconv.NetHostPortKey.Int(*cfg.Port))
+	}
+	if cfg.ServerName != nil {
+		attrs = append(attrs, semconv.HTTPServerNameKey.String(*cfg.ServerName))
+	}
+	if cfg.CustomMetricAttributes != nil {
+		attrs = append(attrs, cfg.CustomMetricAttributes(r)...)
+	}
+	return attrs
+}
+
+func httpServerTraceAttributesFromRequest(r *http.Request, cfg config) []attribute.KeyValue {
+
+	attrs := []attribute.KeyValue{
+		httpFlavorAttribute(r),
+		semconv.HTTPMethodKey.String(r.Method),
+		semconv.HTTPRequestContentLengthKey.Int64(max(r.ContentLength, 0)),
+		semconv.HTTPSchemeKey.String(scheme(r)),
+		semconv.HTTPTargetKey.String(r.RequestURI),
+		semconv.HTTPURLKey.String(r.URL.String()),
+		semconv.HTTPUserAgentKey.String(r.UserAgent()),
+		semconv.NetHostNameKey.String(hostname(r)),
+		semconv.NetTransportTCP,
+	}
+	if cfg.Port != nil {
+		attrs = append(attrs, semconv.NetHostPortKey.Int(*cfg.Port))
+	}
+	if cfg.ServerName != nil {
+		attrs = append(attrs, semconv.HTTPServerNameKey.String(*cfg.ServerName))
+	}
+	if username, ok := HasBasicAuth(r.Header.Get("Authorization")); ok {
+		attrs = append(attrs, semconv.EnduserIDKey.String(username))
+	}
+	if cfg.collectClientIP {
+		if clientIP, _, err := net.SplitHostPort(r.RemoteAddr); err == nil && len(clientIP) > 0 {
+			attrs = append(attrs, semconv.HTTPClientIPKey.String(clientIP))
+		}
+	}
+	if cfg.CustomAttributes != nil {
+		attrs = append(attrs, cfg.CustomAttributes(r)...)
+	}
+	return attrs
+}
+
+func httpFlavorAttribute(r *http.Request) attribute.KeyValue {
+
+	if r.ProtoAtLeast(1, 1) {
+		return semconv.HTTPFlavorHTTP11
+	}
+	return semconv.HTTPFlavorHTTP10
+}
+
+func scheme(r *http.Request) string {
+
+	if r.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
+
+func hostname(r *http.Request) string {
+
+	if host, _, err := net.SplitHostPort(r.Host); err == nil {
+		return host
+	}
+	return r.Host
+}
+
+func HasBasicAuth(auth string) (string, bool) {
+
+	if auth == "" {
+		return "", false
+	}
+	if !strings.HasPrefix(auth, "Basic ") {
+		return "", false
+	}
+	raw, err := base64.StdEncoding.DecodeString(auth[6:])
+	if err != nil {
+		return "", false
+	}
+	creds := string(raw)
+	index := strings.Index(creds, ":")
+	if index == -1 {
+		return "", false
+	}
+	return creds[:index], true
+}
@@ -0,0 +1,175 @@
+package generator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// PluginPrefix is a prefix of plugin executable name: plugin 'gateway' is looked up in PATH as 'tg-gateway'.
+const PluginPrefix = "tg-"
+
+// pluginTimeout limits run of plugin, so hung plugin does not block generation.
+var pluginTimeout = 5 * time.Minute
+
+// PluginRequest is written to stdin of plugin.
+type PluginRequest struct {
+	Contract *Contract         `json:"contract"`
+	Options  map[string]string `json:"options,omitempty"`
+}
+
+// PluginResponse is read from stdout of plugin. File names are relative to output directory.
+type PluginResponse struct {
+	Files []PluginFile `json:"files"`
+	Error string       `json:"error,omitempty"`
+}
+
+type PluginFile struct {
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+// RenderPlugin runs external plugin with contract model on stdin and writes returned files to outDir.
+// Files get 'doNotEdit' header and are recorded in manifest of outDir, so files of previous run are removed.
+func (tr *Transport) RenderPlugin(name, outDir string, options map[string]string) (err error) {
+
+	var snapshot *dirSnapshot
+	if snapshot, err = tr.beginRender(outDir); err != nil {
+		return
+	}
+	defer func() { err = tr.endRender(snapshot, err) }()
+
+	var response PluginResponse
+	if response, err = tr.runPlugin(name, options); err != nil {
+		return
+	}
+	names := make(map[string]bool, len(response.Files))
+	for _, file := range response.Files {
+		if filepath.IsAbs(file.Name) || !filepath.IsLocal(file.Name) {
+			return fmt.Errorf("plugin %s: file name '%s' must be relative to output directory", name, file.Name)
+		}
+		fileName := filepath.ToSlash(filepath.Clean(file.Name))
+		if names[fileName] {
+			return fmt.Errorf("plugin %s: file '%s' is returned twice", name, fileName)
+		}
+		names[fileName] = true
+	}
+	cache := tr.readManifest(outDir)
+	target := targetPlugin + ":" + name
+	tr.cleanupPlugin(outDir, cache.Targets[target])
+	files := make(map[string]string, len(response.Files))
+	for _, file := range response.Files {
+		filePath := path.Join(outDir, filepath.ToSlash(file.Name))
+		if err = tr.output().MkdirAll(path.Dir(filePath), 0777); err != nil {
+			return
+		}
+		content := withDoNotEdit(filePath, []byte(file.Content))
+		if err = tr.output().WriteFile(filePath, content, 0600); err != nil {
+			return
+		}
+		files[filepath.ToSlash(filepath.Clean(file.Name))] = hash(content)
+	}
+	delete(cache.Targets, target)
+	if len(files) != 0 {
+		cache.Targets[target] = manifestTarget{Files: files}
+	}
+	if err = tr.writeManifest(outDir, cache); err != nil {
+		return
+	}
+	tr.log.WithField("plugin", name).Infof("write %d file(s) to %s", len(response.Files), outDir)
+	return
+}
+
+func (tr *Transport) runPlugin(name string, options map[string]string) (response PluginResponse, err error) {
+
+	// path of plugin in config uses '/' on every platform
+	executable := filepath.FromSlash(name)
+	if !strings.ContainsAny(name, "/"+string(filepath.Separator)) {
+		if executable, err = exec.LookPath(PluginPrefix + name); err != nil {
+			return response, fmt.Errorf("plugin %s: %w", name, err)
+		}
+	}
+	var request []byte
+	if request, err = json.Marshal(PluginRequest{Contract: tr.Contract(), Options: options}); err != nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), pluginTimeout)
+	defer cancel()
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, executable) // nolint:gosec
+	cmd.Stdin = bytes.NewReader(request)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	// children of killed plugin may keep its stdout open
+	cmd.WaitDelay = time.Second
+	if err = cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return response, fmt.Errorf("plugin %s: no response in %v", name, pluginTimeout)
+		}
+		return response, fmt.Errorf("plugin %s: %w: %s", name, err, strings.TrimSpace(stderr.String()))
+	}
+	if err = json.Unmarshal(stdout.Bytes(), &response); err != nil {
+		return response, fmt.Errorf("plugin %s: read response: %w", name, err)
+	}
+	if response.Error != "" {
+		return response, fmt.Errorf("plugin %s: %s", name, response.Error)
+	}
+	return
+}
+
+// cleanupPlugin removes files of previous run of plugin, which are recorded in manifest of outDir.
+func (tr *Transport) cleanupPlugin(outDir string, previous manifestTarget) {
+
+	for relPath := range previous.Files {
+		filePath := filepath.Join(outDir, filepath.FromSlash(relPath))
+		if _, err := tr.readFile(filePath); err != nil {
+			continue
+		}
+		if err := tr.output().Remove(filePath); err != nil {
+			tr.log.WithError(err).Warn("cleanup")
+		}
+	}
+}
+
+// withDoNotEdit prepends 'doNotEdit' header in comment syntax of file. Formats without comments (JSON) are kept as is.
+// Header of script follows its '#!' line, so script stays executable.
+func withDoNotEdit(filePath string, content []byte) []byte {
+
+	var shebang []byte
+	body := content
+	if bytes.HasPrefix(content, []byte("#!")) {
+		if end := bytes.IndexByte(content, '\n'); end >= 0 {
+			shebang, body = content[:end+1], content[end+1:]
+		} else {
+			shebang, body = append(bytes.Clone(content), '\n'), nil
+		}
+	}
+	if bytes.Contains(bytes.SplitN(body, []byte("\n"), 2)[0], []byte(doNotEdit)) {
+		return content
+	}
+	var header string
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".go", ".js", ".ts", ".proto", ".java", ".kt", ".swift", ".c", ".h", ".cpp", ".cs", ".rs", ".dart", ".php", ".scss":
+		header = "// " + doNotEdit
+	case ".yaml", ".yml", ".toml", ".py", ".sh", ".rb", ".conf", ".env", ".tf", ".hcl", ".properties":
+		header = "# " + doNotEdit
+	case ".sql", ".lua":
+		header = "-- " + doNotEdit
+	case ".md", ".html", ".xml":
+		header = "<!-- " + doNotEdit + " -->"
+	case ".css":
+		header = "/* " + doNotEdit + " */"
+	default:
+		return content
+	}
+	result := make([]byte, 0, len(shebang)+len(header)+1+len(body))
+	result = append(result, shebang...)
+	result = append(result, header+"\n"...)
+	return append(result, body...)
+}
@@ -0,0 +1,127 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// fakePlugin answers files by step option: first step writes 'a.json' and 'sub/c.yaml', second one writes 'b.json'.
+const fakePlugin = `#!/bin/sh
+request=$(cat)
+case "$request" in
+*'"step":"error"'*) echo '{"error":"broken contract"}' ;;
+*'"step":"escape"'*) echo '{"files":[{"name":"../escape.json","content":"{}"}]}' ;;
+*'"step":"duplicate"'*) echo '{"files":[{"name":"a.json","content":"{}"},{"name":"./a.json","content":"{}"}]}' ;;
+*'"step":"hang"'*) exec sleep 10 ;;
+*'"step":"script"'*) printf '%s\n' '{"files":[{"name":"run.sh","content":"#!/bin/sh\necho run\n"}]}' ;;
+*'"step":"2"'*) echo '{"files":[{"name":"b.json","content":"{}"}]}' ;;
+*) echo '{"files":[{"name":"a.json","content":"{}"},{"name":"sub/c.yaml","content":"a: 1"}]}' ;;
+esac
+`
+
+func TestRenderPlugin(t *testing.T) {
+
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin is a shell script")
+	}
+	files := map[string]string{
+		"go.mod": "module example.com/api\n\ngo 1.22\n",
+		"service/user.go": `package service
+
+import "context"
+
+// @tg jsonRPC-server
+type User interface {
+	GetName(ctx context.Context, id int) (name string, err error)
+}
+`,
+		"plugins/tg-fake":   fakePlugin,
+		"gateway/keep.json": "{}",
+	}
+	dir := writeProject(t, files)
+	if err := os.Chmod(filepath.Join(dir, "plugins", "tg-fake"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", filepath.Join(dir, "plugins")+string(os.PathListSeparator)+os.Getenv("PATH"))
+	tr, err := NewTransport(logrus.New(), "test", "service")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join("gateway", name))
+		return err == nil
+	}
+
+	if err = tr.RenderPlugin("fake", "gateway", map[string]string{"step": "1"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !exists("a.json") || !exists(manifestName) {
+		t.Fatal("files of plugin are not written")
+	}
+	if data, _ := os.ReadFile("gateway/sub/c.yaml"); !strings.HasPrefix(string(data), "# "+doNotEdit) {
+		t.Fatalf("file has no header:\n%s", data)
+	}
+
+	if err = tr.RenderPlugin("fake", "gateway", map[string]string{"step": "2"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if exists("a.json") || exists("sub/c.yaml") || !exists("b.json") {
+		t.Fatal("files of previous run are not replaced")
+	}
+	if !exists("keep.json") {
+		t.Fatal("file, which is not generated by plugin, is removed")
+	}
+
+	if err = tr.RenderPlugin("./plugins/tg-fake", "gateway", map[string]string{"step": "1"}); err != nil {
+		t.Fatalf("plugin is not run by path: %v", err)
+	}
+
+	if err = tr.RenderPlugin("fake", "gateway", map[string]string{"step": "script"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if data, _ := os.ReadFile("gateway/run.sh"); string(data) != "#!/bin/sh\n# "+doNotEdit+"\necho run\n" {
+		t.Fatalf("header of script does not follow shebang:\n%s", data)
+	}
+
+	defer func(timeout time.Duration) { pluginTimeout = timeout }(pluginTimeout)
+	pluginTimeout = 100 * time.Millisecond
+	for step, message := range map[string]string{
+		"error":     "plugin fake: broken contract",
+		"escape":    "must be relative to output directory",
+		"duplicate": "file 'a.json' is returned twice",
+		"hang":      "plugin fake: no response in 100ms",
+	} {
+		if err = tr.RenderPlugin("fake", "gateway", map[string]string{"step": step}); err == nil || !strings.Contains(err.Error(), message) {
+			t.Fatalf("expected error %q, got %v", message, err)
+		}
+	}
+	if err = tr.RenderPlugin("missing", "gateway", nil); err == nil || !strings.Contains(err.Error(), "plugin missing") {
+		t.Fatalf("expected error of missing plugin, got %v", err)
+	}
+}
+
+func TestWithDoNotEdit(t *testing.T) {
+
+	header := "# " + doNotEdit + "\n"
+	for _, check := range []struct {
+		name, content, expected string
+	}{
+		{name: "routes.yaml", content: "a: 1\n", expected: header + "a: 1\n"},
+		{name: "routes.yaml", content: header + "a: 1\n", expected: header + "a: 1\n"},
+		{name: "routes.json", content: "{}", expected: "{}"},
+		{name: "run.sh", content: "#!/bin/sh\necho run\n", expected: "#!/bin/sh\n" + header + "echo run\n"},
+		{name: "run.sh", content: "#!/bin/sh\n" + header + "echo run\n", expected: "#!/bin/sh\n" + header + "echo run\n"},
+		{name: "run.sh", content: "#!/bin/sh", expected: "#!/bin/sh\n" + header},
+		{name: "cli.js", content: "#!/usr/bin/env node\n", expected: "#!/usr/bin/env node\n// " + doNotEdit + "\n"},
+	} {
+		if content := string(withDoNotEdit(check.name, []byte(check.content))); content != check.expected {
+			t.Fatalf("unexpected content of %s:\n%q\nexpected:\n%q", check.name, content, check.expected)
+		}
+	}
+}
@@ -15,7 +15,7 @@ import (
 
 func (svc *service) renderExchange(outDir string) (err error) {
 
-	srcFile := newSrc(filepath.Base(outDir))
+	srcFile := svc.tr.newSrc(filepath.Base(outDir))
 	srcFile.PackageComment(doNotEdit)
 
 	ctx := context.WithValue(context.Background(), keyCode, srcFile) // nolint
@@ -0,0 +1,178 @@
+package generator
+
+import (
+	"context"
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"github.com/seniorGolang/tg/v2/pkg/astra/types"
+	"github.com/seniorGolang/tg/v2/pkg/utils"
+)
+
+// renderGRPC renders adapter of service to gRPC server, which calls methods through middlewares of service.
+func (svc *service) renderGRPC(outDir string, schema *grpcSchema) (err error) {
+
+	srcFile := svc.tr.newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	srcFile.ImportName(packageGRPC, "grpc")
+	srcFile.ImportName(schema.goPkg, "pb")
+	srcFile.ImportName(packageGRPCCodes, "codes")
+	srcFile.ImportName(packageGRPCStatus, "status")
+	srcFile.ImportName(svc.pkgPath, filepath.Base(svc.pkgPath))
+
+	srcFile.Line().Type().Id("grpc"+svc.Name).Struct(
+		Qual(schema.goPkg, "Unimplemented"+svc.Name+"Server"),
+		Id("svc").Op("*").Id("server"+svc.Name),
+	)
+	srcFile.Line().Func().Id("NewGRPC" + svc.Name).Params(Id("svc"+svc.Name).Qual(svc.pkgPath, svc.Name)).Params(Id("srv").Op("*").Id("grpc" + svc.Name)).Block(
+		Return(Op("&").Id("grpc" + svc.Name).Values(Dict{
+			Id("svc"): Id("newServer" + svc.Name).Call(Id("svc" + svc.Name)),
+		})),
+	)
+	srcFile.Line().Func().Params(Id("srv").Op("*").Id("grpc" + svc.Name)).Id("Service").Params().Params(Op("*").Id("server" + svc.Name)).Block(
+		Return(Id("srv").Dot("svc")),
+	)
+	withFunc := func(name string) Code {
+		return Func().Params(Id("srv").Op("*").Id("grpc"+svc.Name)).Id(name).Params().Params(Op("*").Id("grpc"+svc.Name)).Block(
+			Id("srv").Dot("svc").Dot(name).Call(),
+			Return(Id("srv")),
+		)
+	}
+	srcFile.Line().Add(withFunc("WithLog"))
+	if svc.tags.IsSet(tagTrace) {
+		srcFile.Line().Add(withFunc("WithTrace"))
+	}
+	if svc.tags.IsSet(tagMetrics) {
+		srcFile.Line().Add(withFunc("WithMetrics"))
+	}
+	srcFile.Line().Func().Params(Id("srv").Op("*").Id("grpc" + svc.Name)).Id("Register").Params(Id("registrar").Qual(packageGRPC, "ServiceRegistrar")).Block(
+		Qual(schema.goPkg, "Register"+svc.Name+"Server").Call(Id("registrar"), Id("srv")),
+	)
+	for _, pm := range schema.methods[svc.Name] {
+		srcFile.Line().Add(svc.grpcServeMethodFunc(schema, pm))
+	}
+	return srcFile.Save(path.Join(outDir, svc.lcName()+"-grpc.go"))
+}
+
+func (svc *service) grpcServeMethodFunc(schema *grpcSchema, pm protoMethod) Code {
+
+	method := pm.method
+	return Func().Params(Id("srv").Op("*").Id("grpc"+svc.Name)).Id(method.Name).
+		Params(Id(_ctx_).Qual(packageContext, "Context"), Id("_request").Op("*").Qual(schema.goPkg, pm.request.name)).
+		Params(Id("_response").Op("*").Qual(schema.goPkg, pm.response.name), Err().Error()).BlockFunc(func(bg *Group) {
+
+		bg.Line()
+		for _, field := range pm.request.fields {
+			bg.Var().Id(field.goPath[0]).Add(goTypeOf(field.ref.ct))
+			schema.fromProto(bg, goPathOf(field.goPath[0], nil), fieldOf("_request", field.name), field.ref, func() Code {
+				return Return(Nil(), Qual(packageGRPCStatus, "Error").Call(Qual(packageGRPCCodes, "InvalidArgument"), Err().Dot("Error").Call()))
+			})
+		}
+		for _, field := range pm.response.fields {
+			bg.Var().Id(field.goPath[0]).Add(goTypeOf(field.ref.ct))
+		}
+		call := Id("srv").Dot("svc").Dot(method.Name).CallFunc(func(cg *Group) {
+			if isContextFirst(method.Args) {
+				cg.Id(_ctx_)
+			}
+			for _, arg := range method.argsWithoutContext() {
+				argCode := Id(utils.ToLowerCamel(arg.Name))
+				if types.IsEllipsis(arg.Type) {
+					argCode.Op("...")
+				}
+				cg.Add(argCode)
+			}
+		})
+		results := grpcResults(method)
+		if isErrorLast(method.Results) {
+			bg.If(List(results...).Op("=").Add(call).Op(";").Err().Op("!=").Nil()).Block(
+				Return(Nil(), Id("grpcError").Call(Err())),
+			)
+		} else if len(results) != 0 {
+			bg.List(results...).Op("=").Add(call)
+		} else {
+			bg.Add(call)
+		}
+		bg.Id("_response").Op("=").Op("&").Qual(schema.goPkg, pm.response.name).Values()
+		for _, field := range pm.response.fields {
+			schema.toProto(bg, fieldOf("_response", field.name), goPathOf(field.goPath[0], nil), field.ref)
+		}
+		bg.Return()
+	})
+}
+
+// grpcResults returns names of results of method, error is 'err'.
+func grpcResults(method *method) (results []Code) {
+
+	for _, ret := range method.resultsWithoutError() {
+		results = append(results, Id(utils.ToLowerCamel(ret.Name)))
+	}
+	if isErrorLast(method.Results) {
+		results = append(results, Err())
+	}
+	return
+}
+
+// renderClientGRPC renders client of service, which calls methods of service by gRPC.
+func (svc *service) renderClientGRPC(outDir string, schema *grpcSchema) (err error) {
+
+	srcFile := svc.tr.newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	ctx := context.WithValue(context.Background(), keyCode, srcFile) // nolint
+
+	srcFile.ImportName(packageGRPC, "grpc")
+	srcFile.ImportName(schema.goPkg, "pb")
+	srcFile.ImportName(svc.pkgPath, filepath.Base(svc.pkgPath))
+
+	srcFile.Line().Add(docComment(docText(svc.Docs))).Type().Id("Client" + svc.Name + "GRPC").Struct(
+		Id("client").Qual(schema.goPkg, svc.Name+"Client"),
+	)
+	srcFile.Line().Func().Id("NewClient" + svc.Name + "GRPC").Params(Id("conn").Qual(packageGRPC, "ClientConnInterface")).Params(Op("*").Id("Client" + svc.Name + "GRPC")).Block(
+		Return(Op("&").Id("Client" + svc.Name + "GRPC").Values(Dict{
+			Id("client"): Qual(schema.goPkg, "New"+svc.Name+"Client").Call(Id("conn")),
+		})),
+	)
+	for _, pm := range schema.methods[svc.Name] {
+		srcFile.Line().Add(docComment(pm.method.description())).Add(svc.grpcClientMethodFunc(ctx, schema, pm))
+	}
+	return srcFile.Save(path.Join(outDir, svc.lcName()+"-grpc.go"))
+}
+
+func (svc *service) grpcClientMethodFunc(ctx context.Context, schema *grpcSchema, pm protoMethod) Code {
+
+	method := pm.method
+	results := method.Results
+	if !isErrorLast(results) {
+		results = append(append([]types.Variable{}, results...), types.Variable{Base: types.Base{Name: "err"}, Type: types.TName{TypeName: "error"}})
+	}
+	return Func().Params(Id("cli").Op("*").Id("Client" + svc.Name + "GRPC")).Id(method.Name).
+		Params(funcDefinitionParams(ctx, method.Args)).Params(funcDefinitionParams(ctx, results)).BlockFunc(func(bg *Group) {
+
+		bg.Line()
+		bg.Id("_request").Op(":=").Op("&").Qual(schema.goPkg, pm.request.name).Values()
+		for _, field := range pm.request.fields {
+			schema.toProto(bg, fieldOf("_request", field.name), goPathOf(field.goPath[0], nil), field.ref)
+		}
+		callCtx := Qual(packageContext, "Background").Call()
+		if isContextFirst(method.Args) {
+			callCtx = Id(utils.ToLowerCamel(method.Args[0].Name))
+		}
+		if len(pm.response.fields) == 0 {
+			bg.List(Id("_"), Err()).Op("=").Id("cli").Dot("client").Dot(method.Name).Call(callCtx, Id("_request"))
+			bg.Return()
+			return
+		}
+		bg.Var().Id("_response").Op("*").Qual(schema.goPkg, pm.response.name)
+		bg.If(List(Id("_response"), Err()).Op("=").Id("cli").Dot("client").Dot(method.Name).Call(callCtx, Id("_request")).Op(";").Err().Op("!=").Nil()).Block(
+			Return(),
+		)
+		for _, field := range pm.response.fields {
+			schema.fromProto(bg, goPathOf(field.goPath[0], nil), fieldOf("_response", field.name), field.ref, func() Code { return Return() })
+		}
+		bg.Return()
+	})
+}
@@ -22,12 +22,12 @@ import (
 
 func (svc *service) renderClientHTTP(outDir string) (err error) {
 
-	srcFile := newSrc(filepath.Base(outDir))
+	srcFile := svc.tr.newSrc(filepath.Base(outDir))
 	srcFile.PackageComment(doNotEdit)
 
 	ctx := context.WithValue(context.Background(), keyCode, srcFile) // nolint
 
-	if err = pkgCopyTo("httpclient", outDir); err != nil {
+	if err = pkgCopyTo(svc.tr.output(), "httpclient", outDir); err != nil {
 		return err
 	}
 	srcFile.ImportName(packageContext, "context")
@@ -40,11 +40,21 @@ func (svc *service) renderClientHTTP(outDir string) (err error) {
 	srcFile.ImportName(fmt.Sprintf("%s/hasher", svc.tr.pkgPath(outDir)), "hasher")
 	srcFile.ImportName(fmt.Sprintf("%s/httpclient", svc.tr.pkgPath(outDir)), "httpclient")
 
-	srcFile.Type().Id("Client" + svc.Name).StructFunc(func(g *Group) {
+	srcFile.Add(docComment(docText(svc.Docs))).Type().Id("Client" + svc.Name).StructFunc(func(g *Group) {
 		g.Id("httpClient").Op("*").Qual(fmt.Sprintf("%s/httpclient", svc.tr.pkgPath(outDir)), "ClientHTTP")
 	}).Line()
 
-	srcFile.Func().Id("NewClient"+svc.Name).Params(
+	srcFile.Add(svc.tr.overridable(&srcFile, OverrideHTTPClient, svc, svc.httpClientNewFunc(outDir))).Line()
+
+	for _, method := range svc.methods {
+		srcFile.Line().Add(svc.httpClientMethodFunc(ctx, method, outDir))
+	}
+	return srcFile.Save(path.Join(outDir, svc.lcName()+"-http-client.go"))
+}
+
+func (svc *service) httpClientNewFunc(outDir string) Code {
+
+	return Func().Id("NewClient"+svc.Name).Params(
 		Id("endpoint").String(),
 		Id("opts").Op("...").Qual(fmt.Sprintf("%s/httpclient", svc.tr.pkgPath(outDir)), "Option"),
 	).Params(Id("client").Op("*").Id("Client"+svc.Name)).Block(
@@ -52,18 +62,16 @@ func (svc *service) renderClientHTTP(outDir string) (err error) {
 		Return(Op("&").Id("Client"+svc.Name).Values(Dict{
 			Id("httpClient"): Id("httpClient"),
 		})),
-	).Line()
-
-	for _, method := range svc.methods {
-		srcFile.Line().Add(svc.httpClientMethodFunc(ctx, method, outDir))
-	}
-	return srcFile.Save(path.Join(outDir, svc.lcName()+"-http-client.go"))
+	)
 }
 
 func (svc *service) httpClientMethodFunc(ctx context.Context, method *method, _ string) Code {
 
-	c := Comment(fmt.Sprintf("%s performs the %s operation.", method.Name, method.Name))
-	c.Line()
+	doc := method.description()
+	if doc == "" {
+		doc = fmt.Sprintf("%s performs the %s operation.", method.Name, method.Name)
+	}
+	c := docComment(doc)
 	c.Func().Params(Id("cli").Op("*").Id("Client" + svc.Name)).
 		Id(method.Name).
 		Params(funcDefinitionParams(ctx, method.Args)).
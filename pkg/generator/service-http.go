@@ -14,11 +14,12 @@ import (
 
 func (svc *service) renderHTTP(outDir string) (err error) {
 
-	srcFile := newSrc(filepath.Base(outDir))
+	srcFile := svc.tr.newSrc(filepath.Base(outDir))
 	srcFile.PackageComment(doNotEdit)
 
 	srcFile.ImportName(packageCors, "cors")
-	srcFile.ImportName(packageFiber, "fiber")
+	srcFile.ImportName(svc.tr.fiberPkg(), "fiber")
+	srcFile.ImportAlias(packageHttp, aliasNetHTTP)
 	srcFile.ImportName(packageZeroLog, "zerolog")
 	srcFile.ImportName(svc.pkgPath, filepath.Base(svc.pkgPath))
 
@@ -49,7 +50,11 @@ func (svc *service) renderHTTP(outDir string) (err error) {
 	}
 	srcFile.Line().Add(svc.withErrorHandler())
 
-	srcFile.Line().Func().Params(Id("http").Op("*").Id("http" + svc.Name)).Id("SetRoutes").Params(Id("route").Op("*").Qual(packageFiber, "App")).BlockFunc(func(bg *Group) {
+	if svc.tr.isNetHTTP() {
+		srcFile.Line().Add(svc.setRoutesNetHTTPFunc())
+		return srcFile.Save(path.Join(outDir, svc.lcName()+"-http.go"))
+	}
+	srcFile.Line().Func().Params(Id("http").Op("*").Id("http" + svc.Name)).Id("SetRoutes").Params(Id("route").Op("*").Qual(svc.tr.fiberPkg(), "App")).BlockFunc(func(bg *Group) {
 		if svc.tags.Contains(tagServerJsonRPC) {
 			bg.Id("route").Dot("Post").Call(Lit(svc.batchPath()), Id("http").Dot("serveBatch"))
 			for _, method := range svc.methods {
@@ -65,7 +70,7 @@ func (svc *service) renderHTTP(outDir string) (err error) {
 					continue
 				}
 				if method.tags.Contains(tagHandler) {
-					bg.Id("route").Dot(utils.ToCamel(method.httpMethod())).Call(Lit(method.httpPath()), Func().Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx")).Params(Err().Error()).Block(
+					bg.Id("route").Dot(utils.ToCamel(method.httpMethod())).Call(Lit(method.httpPath()), Func().Params(svc.tr.fiberCtx(_ctx_)).Params(Err().Error()).Block(
 						Return().Qual(method.handlerQual()).Call(Id(_ctx_), Id("http").Dot("base")),
 					))
 					continue
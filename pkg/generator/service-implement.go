@@ -11,7 +11,7 @@ import (
 func (svc *service) renderImplement(outDir string) (err error) { // nolint
 
 	outDir, _ = filepath.Abs(outDir)
-	srcFile := newSrc(filepath.Base(outDir))
+	srcFile := svc.tr.newSrc(filepath.Base(outDir))
 	srcFile.PackageComment(doNotEdit)
 
 	return srcFile.Save(path.Join(outDir, svc.lcName()+".go"))
@@ -16,7 +16,7 @@ import (
 
 func (svc *service) renderClientJsonRPC(outDir string) (err error) {
 
-	srcFile := newSrc(filepath.Base(outDir))
+	srcFile := svc.tr.newSrc(filepath.Base(outDir))
 	srcFile.PackageComment(doNotEdit)
 
 	ctx := context.WithValue(context.Background(), keyCode, srcFile) // nolint
@@ -29,7 +29,7 @@ func (svc *service) renderClientJsonRPC(outDir string) (err error) {
 	srcFile.ImportName(fmt.Sprintf("%s/hasher", svc.tr.pkgPath(outDir)), "hasher")
 	srcFile.ImportName(fmt.Sprintf("%s/jsonrpc", svc.tr.pkgPath(outDir)), "jsonrpc")
 
-	srcFile.Line().Type().Id("Client" + svc.Name).StructFunc(func(sg *Group) {
+	srcFile.Line().Add(docComment(docText(svc.Docs))).Type().Id("Client" + svc.Name).StructFunc(func(sg *Group) {
 		sg.Op("*").Id("ClientJsonRPC")
 	}).Line()
 	for _, method := range svc.methods {
@@ -42,8 +42,12 @@ func (svc *service) renderClientJsonRPC(outDir string) (err error) {
 		if method.tags.Contains(tagMethodHTTP) {
 			continue
 		}
-		srcFile.Line().Add(svc.jsonrpcClientMethodFunc(ctx, method, outDir))
+		srcFile.Line().Add(docComment(method.description())).Add(svc.jsonrpcClientMethodFunc(ctx, method, outDir))
 		srcFile.Line().Add(svc.jsonrpcClientRequestFunc(ctx, method, outDir))
+		if method.isNotify() {
+			srcFile.Line().Add(svc.jsonrpcClientNotifyFunc(ctx, method))
+			srcFile.Line().Add(svc.jsonrpcClientReqNotifyFunc(ctx, method, outDir))
+		}
 	}
 	return srcFile.Save(path.Join(outDir, svc.lcName()+"-jsonrpc.go"))
 }
@@ -105,6 +109,48 @@ func (svc *service) jsonrpcClientMethodFunc(ctx context.Context, method *method,
 	})
 }
 
+// jsonrpcClientNotifyFunc returns method of client, which sends notification and does not wait for response.
+func (svc *service) jsonrpcClientNotifyFunc(ctx context.Context, method *method) Code {
+
+	return Comment(fmt.Sprintf("Notify%[1]s calls %[1]s as notification, server does not answer it.", method.Name)).Line().
+		Func().Params(Id("cli").Op("*").Id("Client" + svc.Name)).
+		Id("Notify" + method.Name).
+		Params(funcDefinitionParams(ctx, method.Args)).Params(Err().Error()).BlockFunc(func(bg *Group) {
+
+		bg.Line()
+		bg.Id("_request").Op(":=").Id(method.requestStructName()).Values(DictFunc(func(dict Dict) {
+			for idx, arg := range method.fieldsArgument() {
+				dict[Id(utils.ToCamel(arg.Name))] = Id(method.argsWithoutContext()[idx].Name)
+			}
+		}))
+		bg.Return(Id("cli").Dot("rpc").Dot("Notify").Call(Id(_ctx_), Lit(svc.lcName()+"."+method.lcName()), Id("_request")))
+	})
+}
+
+// jsonrpcClientReqNotifyFunc returns notification for batch, it has no id and no callback.
+func (svc *service) jsonrpcClientReqNotifyFunc(ctx context.Context, method *method, outDir string) Code {
+
+	ctxCode := Id(_ctx_).Qual(packageContext, "Context")
+	return Func().Params(Id("cli").Op("*").Id("Client"+svc.Name)).
+		Id("ReqNotify"+method.Name).
+		Params(ctxCode, funcDefinitionParams(ctx, method.argsWithoutContext())).
+		Params(Id("_request").Id("RequestRPC")).BlockFunc(func(bg *Group) {
+
+		bg.Line()
+		bg.Id("_request").Op("=").Id("RequestRPC").Values(Dict{
+			Id("rpcRequest"): Qual(fmt.Sprintf("%s/jsonrpc", svc.tr.pkgPath(outDir)), "NewNotification").Call(
+				Lit(svc.lcName()+"."+method.lcName()),
+				Id(method.requestStructName()).Values(DictFunc(func(dg Dict) {
+					for idx, arg := range method.fieldsArgument() {
+						dg[Id(utils.ToCamel(arg.Name))] = Id(method.argsWithoutContext()[idx].Name)
+					}
+				})),
+			),
+		})
+		bg.Return()
+	})
+}
+
 func (svc *service) jsonrpcClientRequestFunc(ctx context.Context, method *method, outDir string) Code {
 
 	ctxCode := Id(_ctx_).Qual(packageContext, "Context")
@@ -187,7 +233,7 @@ func (svc *service) jsonrpcClientRequestFunc(ctx context.Context, method *method
 
 func (svc *service) renderClientFallbackError(outDir string) (err error) {
 
-	srcFile := newSrc(filepath.Base(outDir))
+	srcFile := svc.tr.newSrc(filepath.Base(outDir))
 	srcFile.PackageComment(doNotEdit)
 
 	srcFile.Type().Id("fallback" + svc.Name).InterfaceFunc(func(ig *Group) {
@@ -17,10 +17,12 @@ import (
 
 func (svc *service) renderJsonRPC(outDir string) (err error) {
 
-	srcFile := newSrc(filepath.Base(outDir))
+	srcFile := svc.tr.newSrc(filepath.Base(outDir))
 	srcFile.PackageComment(doNotEdit)
 
-	srcFile.ImportName(packageFiber, "fiber")
+	srcFile.ImportName(svc.tr.fiberPkg(), "fiber")
+	srcFile.ImportAlias(packageHttp, aliasNetHTTP)
+	srcFile.ImportName(packageIO, "io")
 	srcFile.ImportName(packageContext, "context")
 	srcFile.ImportName(packageErrors, "errors")
 	srcFile.ImportName(packageZeroLogLog, "log")
@@ -32,12 +34,22 @@ func (svc *service) renderJsonRPC(outDir string) (err error) {
 		if !method.isJsonRPC() {
 			continue
 		}
-		srcFile.Func().Params(Id("http").Op("*").Id("http" + svc.Name)).Id("serve" + method.Name).Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx")).Params(Err().Error()).Block(
-			Return().Id("http").Dot("_serveMethod").Call(Id(_ctx_), Lit(method.lcName()), Id("http").Dot(method.lccName())),
-		)
+		if svc.tr.isNetHTTP() {
+			srcFile.Func().Params(Id("http").Op("*").Id("http" + svc.Name)).Id("serve" + method.Name).Params(svc.tr.exchangeParams(_ctx_)...).Block(
+				Id("http").Dot("_serveMethod").Call(Id("w"), Id("r"), Lit(method.lcName()), Id("http").Dot(method.lccName())),
+			)
+		} else {
+			srcFile.Func().Params(Id("http").Op("*").Id("http" + svc.Name)).Id("serve" + method.Name).Params(svc.tr.fiberCtx(_ctx_)).Params(Err().Error()).Block(
+				Return().Id("http").Dot("_serveMethod").Call(Id(_ctx_), Lit(method.lcName()), Id("http").Dot(method.lccName())),
+			)
+		}
 		srcFile.Add(svc.rpcMethodFunc(method, outDir))
 	}
-	srcFile.Add(svc.serveMethodFunc())
+	if svc.tr.isNetHTTP() {
+		srcFile.Add(svc.serveMethodNetHTTPFunc())
+	} else {
+		srcFile.Add(svc.serveMethodFunc())
+	}
 	if err = srcFile.Save(path.Join(outDir, svc.lcName()+"-jsonrpc.go")); err != nil {
 		return
 	}
@@ -46,26 +58,33 @@ func (svc *service) renderJsonRPC(outDir string) (err error) {
 
 func (svc *service) renderBatch(outDir string) (err error) {
 
-	srcFile := newSrc(filepath.Base(outDir))
+	srcFile := svc.tr.newSrc(filepath.Base(outDir))
 	srcFile.PackageComment(doNotEdit)
 
-	srcFile.ImportName(packageFiber, "fiber")
+	srcFile.ImportName(svc.tr.fiberPkg(), "fiber")
+	srcFile.ImportAlias(packageHttp, aliasNetHTTP)
+	srcFile.ImportName(packageIO, "io")
 	srcFile.ImportName(packageContext, "context")
 	srcFile.ImportName(packageSync, "sync")
 	srcFile.ImportName(packageStrings, "strings")
 	srcFile.ImportName(svc.tr.tags.Value(tagPackageJSON, packageStdJSON), "json")
 
-	srcFile.Add(batchDoFunc(batchTarget{receiver: "http", receiverType: "http" + svc.Name}))
+	srcFile.Add(svc.tr.batchDoFunc(batchTarget{receiver: "http", receiverType: "http" + svc.Name}))
+	srcFile.Add(svc.tr.batchParallelFunc(batchTarget{receiver: "http", receiverType: "http" + svc.Name}))
 	srcFile.Add(svc.singleBatchFunc())
-	srcFile.Add(svc.serveBatchFunc())
+	if svc.tr.isNetHTTP() {
+		srcFile.Add(svc.tr.serveBatchNetHTTPFunc(batchTarget{receiver: "http", receiverType: "http" + svc.Name}))
+	} else {
+		srcFile.Add(svc.serveBatchFunc())
+	}
 
 	return srcFile.Save(path.Join(outDir, svc.lcName()+"-batch.go"))
 }
 
 func (svc *service) rpcMethodFunc(method *method, outDir string) Code {
 
-	return Func().Params(Id("http").Op("*").Id("http"+svc.Name)).Id(method.lccName()).
-		Params(Id("userCtx").Qual(packageContext, "Context"), Id("ftx").Op("*").Qual(packageFiber, "Ctx"), Id("requestBase").Id("baseJsonRPC")).
+	return Func().Params(Id("http").Op("*").Id("http" + svc.Name)).Id(method.lccName()).
+		Params(svc.tr.handlerParams("requestBase")...).
 		Params(Id("responseBase").Op("*").Id("baseJsonRPC")).BlockFunc(func(bg *Group) {
 		bg.Line()
 		bg.Var().Err().Error()
@@ -88,7 +107,7 @@ func (svc *service) rpcMethodFunc(method *method, outDir string) Code {
 			ig.Return(Id("makeErrorResponseJsonRPC").Call(Id("requestBase").Dot("ID"), Id("parseError"), Lit("incorrect protocol version: ").Op("+").Id("requestBase").Dot("Version"), Nil()))
 		})
 		if method.hasFiberRequest() {
-			bg.If(Id("ftx").Op("!=").Nil()).Block(
+			bg.If(svc.tr.hasExchange("ftx")).Block(
 				Add(method.httpArgHeaders("ftx", func(arg, header string) *Statement {
 					return Line().If(Err().Op("!=").Nil()).Block(
 						Return(Id("makeErrorResponseJsonRPC").Call(Id("requestBase").Dot("ID"), Id("parseError"), Lit(fmt.Sprintf("http header '%s' could not be decoded: ", header)).Op("+").Err().Dot("Error").Call(), Nil())),
@@ -126,6 +145,9 @@ func (svc *service) rpcMethodFunc(method *method, outDir string) Code {
 			)
 			ig.Return(Id("makeErrorResponseJsonRPC").Call(Id("requestBase").Dot("ID"), Id("code"), Err().Dot("Error").Call(), Err()))
 		})
+		bg.If(Id("requestBase").Dot("ID").Op("==").Nil()).Block(
+			Return(),
+		)
 		bg.Id("responseBase").Op("=").Op("&").Id("baseJsonRPC").Values(Dict{
 			Id("Version"): Id("Version"),
 			Id("ID"):      Id("requestBase").Dot("ID"),
@@ -139,20 +161,20 @@ func (svc *service) rpcMethodFunc(method *method, outDir string) Code {
 			ig.Return(Id("makeErrorResponseJsonRPC").Call(Id("requestBase").Dot("ID"), Id("parseError"), Lit("response body could not be encoded: ").Op("+").Err().Dot("Error").Call(), Nil()))
 		})
 		if len(method.retCookieMap()) > 0 {
-			bg.If(Id("ftx").Op("!=").Nil()).BlockFunc(func(cg *Group) {
+			bg.If(svc.tr.hasExchange("ftx")).BlockFunc(func(cg *Group) {
 				for retName := range method.retCookieMap() {
 					if ret := method.resultByName(retName); ret != nil {
 						cg.If(List(Id("rCookie"), Id("ok")).Op(":=").
 							Qual(packageReflect, "ValueOf").Call(Id("response").Dot(utils.ToCamel(retName))).Dot("Interface").Call().
 							Op(".").Call(Id("cookieType"))).Op(";").Id("ok").Op("&&").Id("response").Dot(utils.ToCamel(retName)).Op("!=").Nil().Block(
-							Id("ftx").Dot("Cookie").Call(Id("rCookie").Dot("Cookie").Call()),
+							svc.tr.setResponseCookie("ftx", Id("rCookie").Dot("Cookie").Call()),
 						)
 					}
 				}
 			})
 		}
 		if method.hasFiberRetHeaders() {
-			bg.If(Id("ftx").Op("!=").Nil()).Block(
+			bg.If(svc.tr.hasExchange("ftx")).Block(
 				Add(method.httpRetHeaders("ftx")),
 			)
 		}
@@ -164,7 +186,7 @@ func (svc *service) serveMethodFunc() Code {
 
 	return Func().Params(Id("http").Op("*").Id("http" + svc.Name)).Id("_serveMethod").
 		ParamsFunc(func(pg *Group) {
-			pg.Id(_ctx_).Op("*").Qual(packageFiber, "Ctx")
+			pg.Add(svc.tr.fiberCtx(_ctx_))
 			pg.Id("methodName").String()
 			pg.Id("methodHandler").Id("methodJsonRPC")
 		}).
@@ -172,8 +194,8 @@ func (svc *service) serveMethodFunc() Code {
 		BlockFunc(func(bg *Group) {
 			bg.Line()
 			bg.Id("methodHTTP").Op(":=").Id(_ctx_).Dot("Method").Call()
-			bg.If(Id("methodHTTP").Op("!=").Qual(packageFiber, "MethodPost")).BlockFunc(func(ig *Group) {
-				ig.Id(_ctx_).Dot("Response").Call().Dot("SetStatusCode").Call(Qual(packageFiber, "StatusMethodNotAllowed"))
+			bg.If(Id("methodHTTP").Op("!=").Qual(svc.tr.fiberPkg(), "MethodPost")).BlockFunc(func(ig *Group) {
+				ig.Id(_ctx_).Dot("Response").Call().Dot("SetStatusCode").Call(Qual(svc.tr.fiberPkg(), "StatusMethodNotAllowed"))
 				ig.If(List(Id("_"), Err()).Op("=").Id(_ctx_).Dot("WriteString").Call(Lit("only POST method supported")).Op(";").Err().Op("!=").Nil()).Block(
 					Return(),
 				)
@@ -186,21 +208,22 @@ func (svc *service) serveMethodFunc() Code {
 			bg.Id("methodNameOrigin").Op(":=").Id("request").Dot("Method")
 			bg.Id("method").Op(":=").Qual(packageStrings, "ToLower").Call(Id("request").Dot("Method"))
 
-			bg.If(Id("method").Op("!=").Lit("").Op("&&").Id("method").Op("!=").Id("methodName")).BlockFunc(func(ig *Group) {
-				ig.Return().Id("sendResponse").Call(Id(_ctx_), Id("makeErrorResponseJsonRPC").Call(Id("request").Dot("ID"), Id("methodNotFoundError"), Lit("invalid method ").Op("+").Id("methodNameOrigin"), Nil()))
-			})
-			bg.Id("response").Op("=").Id("methodHandler").Call(Id(_ctx_).Dot("UserContext").Call(), Id(_ctx_), Id("request"))
+			bg.If(Id("method").Op("!=").Lit("").Op("&&").Id("method").Op("!=").Id("methodName")).Block(
+				Id("response").Op("=").Id("makeErrorResponseJsonRPC").Call(Id("request").Dot("ID"), Id("methodNotFoundError"), Lit("invalid method ").Op("+").Id("methodNameOrigin"), Nil()),
+			).Else().Block(
+				Id("response").Op("=").Id("methodHandler").Call(svc.tr.userContext(_ctx_), Id(_ctx_), Id("request")),
+			)
 			bg.If(Id("response").Op("!=").Nil()).Block(
 				Return().Id("sendResponse").Call(Id(_ctx_), Id("response")),
 			)
-			bg.Return()
+			bg.Return(svc.tr.sendNoContent())
 		})
 }
 
 func (svc *service) singleBatchFunc() Code {
 
-	return Func().Params(Id("http").Op("*").Id("http"+svc.Name)).Id("doSingleBatch").
-		Params(Id("userCtx").Qual(packageContext, "Context"), Id("ftx").Op("*").Qual(packageFiber, "Ctx"), Id("request").Id("baseJsonRPC")).Params(Id("response").Op("*").Id("baseJsonRPC")).BlockFunc(
+	return Func().Params(Id("http").Op("*").Id("http" + svc.Name)).Id("doSingleBatch").
+		Params(svc.tr.handlerParams("request")...).Params(Id("response").Op("*").Id("baseJsonRPC")).BlockFunc(
 		func(bg *Group) {
 			bg.Line()
 			bg.Id("methodNameOrigin").Op(":=").Id("request").Dot("Method")
@@ -212,7 +235,7 @@ func (svc *service) singleBatchFunc() Code {
 							continue
 						}
 						sg.Case(Lit(method.lcName())).Block(
-							Return(Id("http").Dot(utils.ToLowerCamel(method.Name)).Call(Id("userCtx"), Id("ftx"), Id("request"))),
+							Return(Id("http").Dot(utils.ToLowerCamel(method.Name)).Call(svc.tr.handlerArgs("request")...)),
 						)
 					}
 					sg.Default().BlockFunc(func(dg *Group) {
@@ -225,14 +248,14 @@ func (svc *service) singleBatchFunc() Code {
 func (svc *service) serveBatchFunc() Code {
 
 	return Func().Params(Id("http").Op("*").Id("http" + svc.Name)).Id("serveBatch").
-		Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx")).Params(Id("err").Error()).BlockFunc(
+		Params(svc.tr.fiberCtx(_ctx_)).Params(Id("err").Error()).BlockFunc(
 		func(bg *Group) {
 			bg.Line()
 			bg.Var().Id("single").Bool()
 			bg.Var().Id("requests").Op("[]").Id("baseJsonRPC")
 			bg.Id("methodHTTP").Op(":=").Id(_ctx_).Dot("Method").Call()
-			bg.If(Id("methodHTTP").Op("!=").Qual(packageFiber, "MethodPost")).BlockFunc(func(ig *Group) {
-				ig.Id(_ctx_).Dot("Response").Call().Dot("SetStatusCode").Call(Qual(packageFiber, "StatusMethodNotAllowed"))
+			bg.If(Id("methodHTTP").Op("!=").Qual(svc.tr.fiberPkg(), "MethodPost")).BlockFunc(func(ig *Group) {
+				ig.Id(_ctx_).Dot("Response").Call().Dot("SetStatusCode").Call(Qual(svc.tr.fiberPkg(), "StatusMethodNotAllowed"))
 				ig.If(List(Id("_"), Err()).Op("=").Id(_ctx_).Dot("WriteString").Call(Lit("only POST method supported")).Op(";").Err().Op("!=").Nil()).Block(
 					Return(),
 				)
@@ -247,12 +270,14 @@ func (svc *service) serveBatchFunc() Code {
 				ig.Id("requests").Op("=").Append(Id("requests"), Id("request"))
 			})
 			bg.If(Id("single")).Block(
-				Return(Id("sendResponse").Call(Id(_ctx_), Id("http").Dot("doSingleBatch").
-					Call(Id(_ctx_).Dot("UserContext").Call(), Id(_ctx_), Id("requests").Op("[").Lit(0).Op("]")),
-				)),
+				If(Id("response").Op(":=").Id("http").Dot("doSingleBatch").Call(svc.tr.userContext(_ctx_), Id(_ctx_), Id("requests").Op("[").Lit(0).Op("]")).Op(";").Id("response").Op("!=").Nil()).Block(
+					Return(Id("sendResponse").Call(Id(_ctx_), Id("response"))),
+				),
+				Return(svc.tr.sendNoContent()),
+			)
+			bg.If(Id("responses").Op(":=").Id("http").Dot("doBatch").Call(Id(_ctx_), Id("requests")).Op(";").Len(Id("responses")).Op("!=").Lit(0)).Block(
+				Return(Id("sendResponse").Call(Id(_ctx_), Id("responses"))),
 			)
-			bg.Return(Id("sendResponse").Call(Id(_ctx_), Id("http").Dot("doBatch").
-				Call(Id(_ctx_), Id("requests")),
-			))
+			bg.Return(svc.tr.sendNoContent())
 		})
 }
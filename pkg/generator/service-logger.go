@@ -17,10 +17,10 @@ import (
 
 func (svc *service) renderLogger(outDir string) (err error) {
 
-	if err = pkgCopyTo("viewer", outDir); err != nil {
+	if err = pkgCopyTo(svc.tr.output(), "viewer", outDir); err != nil {
 		return err
 	}
-	srcFile := newSrc(filepath.Base(outDir))
+	srcFile := svc.tr.newSrc(filepath.Base(outDir))
 	srcFile.PackageComment(doNotEdit)
 
 	ctx := context.WithValue(context.Background(), keyCode, srcFile) // nolint
@@ -35,6 +35,7 @@ func (svc *service) renderLogger(outDir string) (err error) {
 	)
 
 	srcFile.Line().Add(svc.loggerMiddleware())
+	srcFile.Line().Add(svc.tr.overridable(&srcFile, OverrideLogger, svc, svc.loggerFieldsFunc()))
 
 	for _, method := range svc.methods {
 		srcFile.Line().Func().Params(Id("m").Id("logger" + svc.Name)).Id(method.Name).Params(funcDefinitionParams(ctx, method.Args)).Params(funcDefinitionParams(ctx, method.Results)).BlockFunc(svc.loggerFuncBody(method, outDir))
@@ -53,6 +54,14 @@ func (svc *service) loggerMiddleware() Code {
 	)
 }
 
+func (svc *service) loggerFieldsFunc() Code {
+
+	return Func().Params(Id("m").Id("logger"+svc.Name)).Id("logFields").Params(Id(_ctx_).Qual(packageContext, "Context"), Id("ev").Op("*").Qual(packageZeroLog, "Event"), Id("fields").Map(String()).Interface(), Id("begin").Qual(packageTime, "Time")).Block(
+		Id("ev").Dot("Fields").Call(Id("fields")).
+			Dot("Str").Call(Lit("took"), Qual(packageTime, "Since").Call(Id("begin")).Dot("String").Call()),
+	)
+}
+
 func (svc *service) loggerFuncBody(method *method, outDir string) func(g *Group) {
 
 	return func(g *Group) {
@@ -81,8 +90,7 @@ func (svc *service) loggerFuncBody(method *method, outDir string) func(g *Group)
 						d[Lit("response")] = Qual(fmt.Sprintf("%s/viewer", svc.tr.pkgPath(outDir)), "Sprintf").Call(Lit("%+v"), Id(method.responseStructName()).Values(utils.DictByNormalVariables(returns, originReturns)))
 					}
 				}))
-				fg.Id("ev").Dot("Fields").Call(Id("fields")).
-					Dot("Str").Call(Lit("took"), Qual(packageTime, "Since").Call(Id("_begin")).Dot("String").Call())
+				fg.Id("m").Dot("logFields").Call(Id(_ctx_), Id("ev"), Id("fields"), Id("_begin"))
 			})
 			g.If(Id("err").Op("!=").Id("nil")).BlockFunc(func(g *Group) {
 				g.Id("logger").Dot("Error").Call().Dot("Err").Call(Err()).Dot("Func").Call(Id("logHandle")).Dot("Msg").Call(Lit(fmt.Sprintf("call %s", method.lccName())))
@@ -13,7 +13,7 @@ import (
 
 func (svc *service) renderMetrics(outDir string) (err error) {
 
-	srcFile := newSrc(filepath.Base(outDir))
+	srcFile := svc.tr.newSrc(filepath.Base(outDir))
 	srcFile.PackageComment(doNotEdit)
 
 	ctx := context.WithValue(context.Background(), keyCode, srcFile) // nolint
@@ -52,7 +52,7 @@ func (svc *service) metricFuncBody(method *method) func(g *Group) {
 		errCodeAssignment := Id("errCode").Op("=")
 
 		if method.isHTTP() {
-			errCodeAssignment.Qual(packageFiber, "StatusInternalServerError")
+			errCodeAssignment.Add(svc.tr.httpStatus("StatusInternalServerError"))
 		} else {
 			errCodeAssignment.Id("internalError")
 		}
@@ -0,0 +1,149 @@
+package generator
+
+import (
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+
+	"github.com/seniorGolang/tg/v2/pkg/utils"
+)
+
+// setRoutesNetHTTPFunc returns SetRoutes of service for BackendNetHTTP, method of request is a part of route pattern.
+func (svc *service) setRoutesNetHTTPFunc() Code {
+
+	return Func().Params(Id("http").Op("*").Id("http" + svc.Name)).Id("SetRoutes").Params(Id("route").Op("*").Qual(packageHttp, "ServeMux")).BlockFunc(func(bg *Group) {
+		if svc.tags.Contains(tagServerJsonRPC) {
+			bg.Id("route").Dot("HandleFunc").Call(Lit(muxPattern("POST", svc.batchPath())), Id("http").Dot("serveBatch"))
+			for _, method := range svc.methods {
+				if !method.isJsonRPC() {
+					continue
+				}
+				bg.Id("route").Dot("HandleFunc").Call(Lit(muxPattern("POST", method.jsonrpcPath())), Id("http").Dot("serve"+method.Name))
+			}
+		}
+		if svc.tags.Contains(tagServerHTTP) {
+			for _, method := range svc.methods {
+				if !method.isHTTP() {
+					continue
+				}
+				pattern := Lit(muxPattern(method.httpMethod(), method.httpPath()))
+				if method.tags.Contains(tagHandler) {
+					bg.Id("route").Dot("HandleFunc").Call(pattern, Func().Params(svc.tr.exchangeParams(_ctx_)...).Block(
+						If(Err().Op(":=").Qual(method.handlerQual()).Call(Id("w"), Id("r"), Id("http").Dot("base")).Op(";").Err().Op("!=").Nil()).Block(
+							Id("sendError").Call(Id("w"), Id("r"), Err()),
+						),
+					))
+					continue
+				}
+				bg.Id("route").Dot("HandleFunc").Call(pattern, Id("http").Dot("serve"+method.Name))
+			}
+		}
+	})
+}
+
+func (svc *service) serveMethodNetHTTPFunc() Code {
+
+	parseError := func(id Code, code, message Code) Code {
+		return Id("sendResponse").Call(Id("w"), Id("r"), Qual(packageHttp, "StatusOK"), Id("makeErrorResponseJsonRPC").Call(id, code, message, Nil()))
+	}
+	return Func().Params(Id("http").Op("*").Id("http" + svc.Name)).Id("_serveMethod").
+		ParamsFunc(func(pg *Group) {
+			for _, param := range svc.tr.exchangeParams(_ctx_) {
+				pg.Add(param)
+			}
+			pg.Id("methodName").String()
+			pg.Id("methodHandler").Id("methodJsonRPC")
+		}).
+		BlockFunc(func(bg *Group) {
+			bg.Line()
+			bg.Var().Id("request").Id("baseJsonRPC")
+			bg.Var().Id("response").Op("*").Id("baseJsonRPC")
+			bg.List(Id("body"), Err()).Op(":=").Qual(packageIO, "ReadAll").Call(Id("r").Dot("Body"))
+			bg.If(Err().Op("==").Nil()).Block(
+				Err().Op("=").Qual(svc.tr.tags.Value(tagPackageJSON, packageStdJSON), "Unmarshal").Call(Id("body"), Op("&").Id("request")),
+			)
+			bg.If(Err().Op("!=").Nil()).Block(
+				parseError(Op("[]").Byte().Call(Lit(`"0"`)), Id("parseError"), Lit("request body could not be decoded: ").Op("+").Err().Dot("Error").Call()),
+				Return(),
+			)
+			bg.Id("methodNameOrigin").Op(":=").Id("request").Dot("Method")
+			bg.Id("method").Op(":=").Qual(packageStrings, "ToLower").Call(Id("request").Dot("Method"))
+
+			bg.If(Id("method").Op("!=").Lit("").Op("&&").Id("method").Op("!=").Id("methodName")).Block(
+				Id("response").Op("=").Id("makeErrorResponseJsonRPC").Call(Id("request").Dot("ID"), Id("methodNotFoundError"), Lit("invalid method ").Op("+").Id("methodNameOrigin"), Nil()),
+			).Else().Block(
+				Id("response").Op("=").Id("methodHandler").Call(Id("r").Dot("Context").Call(), Id("w"), Id("r"), Id("request")),
+			)
+			bg.If(Id("response").Op("==").Nil()).Block(
+				svc.tr.sendNoContent(),
+				Return(),
+			)
+			bg.Id("sendResponse").Call(Id("w"), Id("r"), Qual(packageHttp, "StatusOK"), Id("response"))
+		})
+}
+
+func (svc *service) httpServeMethodNetHTTPFunc(method *method) Code {
+
+	badRequest := func(message string) func(arg, header string) *Statement {
+		return func(arg, header string) *Statement {
+			return Line().If(Err().Op("!=").Nil()).Block(
+				Id("sendResponse").Call(Id("w"), Id("r"), Qual(packageHttp, "StatusBadRequest"), Lit(message+" could not be decoded: ").Op("+").Err().Dot("Error").Call()),
+				Return(),
+			)
+		}
+	}
+	return Func().Params(Id("http").Op("*").Id("http" + svc.Name)).Id("serve" + method.Name).Params(svc.tr.exchangeParams(_ctx_)...).BlockFunc(func(bg *Group) {
+
+		bg.Line()
+		bg.Var().Err().Error()
+		bg.Var().Id("request").Id(method.requestStructName())
+		if len(method.arguments()) != 0 {
+			bg.Var().Id("body").Op("[]").Byte()
+			bg.If(List(Id("body"), Err()).Op("=").Qual(packageIO, "ReadAll").Call(Id("r").Dot("Body")).Op(";").Err().Op("==").Nil().Op("&&").Len(Id("body")).Op("!=").Lit(0)).Block(
+				Err().Op("=").Qual(svc.tr.tags.Value(tagPackageJSON, packageStdJSON), "Unmarshal").Call(Id("body"), Op("&").Id("request")),
+			)
+			bg.Add(badRequest("request body")("", ""))
+		}
+		bg.Add(method.urlArgs(badRequest("path arguments")))
+		bg.Add(method.urlParams(badRequest("url arguments")))
+		bg.Add(method.httpArgHeaders(_ctx_, badRequest("http header")))
+		bg.Add(method.httpCookies(_ctx_, badRequest("http header")))
+		if responseMethod := method.tags.Value(tagHttpResponse, ""); responseMethod != "" {
+			bg.If(Err().Op("=").Add(toID(responseMethod)).Call(Id("w"), Id("r"), Id("http").Dot("svc"), callParamNames("request", method.argsWithoutContext())).Op(";").Err().Op("!=").Nil()).Block(
+				Id("sendError").Call(Id("w"), Id("r"), Err()),
+			)
+			return
+		}
+		status := Qual(packageHttp, "StatusOK")
+		if successCode := method.tags.ValueInt(tagHttpSuccess, 0); successCode != 0 {
+			status = Lit(successCode)
+		}
+		bg.Var().Id("response").Id(method.responseStructName())
+		bg.If().List(Id("response"), Err()).Op("=").Id("http").Dot(method.lccName()).Call(Id("r").Dot("Context").Call(), Id("request")).Op(";").Err().Op("==").Nil().BlockFunc(func(bf *Group) {
+			var ex Statement
+			for retName := range method.retCookieMap() {
+				if ret := method.resultByName(retName); ret != nil {
+					ex.If(List(Id("rCookie"), Id("ok")).Op(":=").
+						Qual(packageReflect, "ValueOf").Call(Id("response").Dot(utils.ToCamel(retName))).Dot("Interface").Call().
+						Op(".").Call(Id("cookieType"))).Op(";").Id("ok").Op("&&").Id("response").Dot(utils.ToCamel(retName)).Op("!=").Nil().Block(
+						svc.tr.setResponseCookie(_ctx_, Id("rCookie").Dot("Cookie").Call()),
+					)
+				}
+			}
+			ex.Add(method.httpRetHeaders(_ctx_))
+			bf.Var().Id("iResponse").Interface().Op("=").Id("response")
+			bf.If(List(Id("redirect"), Id("ok")).Op(":=").Id("iResponse").Op(".").Call(Id("withRedirect")).Op(";").Id("ok")).Block(
+				Qual(packageHttp, "Redirect").Call(Id("w"), Id("r"), Id("redirect").Dot("RedirectTo").Call(), Qual(packageHttp, "StatusFound")),
+				Return(),
+			)
+			if len(ex) > 0 {
+				bf.Add(&ex)
+			}
+			if len(method.resultsWithoutError()) == 1 && method.tags.IsSet(tagHttpEnableInlineSingle) {
+				bf.Id("sendResponse").Call(Id("w"), Id("r"), status, Id("response").Dot(utils.ToCamel(method.resultsWithoutError()[0].Name)))
+			} else {
+				bf.Id("sendResponse").Call(Id("w"), Id("r"), status, Id("response"))
+			}
+			bf.Return()
+		})
+		bg.Id("sendError").Call(Id("w"), Id("r"), Err())
+	})
+}
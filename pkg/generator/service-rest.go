@@ -17,10 +17,12 @@ import (
 
 func (svc *service) renderREST(outDir string) (err error) {
 
-	srcFile := newSrc(filepath.Base(outDir))
+	srcFile := svc.tr.newSrc(filepath.Base(outDir))
 	srcFile.PackageComment(doNotEdit)
 
-	srcFile.ImportName(packageFiber, "fiber")
+	srcFile.ImportName(svc.tr.fiberPkg(), "fiber")
+	srcFile.ImportAlias(packageHttp, aliasNetHTTP)
+	srcFile.ImportName(packageIO, "io")
 	srcFile.ImportName(packageZeroLog, "zerolog")
 	srcFile.ImportName(svc.pkgPath, filepath.Base(svc.pkgPath))
 	srcFile.ImportName(svc.tr.tags.Value(tagPackageJSON, packageStdJSON), "json")
@@ -30,6 +32,10 @@ func (svc *service) renderREST(outDir string) (err error) {
 			continue
 		}
 		srcFile.Add(svc.httpMethodFunc(method))
+		if svc.tr.isNetHTTP() {
+			srcFile.Add(svc.httpServeMethodNetHTTPFunc(method))
+			continue
+		}
 		srcFile.Add(svc.httpServeMethodFunc(method))
 	}
 	return srcFile.Save(path.Join(outDir, svc.lcName()+"-rest.go"))
@@ -68,7 +74,7 @@ func (svc *service) httpMethodFunc(method *method) Code {
 
 func (svc *service) httpServeMethodFunc(method *method) Code {
 
-	return Func().Params(Id("http").Op("*").Id("http" + svc.Name)).Id("serve" + method.Name).Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx")).
+	return Func().Params(Id("http").Op("*").Id("http" + svc.Name)).Id("serve" + method.Name).Params(svc.tr.fiberCtx(_ctx_)).
 		Params(Err().Error()).BlockFunc(func(bg *Group) {
 
 		bg.Line()
@@ -77,33 +83,33 @@ func (svc *service) httpServeMethodFunc(method *method) Code {
 			bg.Id(_ctx_).Dot("Response").Call().Dot("SetStatusCode").Call(Lit(successCode))
 		}
 		if len(method.arguments()) != 0 {
-			bg.If(Err().Op("=").Id(_ctx_).Dot("BodyParser").Call(Op("&").Id("request")).Op(";").Err().Op("!=").Nil()).BlockFunc(func(ig *Group) {
-				ig.Id(_ctx_).Dot("Response").Call().Dot("SetStatusCode").Call(Qual(packageFiber, "StatusBadRequest"))
+			bg.If(Err().Op("=").Add(svc.tr.bodyParser(_ctx_, Op("&").Id("request"))).Op(";").Err().Op("!=").Nil()).BlockFunc(func(ig *Group) {
+				ig.Id(_ctx_).Dot("Response").Call().Dot("SetStatusCode").Call(Qual(svc.tr.fiberPkg(), "StatusBadRequest"))
 				ig.List(Id("_"), Err()).Op("=").Id(_ctx_).Dot("WriteString").Call(Lit("request body could not be decoded: ").Op("+").Err().Dot("Error").Call())
 				ig.Return()
 			})
 		}
 		bg.Add(method.urlArgs(func(arg, header string) *Statement {
 			return Line().If(Err().Op("!=").Nil()).BlockFunc(func(ig *Group) {
-				ig.Id(_ctx_).Dot("Status").Call(Qual(packageFiber, "StatusBadRequest"))
+				ig.Id(_ctx_).Dot("Status").Call(Qual(svc.tr.fiberPkg(), "StatusBadRequest"))
 				ig.Return().Id("sendResponse").Call(Id(_ctx_), Lit("path arguments could not be decoded: ").Op("+").Err().Dot("Error").Call())
 			})
 		}))
 		bg.Add(method.urlParams(func(arg, header string) *Statement {
 			return Line().If(Err().Op("!=").Nil()).BlockFunc(func(ig *Group) {
-				ig.Id(_ctx_).Dot("Status").Call(Qual(packageFiber, "StatusBadRequest"))
+				ig.Id(_ctx_).Dot("Status").Call(Qual(svc.tr.fiberPkg(), "StatusBadRequest"))
 				ig.Return().Id("sendResponse").Call(Id(_ctx_), Lit("url arguments could not be decoded: ").Op("+").Err().Dot("Error").Call())
 			})
 		}))
 		bg.Add(method.httpArgHeaders(_ctx_, func(arg, header string) *Statement {
 			return Line().If(Err().Op("!=").Nil()).BlockFunc(func(ig *Group) {
-				ig.Id(_ctx_).Dot("Status").Call(Qual(packageFiber, "StatusBadRequest"))
+				ig.Id(_ctx_).Dot("Status").Call(Qual(svc.tr.fiberPkg(), "StatusBadRequest"))
 				ig.Return().Id("sendResponse").Call(Id(_ctx_), Lit("http header could not be decoded: ").Op("+").Err().Dot("Error").Call())
 			})
 		}))
 		bg.Add(method.httpCookies(_ctx_, func(arg, header string) *Statement {
 			return Line().If(Err().Op("!=").Nil()).BlockFunc(func(ig *Group) {
-				ig.Id(_ctx_).Dot("Status").Call(Qual(packageFiber, "StatusBadRequest"))
+				ig.Id(_ctx_).Dot("Status").Call(Qual(svc.tr.fiberPkg(), "StatusBadRequest"))
 				ig.Return().Id("sendResponse").Call(Id(_ctx_), Lit("http header could not be decoded: ").Op("+").Err().Dot("Error").Call())
 			})
 		}))
@@ -111,7 +117,7 @@ func (svc *service) httpServeMethodFunc(method *method) Code {
 			bg.Return().Add(toID(responseMethod).Call(Id(_ctx_), Id("http").Dot("svc"), callParamNames("request", method.argsWithoutContext())))
 		} else {
 			bg.Var().Id("response").Id(method.responseStructName())
-			bg.If().List(Id("response"), Err()).Op("=").Id("http").Dot(method.lccName()).Call(Id(_ctx_).Dot("UserContext").Call(), Id("request")).Op(";").Err().Op("==").Nil().BlockFunc(func(bf *Group) {
+			bg.If().List(Id("response"), Err()).Op("=").Id("http").Dot(method.lccName()).Call(svc.tr.userContext(_ctx_), Id("request")).Op(";").Err().Op("==").Nil().BlockFunc(func(bf *Group) {
 				var ex Statement
 				if len(method.retCookieMap()) > 0 {
 					for retName := range method.retCookieMap() {
@@ -127,7 +133,7 @@ func (svc *service) httpServeMethodFunc(method *method) Code {
 				ex.Add(method.httpRetHeaders(_ctx_))
 				bf.Var().Id("iResponse").Interface().Op("=").Id("response")
 				bf.If(List(Id("redirect"), Id("ok")).Op(":=").Id("iResponse").Op(".").Call(Id("withRedirect")).Op(";").Id("ok")).Block(
-					Return().Id(_ctx_).Dot("Redirect").Call(Id("redirect").Dot("RedirectTo").Call()),
+					Return().Add(svc.tr.redirect(_ctx_, Id("redirect").Dot("RedirectTo").Call())),
 				)
 				if len(ex) > 0 {
 					bf.Add(&ex)
@@ -138,12 +144,7 @@ func (svc *service) httpServeMethodFunc(method *method) Code {
 					bf.Return().Id("sendResponse").Call(Id(_ctx_), Id("response"))
 				}
 			})
-			bg.If(List(Id("errCoder"), Id("ok")).Op(":=").Err().Op(".").Call(Id("withErrorCode")).Op(";").Id("ok")).Block(
-				Id(_ctx_).Dot("Status").Call(Id("errCoder").Dot("Code").Call()),
-			).Else().Block(
-				Id(_ctx_).Dot("Status").Call(Qual(packageFiber, "StatusInternalServerError")),
-			)
-			bg.Return().Id("sendResponse").Call(Id(_ctx_), Err())
+			bg.Return().Id("sendError").Call(Id(_ctx_), Err())
 		}
 	})
 }
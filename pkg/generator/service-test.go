@@ -14,7 +14,7 @@ import (
 func (svc *service) renderTest(outDir string) (err error) {
 
 	outDir, _ = filepath.Abs(outDir)
-	srcFile := newSrc(filepath.Base(outDir))
+	srcFile := svc.tr.newSrc(filepath.Base(outDir))
 	srcFile.ImportName(packageTesting, "testing")
 
 	for _, method := range svc.methods {
@@ -11,6 +11,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/seniorGolang/tg/v2/pkg/astra/types"
+	"github.com/seniorGolang/tg/v2/pkg/diagnostic"
 
 	"github.com/seniorGolang/tg/v2/pkg/tags"
 	"github.com/seniorGolang/tg/v2/pkg/utils"
@@ -25,11 +26,12 @@ type service struct {
 	methods []*method
 	tr      *Transport
 	tags    tags.DocTags
+	diags   diagnostic.List
 
 	testsPath string
 }
 
-func newService(log logrus.FieldLogger, tr *Transport, filePath string, iface types.Interface) (svc *service) {
+func newService(log logrus.FieldLogger, tr *Transport, filePath string, iface types.Interface, local []types.Interface) (svc *service) {
 
 	svc = &service{
 		tr:        tr,
@@ -37,12 +39,10 @@ func newService(log logrus.FieldLogger, tr *Transport, filePath string, iface ty
 		Interface: iface,
 		tags:      tags.ParseTags(iface.Docs).Merge(tr.tags),
 	}
-	for _, method := range iface.Methods {
-		svc.methods = append(svc.methods, newMethod(log, svc, method))
-	}
 	absPath, _ := filepath.Abs(filepath.Dir(filePath))
 	svc.pkgPath, _ = utils.GetPkgPath(filepath.Dir(filePath), true)
 	svc.pkgPath = path.Join(svc.pkgPath, path.Dir(strings.TrimPrefix(filePath, absPath)))
+	svc.addMethods(local)
 
 	return
 }
@@ -66,39 +66,43 @@ func (svc *service) renderClient(outDir string) (err error) {
 	}
 	if svc.tags.Contains(tagServerJsonRPC) {
 		err = svc.renderClientJsonRPC(outDir)
-		showError(svc.log, svc.renderClientFallbackError(outDir), "renderFallback")
+		svc.showError(svc.renderClientFallbackError(outDir), "renderFallback")
 	}
 	if svc.tags.Contains(tagServerHTTP) {
-		showError(svc.log, svc.renderClientHTTP(outDir), "renderHTTP")
+		svc.showError(svc.renderClientHTTP(outDir), "renderHTTP")
 	}
 	return
 }
 
-func (svc *service) render(outDir string) (err error) {
+func (svc *service) render(outDir string) {
 
-	showError(svc.log, svc.renderHTTP(outDir), "renderHTTP")
-	showError(svc.log, svc.renderServer(outDir), "renderServer")
-	showError(svc.log, svc.renderExchange(outDir), "renderExchange")
-	showError(svc.log, svc.renderMiddleware(outDir), "renderMiddleware")
+	svc.showError(svc.renderHTTP(outDir), "renderHTTP")
+	svc.showError(svc.renderServer(outDir), "renderServer")
+	svc.showError(svc.renderExchange(outDir), "renderExchange")
+	svc.showError(svc.renderMiddleware(outDir), "renderMiddleware")
 	if svc.tags.Contains(tagTests) {
-		showError(svc.log, svc.renderTest(svc.testsPath), "renderTest")
+		svc.showError(svc.renderTest(svc.testsPath), "renderTest")
 	}
 	if svc.tags.Contains(tagTrace) {
-		showError(svc.log, svc.renderTrace(outDir), "renderTrace")
+		svc.showError(svc.renderTrace(outDir), "renderTrace")
 	}
 	if svc.tags.Contains(tagMetrics) {
-		showError(svc.log, svc.renderMetrics(outDir), "renderMetrics")
+		svc.showError(svc.renderMetrics(outDir), "renderMetrics")
 	}
 	if svc.tags.Contains(tagLogger) {
-		showError(svc.log, svc.renderLogger(outDir), "renderLogger")
+		svc.showError(svc.renderLogger(outDir), "renderLogger")
 	}
 	if svc.tags.Contains(tagServerJsonRPC) {
-		showError(svc.log, svc.renderJsonRPC(outDir), "renderJsonRPC")
+		svc.showError(svc.renderJsonRPC(outDir), "renderJsonRPC")
 	}
 	if svc.tags.Contains(tagServerHTTP) {
-		showError(svc.log, svc.renderREST(outDir), "renderREST")
+		svc.showError(svc.renderREST(outDir), "renderREST")
 	}
-	return
+}
+
+// showError reports failed render step of service at its interface.
+func (svc *service) showError(err error, msg string) {
+	svc.tr.showErrorAt(svc.Pos, err, msg)
 }
 
 func (svc *service) batchPath() string {
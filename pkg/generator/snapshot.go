@@ -0,0 +1,118 @@
+package generator
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+type snapshotFile struct {
+	data []byte
+	mode fs.FileMode
+}
+
+// dirSnapshot keeps contents of output directory to restore it after failed generation.
+// Symlinks and other non-regular entries are not read, they are only recorded to be left as is.
+type dirSnapshot struct {
+	root   string
+	exists bool
+	dirs   map[string]fs.FileMode
+	files  map[string]snapshotFile
+	others map[string]bool
+}
+
+func takeSnapshot(root string) (snapshot *dirSnapshot, err error) {
+
+	snapshot = &dirSnapshot{
+		root:   root,
+		dirs:   make(map[string]fs.FileMode),
+		files:  make(map[string]snapshotFile),
+		others: make(map[string]bool),
+	}
+	if _, err = os.Stat(root); os.IsNotExist(err) {
+		return snapshot, nil
+	}
+	snapshot.exists = true
+	err = filepath.WalkDir(root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		var info fs.FileInfo
+		if info, err = entry.Info(); err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			snapshot.dirs[filePath] = info.Mode().Perm()
+			return nil
+		}
+		if !info.Mode().IsRegular() {
+			snapshot.others[filePath] = true
+			return nil
+		}
+		var data []byte
+		if data, err = os.ReadFile(filePath); err != nil {
+			return err
+		}
+		snapshot.files[filePath] = snapshotFile{data: data, mode: info.Mode().Perm()}
+		return nil
+	})
+	return
+}
+
+// restore brings directory back to the snapshot state: new files are removed, changed and removed files are rewritten.
+// Non-regular entries of snapshot are left as is.
+func (snapshot *dirSnapshot) restore() (err error) {
+
+	if !snapshot.exists {
+		return os.RemoveAll(snapshot.root)
+	}
+	var created []string
+	err = filepath.WalkDir(snapshot.root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if _, found := snapshot.dirs[filePath]; !found {
+				created = append(created, filePath)
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if _, found := snapshot.files[filePath]; !found && !snapshot.others[filePath] {
+			return os.Remove(filePath)
+		}
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return
+	}
+	for _, dir := range created {
+		if err = os.RemoveAll(dir); err != nil {
+			return
+		}
+	}
+	dirs := make([]string, 0, len(snapshot.dirs))
+	for dir := range snapshot.dirs {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+	for _, dir := range dirs {
+		if err = os.MkdirAll(dir, snapshot.dirs[dir]); err != nil {
+			return
+		}
+	}
+	for filePath, file := range snapshot.files {
+		if current, readErr := os.ReadFile(filePath); readErr != nil || !bytes.Equal(current, file.data) {
+			if err = os.WriteFile(filePath, file.data, file.mode); err != nil {
+				return
+			}
+		}
+		// permissions of existing file are not changed by write
+		if err = os.Chmod(filePath, file.mode); err != nil {
+			return
+		}
+	}
+	return nil
+}
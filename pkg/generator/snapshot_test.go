@@ -0,0 +1,131 @@
+package generator
+
+import (
+	"errors"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+// readTree returns contents of files and targets of symlinks in dir by slash-separated relative paths.
+func readTree(t *testing.T, dir string) (tree map[string]string) {
+
+	tree = make(map[string]string)
+	err := filepath.WalkDir(dir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil || filePath == dir {
+			return err
+		}
+		name, _ := filepath.Rel(dir, filePath)
+		name = filepath.ToSlash(name)
+		switch {
+		case entry.IsDir():
+			tree[name+"/"] = ""
+		case entry.Type()&fs.ModeSymlink != 0:
+			target, err := os.Readlink(filePath)
+			tree[name] = "-> " + target
+			return err
+		default:
+			data, err := os.ReadFile(filePath)
+			tree[name] = string(data)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func newStrictTransport() *Transport {
+	return &Transport{log: logrus.New(), state: &renderState{strict: true}}
+}
+
+func TestStrictRenderRestoresOutput(t *testing.T) {
+
+	dir := t.TempDir()
+	outDir := filepath.Join(dir, "transport")
+	for name, content := range map[string]string{
+		"server.go":      "package transport\n",
+		"stale.go":       "package transport\n\nconst stale = 1\n",
+		"jsonrpc/rpc.go": "package jsonrpc\n",
+		"custom.txt":     "custom",
+	} {
+		writeFile(t, filepath.Join(outDir, name), content)
+	}
+	if err := os.Symlink("custom.txt", filepath.Join(outDir, "custom-link.txt")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(dir, filepath.Join(outDir, "root")); err != nil {
+		t.Fatal(err)
+	}
+	before := readTree(t, outDir)
+
+	tr := newStrictTransport()
+	snapshot, err := tr.beginRender(outDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(outDir, "server.go"), "package transport\n\n// changed\n")
+	writeFile(t, filepath.Join(outDir, "user-logger.go"), "package transport\n")
+	writeFile(t, filepath.Join(outDir, "metrics", "metrics.go"), "package metrics\n")
+	if err = os.Remove(filepath.Join(outDir, "stale.go")); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Chmod(filepath.Join(outDir, "jsonrpc", "rpc.go"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Symlink("server.go", filepath.Join(outDir, "new-link.go")); err != nil {
+		t.Fatal(err)
+	}
+	tr.showError(errors.New("type is not found"), "render user-logger.go")
+
+	if err = tr.endRender(snapshot, nil); err == nil {
+		t.Fatal("failed render step is not returned")
+	}
+	if after := readTree(t, outDir); !maps.Equal(before, after) {
+		t.Fatalf("output is not restored:\n%v\nexpected:\n%v", after, before)
+	}
+	if info, err := os.Stat(filepath.Join(outDir, "jsonrpc", "rpc.go")); err != nil || info.Mode().Perm() != 0600 {
+		t.Fatalf("mode of file is not restored: %v", info.Mode())
+	}
+}
+
+func TestStrictRenderRemovesNewOutput(t *testing.T) {
+
+	outDir := filepath.Join(t.TempDir(), "transport")
+	tr := newStrictTransport()
+	snapshot, err := tr.beginRender(outDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(outDir, "server.go"), "package transport\n")
+	if err = tr.endRender(snapshot, errors.New("render failed")); err == nil {
+		t.Fatal("error of render is not returned")
+	}
+	if _, err = os.Stat(outDir); !os.IsNotExist(err) {
+		t.Fatalf("output directory is not removed: %v", err)
+	}
+}
+
+func TestStrictRenderKeepsOutputOnSuccess(t *testing.T) {
+
+	outDir := filepath.Join(t.TempDir(), "transport")
+	writeFile(t, filepath.Join(outDir, "server.go"), "package transport\n")
+	tr := newStrictTransport()
+	snapshot, err := tr.beginRender(outDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(outDir, "server.go"), "package transport\n\n// changed\n")
+	if err = tr.endRender(snapshot, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(outDir, "server.go")); string(data) != "package transport\n\n// changed\n" {
+		t.Fatalf("generated file is restored: %q", data)
+	}
+}
@@ -4,7 +4,14 @@
 package generator
 
 import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+
 	"github.com/dave/jennifer/jen"
+	"golang.org/x/tools/go/ast/astutil"
 
 	"github.com/seniorGolang/tg/v2/pkg/goimports"
 	"github.com/seniorGolang/tg/v2/pkg/utils"
@@ -13,28 +20,56 @@ import (
 type goFile struct {
 	*jen.File
 	filepath string
+	out      Output
+	imports  []importSpec
 }
 
-func newSrc(pkgName string) goFile {
+func (tr *Transport) newSrc(pkgName string) goFile {
 	return goFile{
 		File: jen.NewFile(pkgName),
+		out:  tr.output(),
 	}
 }
 
 func (src *goFile) Save(filepath string) (err error) {
 
 	src.filepath = filepath
-	if err = src.File.Save(src.filepath); err != nil {
+	var code bytes.Buffer
+	if err = src.File.Render(&code); err != nil {
 		return
 	}
-
-	var runner goimports.Runner
-	if runner, err = goimports.NewFromFile(filepath); err != nil {
+	source := code.Bytes()
+	if len(src.imports) != 0 {
+		if source, err = src.addImports(source); err != nil {
+			return
+		}
+	}
+	var formatted bytes.Buffer
+	runner := goimports.NewFromFiles(goimports.File{Name: filepath, In: bytes.NewReader(source), Out: &formatted})
+	if err = runner.Run(utils.GetModulePath(filepath)); err != nil {
+		_ = src.out.WriteFile(filepath, source, 0644)
 		return
 	}
+	if formatted.Len() == 0 {
+		return src.out.WriteFile(filepath, source, 0644)
+	}
+	return src.out.WriteFile(filepath, formatted.Bytes(), 0644)
+}
 
-	if err = runner.Run(utils.GetModulePath(filepath)); err != nil {
+// addImports adds imports of overridden declarations, which are not tracked by jennifer.
+func (src *goFile) addImports(code []byte) (out []byte, err error) {
+
+	fileSet := token.NewFileSet()
+	var file *ast.File
+	if file, err = parser.ParseFile(fileSet, src.filepath, code, parser.ParseComments); err != nil {
+		return
+	}
+	for _, spec := range src.imports {
+		astutil.AddNamedImport(fileSet, file, spec.name, spec.path)
+	}
+	var formatted bytes.Buffer
+	if err = format.Node(&formatted, fileSet, file); err != nil {
 		return
 	}
-	return
+	return formatted.Bytes(), nil
 }
@@ -4,7 +4,6 @@
 package generator
 
 import (
-	"bufio"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -18,6 +17,7 @@ import (
 
 	"github.com/seniorGolang/tg/v2/pkg/astra/types"
 
+	"github.com/seniorGolang/tg/v2/pkg/diagnostic"
 	"github.com/seniorGolang/tg/v2/pkg/mod"
 	"github.com/seniorGolang/tg/v2/pkg/tags"
 	"github.com/seniorGolang/tg/v2/pkg/utils"
@@ -96,12 +96,17 @@ func (doc *swagger) walkVariable(typeName, pkgPath string, varType types.Type, v
 		schema.Items = &itemSchema
 	case types.Struct:
 		schema.Type = "object"
+		schema.Description = docText(vType.Docs)
 		schema.Properties = make(swProperties)
 		var inlined []swSchema
 		for _, field := range vType.Fields {
 			if fieldName, inline := jsonName(field); fieldName != "-" {
 				embed := doc.walkVariable(field.Type.String(), pkgPath, field.Type, tags.ParseTags(field.Docs))
 				if !inline {
+					// description next to reference is ignored by OpenAPI 3.0
+					if embed.Description == "" && embed.Ref == "" {
+						embed.Description = docText(field.Docs)
+					}
 					schema.Properties[fieldName] = embed
 					if isRequiredJSONField(field) {
 						schema.Required = append(schema.Required, fieldName)
@@ -139,7 +144,7 @@ func (doc *swagger) walkVariable(typeName, pkgPath string, varType types.Type, v
 		if doc.knownCount[typeName] > 0 {
 			return doc.toSchema(doc.normalizeTypeName(vType.TypeName, pkgPath))
 		}
-		if nextType := searchType(pkgPath, vType.TypeName); nextType != nil {
+		if nextType := doc.Transport.searchType(pkgPath, vType.TypeName); nextType != nil {
 
 			doc.knownCount[typeName]++
 			typeName = doc.normalizeTypeName(vType.String(), pkgPath)
@@ -150,12 +155,21 @@ func (doc *swagger) walkVariable(typeName, pkgPath string, varType types.Type, v
 		}
 
 	case types.TImport:
-		if nextType := searchType(vType.Import.Package, vType.Next.String()); nextType != nil {
+		if nextType := doc.Transport.searchType(vType.Import.Package, vType.Next.String()); nextType != nil {
 			if _, found = doc.schemas[typeName]; !found {
 				doc.schemas[doc.normalizeTypeName(vType.Next.String(), vType.Import.Package)] = doc.walkVariable(nextType.String(), vType.Import.Package, nextType, varTags)
 			}
 			return doc.toSchema(doc.normalizeTypeName(vType.Next.String(), vType.Import.Package))
 		}
+	case types.TInstance:
+		if instance, defPkg, found := doc.instantiate(vType, pkgPath); found {
+			instanceName := doc.normalizeTypeName(instance.Name, defPkg)
+			if _, found = doc.schemas[instanceName]; !found {
+				doc.schemas[instanceName] = doc.toSchema(instanceName)
+				doc.schemas[instanceName] = doc.walkVariable(instance.String(), defPkg, instance, varTags)
+			}
+			return doc.toSchema(instanceName)
+		}
 	case types.TEllipsis:
 		schema.Type = "array"
 		itemSchema := doc.walkVariable(vType.Next.String(), pkgPath, vType.Next, varTags)
@@ -165,6 +179,7 @@ func (doc *swagger) walkVariable(typeName, pkgPath string, varType types.Type, v
 		schema.Nullable = true
 	default:
 		doc.log.WithField("type", vType).Error("unknown type")
+		doc.state.diags = append(doc.state.diags, diagnostic.Warnf(doc.position(), ruleRender, "swagger: type %v cannot be described", vType))
 	}
 	return
 }
@@ -215,12 +230,21 @@ func (doc *swagger) toSchema(typeName string) (schema swSchema) {
 
 func (doc *swagger) searchType(pkg, name string) (retType types.Type) {
 
+	var found bool
+	if retType, _, found = doc.loadType(pkg, name); found {
+		return
+	}
 	if retType = doc.parseType(pkg, name); retType == nil {
-		pkgPath := mod.PkgModPath(pkg)
+		if localDir := mod.LocalPkgDir(doc.svcDir, pkg); localDir != "" {
+			if retType = doc.parseType(localDir, name); retType != nil {
+				return
+			}
+		}
+		pkgPath := mod.PkgModPath(doc.svcDir, pkg)
 		if retType = doc.parseType(pkgPath, name); retType == nil {
 			pkgPath = path.Join("./vendor", pkg)
 			if retType = doc.parseType(pkgPath, name); retType == nil {
-				pkgPath = doc.trimLocalPkg(pkg)
+				pkgPath = trimLocalPkg(doc.svcDir, pkg)
 				retType = doc.parseType(pkgPath, name)
 			}
 		}
@@ -228,9 +252,13 @@ func (doc *swagger) searchType(pkg, name string) (retType types.Type) {
 	return
 }
 
+// parseType looks up type in source files of directory, relative path is a path from project of services package.
 func (doc *swagger) parseType(relPath, name string) (retType types.Type) {
 
-	pkgPath, _ := filepath.Abs(relPath)
+	pkgPath := relPath
+	if !filepath.IsAbs(relPath) {
+		pkgPath = filepath.Join(mod.GoProjectPath(doc.svcDir), relPath)
+	}
 	_ = filepath.Walk(pkgPath, func(filePath string, info os.FileInfo, err error) (retErr error) {
 
 		if err != nil {
@@ -271,39 +299,6 @@ func (doc *swagger) parseType(relPath, name string) (retType types.Type) {
 	return
 }
 
-func (doc *swagger) trimLocalPkg(pkg string) (pgkPath string) {
-
-	module := doc.getModName()
-	if module == "" {
-		return pkg
-	}
-	moduleTokens := strings.Split(module, "/")
-	pkgTokens := strings.Split(pkg, "/")
-	if len(pkgTokens) < len(moduleTokens) {
-		return pkg
-	}
-	pgkPath = path.Join(strings.Join(pkgTokens[len(moduleTokens):], "/"))
-	return
-}
-
-func (doc *swagger) getModName() (module string) {
-	modFile, err := os.OpenFile("go.mod", os.O_RDONLY, os.ModePerm)
-	if err != nil {
-		return
-	}
-	defer func() { _ = modFile.Close() }()
-	rd := bufio.NewReader(modFile)
-	if module, err = rd.ReadString('\n'); err != nil {
-		return ""
-	}
-	module = strings.Trim(module, "\n")
-	moduleTokens := strings.Split(module, " ")
-	if len(moduleTokens) == 2 {
-		module = strings.TrimSpace(moduleTokens[1])
-	}
-	return
-}
-
 func castType(originName string) (typeName, format string) {
 
 	typeName = originName
@@ -24,6 +24,17 @@ func jsonrpcSchema(propName string, property swSchema) (schema swSchema) {
 	return
 }
 
+// jsonrpcNotificationSchema returns schema of request, which may omit id to be notification.
+func jsonrpcNotificationSchema(propName string, property swSchema) (schema swSchema) {
+
+	schema = jsonrpcSchema(propName, property)
+	id := schema.Properties["id"]
+	id.Description = "Request without id is notification, server executes it without response."
+	schema.Properties["id"] = id
+	schema.Required = []string{"jsonrpc", "result"}
+	return
+}
+
 func jsonrpcErrorSchema() (schema swSchema) {
 
 	schema = swSchema{
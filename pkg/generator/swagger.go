@@ -6,7 +6,6 @@ package generator
 import (
 	"encoding/json"
 	"fmt"
-	"os"
 	"path/filepath"
 	"reflect"
 	"slices"
@@ -58,7 +57,7 @@ func (doc *swagger) render(outFilePath string, ifaces ...string) (err error) {
 		err = fmt.Errorf("include and exclude cannot be set at same time (%v | %v)", include, exclude)
 		return
 	}
-	if err = os.MkdirAll(filepath.Dir(outFilePath), 0777); err != nil {
+	if err = doc.output().MkdirAll(filepath.Dir(outFilePath), 0777); err != nil {
 		return
 	}
 
@@ -113,6 +112,10 @@ services:
 				serviceTags = strings.Split(method.tags.Value(tagSwaggerTags), ",")
 			}
 			successCode := method.tags.ValueInt(tagHttpSuccess, fasthttp.StatusOK)
+			opDescription := method.description()
+			if opDescription == "" {
+				opDescription = method.tags.Value(tagDesc)
+			}
 
 			doc.registerStruct(method.requestStructName(), service.pkgPath, method.tags, method.arguments(), isRequiredGeneratedRequestField)
 			doc.registerStruct(method.responseStructName(), service.pkgPath, method.tags, method.results(), isRequiredGeneratedResponseField)
@@ -186,15 +189,20 @@ services:
 				}
 			}
 			if service.tags.Contains(tagServerJsonRPC) && !method.tags.Contains(tagMethodHTTP) {
+				params := swSchema{Ref: "#/components/schemas/" + method.requestStructName()}
+				requestSchema := jsonrpcSchema("params", params)
+				if method.isNotify() {
+					requestSchema = jsonrpcNotificationSchema("params", params)
+				}
 				postMethod := &swOperation{
 					Summary:     method.tags.Value(tagSummary),
-					Description: method.tags.Value(tagDesc),
+					Description: opDescription,
 					Parameters:  parameters,
 					Tags:        serviceTags,
 					Deprecated:  method.tags.Contains(tagDeprecated),
 					RequestBody: &swRequestBody{
 						Content: swContent{
-							contentJSON: swMedia{Schema: jsonrpcSchema("params", swSchema{Ref: "#/components/schemas/" + method.requestStructName()})},
+							contentJSON: swMedia{Schema: requestSchema},
 						},
 					},
 					Responses: swResponses{
@@ -213,6 +221,9 @@ services:
 						},
 					},
 				}
+				if method.isNotify() {
+					postMethod.Responses["204"] = swResponse{Description: "Notification is executed without response"}
+				}
 				swaggerDoc.Paths[method.jsonrpcPath()] = swPath{Post: postMethod}
 			} else if service.tags.Contains(tagServerHTTP) && method.tags.Contains(tagMethodHTTP) {
 				doc.log.WithField("module", "swagger").Infof("service %s append HTTP method %s", serviceTags, method.Name)
@@ -224,7 +235,7 @@ services:
 				responseContentType := method.tags.Value(tagResponseContentType, contentJSON)
 				httpMethod := &swOperation{
 					Summary:     method.tags.Value(tagSummary),
-					Description: method.tags.Value(tagDesc),
+					Description: opDescription,
 					Parameters:  parameters,
 					Tags:        serviceTags,
 					Deprecated:  method.tags.Contains(tagDeprecated),
@@ -271,7 +282,7 @@ services:
 		}
 	}
 	doc.log.Info("write to ", outFilePath)
-	return os.WriteFile(outFilePath, docData, 0600)
+	return doc.output().WriteFile(outFilePath, docData, 0600)
 }
 
 func (doc *swagger) fillErrors(responses swResponses, tags tags.DocTags) {
@@ -305,7 +316,7 @@ func (doc *swagger) fillErrors(responses swResponses, tags tags.DocTags) {
 			}
 			responses[key] = swResponse{Description: text, Content: content}
 
-		} else if key == "defaultError" {
+		} else if key == tagDefaultError {
 
 			if value != "" {
 				if tokens := strings.Split(value, ":"); len(tokens) == 2 {
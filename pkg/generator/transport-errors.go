@@ -12,7 +12,7 @@ import (
 
 func (tr *Transport) renderErrors(outDir string) (err error) {
 
-	srcFile := newSrc(filepath.Base(outDir))
+	srcFile := tr.newSrc(filepath.Base(outDir))
 	srcFile.PackageComment(doNotEdit)
 
 	srcFile.Line().Type().Id("withErrorCode").Interface(
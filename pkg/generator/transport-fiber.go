@@ -12,10 +12,10 @@ import (
 
 func (tr *Transport) renderFiber(outDir string) (err error) {
 
-	srcFile := newSrc(filepath.Base(outDir))
+	srcFile := tr.newSrc(filepath.Base(outDir))
 	srcFile.PackageComment(doNotEdit)
 
-	srcFile.ImportName(packageFiber, "fiber")
+	srcFile.ImportName(tr.fiberPkg(), "fiber")
 	srcFile.ImportName(packageErrors, "errors")
 	srcFile.ImportName(packageZeroLog, "zerolog")
 
@@ -30,23 +30,23 @@ func (tr *Transport) renderFiber(outDir string) (err error) {
 
 func (tr *Transport) renderFiberLogger(srcFile goFile) {
 
-	srcFile.Line().Func().Params(Id("srv").Op("*").Id("Server")).Id("setLogger").Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx")).Error().Block(
-		Id(_ctx_).Dot("SetUserContext").Call(Id("srv").Dot("log").Dot("WithContext").Call(Id(_ctx_).Dot("UserContext").Call())),
+	srcFile.Line().Func().Params(Id("srv").Op("*").Id("Server")).Id("setLogger").Params(tr.fiberCtx(_ctx_)).Error().Block(
+		tr.setUserContext(_ctx_, Id("srv").Dot("log").Dot("WithContext").Call(tr.userContext(_ctx_))),
 		Return(Id(_ctx_).Dot("Next").Call()),
 	)
 }
 
 func (tr *Transport) logLevelHandler(srcFile goFile) {
 
-	srcFile.Line().Func().Params(Id("srv").Op("*").Id("Server")).Id("logLevelHandler").Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx")).Error().Block(
+	srcFile.Line().Func().Params(Id("srv").Op("*").Id("Server")).Id("logLevelHandler").Params(tr.fiberCtx(_ctx_)).Error().Block(
 
 		Line().
 			If(Id("levelName").Op(":=").String().Call(Id(_ctx_).
 				Dot("Request").Call().Dot("Header").
 				Dot("Peek").Call(Id("logLevelHeader"))).Op(";").Id("levelName").Op("!=").Lit("")).Block(
 			If(List(Id("level"), Err()).Op(":=").Qual(packageZeroLog, "ParseLevel").Call(Id("levelName")).Op(";").Err().Op("==").Nil()).Block(
-				Id("logger").Op(":=").Qual(packageZeroLogLog, "Ctx").Call(Id(_ctx_).Dot("UserContext").Call()).Dot("Level").Call(Id("level")),
-				Id(_ctx_).Dot("SetUserContext").Call(Id("logger").Dot("WithContext").Call(Id(_ctx_).Dot("UserContext").Call())),
+				Id("logger").Op(":=").Qual(packageZeroLogLog, "Ctx").Call(tr.userContext(_ctx_)).Dot("Level").Call(Id("level")),
+				tr.setUserContext(_ctx_, Id("logger").Dot("WithContext").Call(tr.userContext(_ctx_))),
 			),
 		),
 		Return(Id(_ctx_).Dot("Next").Call()),
@@ -55,18 +55,18 @@ func (tr *Transport) logLevelHandler(srcFile goFile) {
 
 func (tr *Transport) renderFiberRecover(srcFile goFile) {
 
-	srcFile.Line().Func().Id("recoverHandler").Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx")).Error().Block(
+	srcFile.Line().Func().Id("recoverHandler").Params(tr.fiberCtx(_ctx_)).Error().Block(
 		Defer().Func().Params().Block(
 			If(Id("r").Op(":=").Recover().Op(";").Id("r").Op("!=").Nil().Block(
 				List(Err(), Id("ok")).Op(":=").Id("r").Op(".").Call(Error()),
 				If(Op("!").Id("ok")).Block(
 					Err().Op("=").Qual(packageErrors, "New").Call(Qual(packageFmt, "Sprintf").Call(Lit("%v"), Id("r"))),
 				),
-				Qual(packageZeroLogLog, "Ctx").Call(Id(_ctx_).Dot("UserContext").Call()).Dot("Error").Call().Dot("Stack").Call().Dot("Err").Call(Qual(packageErrors, "Wrap").Call(Err(), Lit("recover"))).
+				Qual(packageZeroLogLog, "Ctx").Call(tr.userContext(_ctx_)).Dot("Error").Call().Dot("Stack").Call().Dot("Err").Call(Qual(packageErrors, "Wrap").Call(Err(), Lit("recover"))).
 					Dot("Str").Call(Lit("method"), Id(_ctx_).Dot("Method").Call()).
 					Dot("Str").Call(Lit("path"), Id(_ctx_).Dot("OriginalURL").Call()).
 					Dot("Msg").Call(Lit("panic occurred")),
-				Id(_ctx_).Dot("Status").Call(Qual(packageFiber, "StatusInternalServerError")),
+				Id(_ctx_).Dot("Status").Call(Qual(tr.fiberPkg(), "StatusInternalServerError")),
 			)),
 		).Call(),
 		Return(Id(_ctx_).Dot("Next").Call()),
@@ -12,17 +12,22 @@ import (
 
 func (tr *Transport) renderHeader(outDir string) (err error) {
 
-	srcFile := newSrc(filepath.Base(outDir))
+	srcFile := tr.newSrc(filepath.Base(outDir))
 	srcFile.PackageComment(doNotEdit)
 
-	srcFile.ImportName(packageFiber, "fiber")
+	srcFile.ImportName(tr.fiberPkg(), "fiber")
+	srcFile.ImportName(packageHttp, "http")
 	srcFile.ImportName(packageErrors, "errors")
 	srcFile.ImportName(packageZeroLogLog, "log")
 	srcFile.ImportName(packageZeroLog, "zerolog")
 	srcFile.ImportName(tr.tags.Value(tagPackageJSON, packageStdJSON), "json")
 
 	tr.renderHeaderTypes(srcFile)
-	tr.renderHeaderHandler(srcFile)
+	if tr.isNetHTTP() {
+		srcFile.Line().Add(tr.headersHandlerNetHTTP())
+	} else {
+		tr.renderHeaderHandler(srcFile)
+	}
 	tr.renderHeaderValue(srcFile)
 	tr.renderHeaderValueInterface(srcFile)
 
@@ -47,7 +52,7 @@ func (tr *Transport) renderHeaderTypes(srcFile goFile) {
 func (tr *Transport) renderHeaderHandler(srcFile goFile) {
 
 	srcFile.Line().Func().Params(Id("srv").Op("*").Id("Server")).
-		Id("headersHandler").Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx")).Params(Error()).BlockFunc(func(g *Group) {
+		Id("headersHandler").Params(tr.fiberCtx(_ctx_)).Params(Error()).BlockFunc(func(g *Group) {
 		g.Line()
 		g.For(List(Id("headerName"), Id("handler")).Op(":=").Range().Id("srv").Dot("headerHandlers")).Block(
 			Id("value").Op(":=").Id(_ctx_).Dot("Request").Call().Dot("Header").Dot("Peek").Call(Id("headerName")),
@@ -59,9 +64,9 @@ func (tr *Transport) renderHeaderHandler(srcFile goFile) {
 				Id(_ctx_).Dot("Response").Call().Dot("Header").Dot("Set").Call(Id("header").Dot("ResponseKey"), Id("headerValue").Call(Id("header").Dot("ResponseValue"))),
 			),
 			If(Id("header").Dot("LogValue").Op("!=").Nil()).Block(
-				Id("logger").Op(":=").Qual(packageZeroLogLog, "Ctx").Call(Id(_ctx_).Dot("UserContext").Call()).
+				Id("logger").Op(":=").Qual(packageZeroLogLog, "Ctx").Call(tr.userContext(_ctx_)).
 					Dot("With").Call().Dot("Interface").Call(Id("header").Dot("LogKey"), Id("header").Dot("LogValue")).Dot("Logger").Call(),
-				Id(_ctx_).Dot("SetUserContext").Call(Id("logger").Dot("WithContext").Call(Id(_ctx_).Dot("UserContext").Call())),
+				tr.setUserContext(_ctx_, Id("logger").Dot("WithContext").Call(tr.userContext(_ctx_))),
 			),
 		)
 		g.Return(Id(_ctx_).Dot("Next").Call())
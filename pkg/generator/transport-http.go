@@ -12,10 +12,11 @@ import (
 
 func (tr *Transport) renderHTTP(outDir string) (err error) {
 
-	srcFile := newSrc(filepath.Base(outDir))
+	srcFile := tr.newSrc(filepath.Base(outDir))
 	srcFile.PackageComment(doNotEdit)
 
-	srcFile.ImportName(packageFiber, "fiber")
+	srcFile.ImportName(tr.fiberPkg(), "fiber")
+	srcFile.ImportName(packageHttp, "http")
 	srcFile.ImportName(packageZeroLog, "zerolog")
 	srcFile.ImportName(packageMultipart, "multipart")
 
@@ -23,9 +24,16 @@ func (tr *Transport) renderHTTP(outDir string) (err error) {
 		Id("RedirectTo").Call().String(),
 	)
 
-	srcFile.Line().Type().Id("cookieType").Interface(
-		Id("Cookie").Params().Params(Op("*").Qual(packageFiber, "Cookie")),
-	)
+	if tr.isNetHTTP() {
+		srcFile.Line().Type().Id("cookieType").Interface(
+			Id("Cookie").Params().Params(Op("*").Qual(packageHttp, "Cookie")),
+		)
+		srcFile.Line().Add(tr.cookieValueFunc())
+	} else {
+		srcFile.Line().Type().Id("cookieType").Interface(
+			Id("Cookie").Params().Params(Op("*").Qual(tr.fiberPkg(), "Cookie")),
+		)
+	}
 
 	return srcFile.Save(path.Join(outDir, "http.go"))
 }
@@ -14,10 +14,10 @@ import (
 
 func (tr *Transport) renderJsonRPC(outDir string) (err error) {
 
-	srcFile := newSrc(filepath.Base(outDir))
+	srcFile := tr.newSrc(filepath.Base(outDir))
 	srcFile.PackageComment(doNotEdit)
 
-	srcFile.ImportName(packageFiber, "fiber")
+	srcFile.ImportName(tr.fiberPkg(), "fiber")
 	srcFile.ImportName(packageErrors, "errors")
 	srcFile.ImportName(packageContext, "context")
 	srcFile.ImportName(packageZeroLog, "zerolog")
@@ -30,7 +30,7 @@ func (tr *Transport) renderJsonRPC(outDir string) (err error) {
 	srcFile.Add(tr.jsonrpcResponsesTypeFunc())
 	srcFile.Add(batchJobType())
 
-	srcFile.Line().Type().Id("methodJsonRPC").Func().Params(Id("userCtx").Qual(packageContext, "Context"), Id("ftx").Op("*").Qual(packageFiber, "Ctx"), Id("requestBase").Id("baseJsonRPC")).Params(Id("responseBase").Op("*").Id("baseJsonRPC"))
+	srcFile.Line().Type().Id("methodJsonRPC").Func().Params(tr.handlerParams("requestBase")...).Params(Id("responseBase").Op("*").Id("baseJsonRPC"))
 	srcFile.Line().Add(tr.makeErrorResponseJsonRPCFunc())
 	if err = srcFile.Save(path.Join(outDir, "jsonrpc.go")); err != nil {
 		return
@@ -40,22 +40,38 @@ func (tr *Transport) renderJsonRPC(outDir string) (err error) {
 
 func (tr *Transport) renderBatch(outDir string) (err error) {
 
-	srcFile := newSrc(filepath.Base(outDir))
+	srcFile := tr.newSrc(filepath.Base(outDir))
 	srcFile.PackageComment(doNotEdit)
 
-	srcFile.ImportName(packageFiber, "fiber")
+	srcFile.ImportName(tr.fiberPkg(), "fiber")
+	srcFile.ImportName(packageHttp, "http")
+	srcFile.ImportName(packageIO, "io")
 	srcFile.ImportName(packageSync, "sync")
 	srcFile.ImportName(packageContext, "context")
 	srcFile.ImportName(packageStrings, "strings")
 	srcFile.ImportName(tr.tags.Value(tagPackageJSON, packageStdJSON), "json")
 
-	srcFile.Add(batchDoFunc(batchTarget{receiver: "srv", receiverType: "Server"}))
+	srcFile.Add(tr.batchDoFunc(batchTarget{receiver: "srv", receiverType: "Server"}))
+	srcFile.Add(tr.batchParallelFunc(batchTarget{receiver: "srv", receiverType: "Server"}))
 	srcFile.Add(tr.singleBatchFunc())
-	srcFile.Add(tr.serveBatchFunc())
+	if tr.isNetHTTP() {
+		srcFile.Add(tr.serveBatchNetHTTPFunc(batchTarget{receiver: "srv", receiverType: "Server"}))
+	} else {
+		srcFile.Add(tr.serveBatchFunc())
+	}
 
 	return srcFile.Save(path.Join(outDir, "batch.go"))
 }
 
+// sendNoContent returns code, which answers notifications by status 204 without body.
+func (tr *Transport) sendNoContent() *Statement {
+
+	if tr.isNetHTTP() {
+		return Id("w").Dot("WriteHeader").Call(Qual(packageHttp, "StatusNoContent"))
+	}
+	return Id(_ctx_).Dot("SendStatus").Call(Qual(tr.fiberPkg(), "StatusNoContent"))
+}
+
 func (tr *Transport) makeErrorResponseJsonRPCFunc() Code {
 
 	return Func().Id("makeErrorResponseJsonRPC").Params(Id("id").Id("idJsonRPC"), Id("code").Int(), Id("msg").String(), Id("data").Interface()).Params(Op("*").Id("baseJsonRPC")).Block(
@@ -158,7 +174,7 @@ func (tr *Transport) jsonrpcConstants(exportErrors bool) Code {
 func (tr *Transport) singleBatchFunc() Code {
 
 	return Func().Params(Id("srv").Op("*").Id("Server")).Id("doSingleBatch").
-		Params(Id("userCtx").Qual(packageContext, "Context"), Id("ftx").Op("*").Qual(packageFiber, "Ctx"), Id("request").Id("baseJsonRPC")).Params(Id("response").Op("*").Id("baseJsonRPC")).BlockFunc(
+		Params(tr.handlerParams("request")...).Params(Id("response").Op("*").Id("baseJsonRPC")).BlockFunc(
 		func(bg *Group) {
 			bg.Line()
 			bg.Id("methodNameOrigin").Op(":=").Id("request").Dot("Method")
@@ -172,7 +188,7 @@ func (tr *Transport) singleBatchFunc() Code {
 								continue
 							}
 							sg.Case(Lit(svc.lcName() + "." + method.lcName())).Block(
-								Return(Id("srv").Dot("http"+serviceName).Dot(utils.ToLowerCamel(method.Name)).Call(Id("userCtx"), Id("ftx"), Id("request"))),
+								Return(Id("srv").Dot("http" + serviceName).Dot(utils.ToLowerCamel(method.Name)).Call(tr.handlerArgs("request")...)),
 							)
 						}
 					}
@@ -186,14 +202,14 @@ func (tr *Transport) singleBatchFunc() Code {
 func (tr *Transport) serveBatchFunc() Code {
 
 	return Func().Params(Id("srv").Op("*").Id("Server")).Id("serveBatch").
-		Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx")).Params(Id("err").Error()).BlockFunc(
+		Params(tr.fiberCtx(_ctx_)).Params(Id("err").Error()).BlockFunc(
 		func(bg *Group) {
 			bg.Line()
 			bg.Var().Id("single").Bool()
 			bg.Var().Id("requests").Op("[]").Id("baseJsonRPC")
 			bg.Id("methodHTTP").Op(":=").Id(_ctx_).Dot("Method").Call()
-			bg.If(Id("methodHTTP").Op("!=").Qual(packageFiber, "MethodPost")).BlockFunc(func(ig *Group) {
-				ig.Id(_ctx_).Dot("Response").Call().Dot("SetStatusCode").Call(Qual(packageFiber, "StatusMethodNotAllowed"))
+			bg.If(Id("methodHTTP").Op("!=").Qual(tr.fiberPkg(), "MethodPost")).BlockFunc(func(ig *Group) {
+				ig.Id(_ctx_).Dot("Response").Call().Dot("SetStatusCode").Call(Qual(tr.fiberPkg(), "StatusMethodNotAllowed"))
 				ig.If(List(Id("_"), Err()).Op("=").Id(_ctx_).Dot("WriteString").Call(Lit("only POST method supported")).Op(";").Err().Op("!=").Nil()).Block(
 					Return(),
 				)
@@ -208,12 +224,14 @@ func (tr *Transport) serveBatchFunc() Code {
 				ig.Id("requests").Op("=").Append(Id("requests"), Id("request"))
 			})
 			bg.If(Id("single")).Block(
-				Return(Id("sendResponse").Call(Id(_ctx_), Id("srv").Dot("doSingleBatch").
-					Call(Id(_ctx_).Dot("UserContext").Call(), Id(_ctx_), Id("requests").Op("[").Lit(0).Op("]")),
-				)),
+				If(Id("response").Op(":=").Id("srv").Dot("doSingleBatch").Call(tr.userContext(_ctx_), Id(_ctx_), Id("requests").Op("[").Lit(0).Op("]")).Op(";").Id("response").Op("!=").Nil()).Block(
+					Return(Id("sendResponse").Call(Id(_ctx_), Id("response"))),
+				),
+				Return(tr.sendNoContent()),
+			)
+			bg.If(Id("responses").Op(":=").Id("srv").Dot("doBatch").Call(Id(_ctx_), Id("requests")).Op(";").Len(Id("responses")).Op("!=").Lit(0)).Block(
+				Return(Id("sendResponse").Call(Id(_ctx_), Id("responses"))),
 			)
-			bg.Return(Id("sendResponse").Call(Id(_ctx_), Id("srv").Dot("doBatch").
-				Call(Id(_ctx_), Id("requests")),
-			))
+			bg.Return(tr.sendNoContent())
 		})
 }
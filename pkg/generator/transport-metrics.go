@@ -12,15 +12,15 @@ import (
 
 func (tr *Transport) renderMetrics(outDir string) (err error) {
 
-	srcFile := newSrc(filepath.Base(outDir))
+	srcFile := tr.newSrc(filepath.Base(outDir))
 
 	srcFile.PackageComment(doNotEdit)
 
 	srcFile.ImportAlias(packagePrometheus, "prometheus")
 
-	srcFile.ImportName(packageFiber, "fiber")
+	srcFile.ImportName(tr.fiberPkg(), "fiber")
 	srcFile.ImportName(packageZeroLog, "zerolog")
-	srcFile.ImportName(packageFiberAdaptor, "adaptor")
+	srcFile.ImportName(tr.fiberAdaptor(), "adaptor")
 	srcFile.ImportName(packagePrometheusHttp, "promhttp")
 
 	srcFile.Add(Var().Id("VersionGauge").Op("*").Qual(packagePrometheus, "GaugeVec"))
@@ -28,17 +28,23 @@ func (tr *Transport) renderMetrics(outDir string) (err error) {
 	srcFile.Add(Var().Id("RequestCountAll").Op("*").Qual(packagePrometheus, "CounterVec"))
 	srcFile.Add(Var().Id("RequestLatency").Op("*").Qual(packagePrometheus, "HistogramVec"))
 
-	srcFile.Add(tr.serveMetricsFunc())
+	if tr.isNetHTTP() {
+		srcFile.ImportName(packageHttp, "http")
+		srcFile.ImportName(packageErrors, "errors")
+		srcFile.Add(tr.overridable(&srcFile, OverrideMetrics, nil, tr.serveMetricsNetHTTPFunc()))
+	} else {
+		srcFile.Add(tr.overridable(&srcFile, OverrideMetrics, nil, tr.serveMetricsFunc()))
+	}
 
 	return srcFile.Save(path.Join(outDir, "metrics.go"))
 }
 
 func (tr *Transport) serveMetricsFunc() Code {
 	return Func().Params(Id("srv").Op("*").Id("Server")).Id("ServeMetrics").Params(Id("log").Qual(packageZeroLog, "Logger"), Id("path").String(), Id("address").String()).Block(
-		Id("srv").Dot("srvMetrics").Op("=").Qual(packageFiber, "New").Call(Qual(packageFiber, "Config").Values(Dict{Id("DisableStartupMessage"): True()})),
-		Id("srv").Dot("srvMetrics").Dot("All").Call(Id("path"), Qual(packageFiberAdaptor, "HTTPHandler").Call(Qual(packagePrometheusHttp, "Handler").Call())),
+		Id("srv").Dot("srvMetrics").Op("=").Qual(tr.fiberPkg(), "New").Call(tr.fiberQuietConfig()),
+		Id("srv").Dot("srvMetrics").Dot("All").Call(Id("path"), Qual(tr.fiberAdaptor(), "HTTPHandler").Call(Qual(packagePrometheusHttp, "Handler").Call())),
 		Go().Func().Params().Block(
-			Err().Op(":=").Id("srv").Dot("srvMetrics").Dot("Listen").Call(Id("address")),
+			Err().Op(":=").Add(tr.fiberListen(Id("srv").Dot("srvMetrics"))),
 			Id("ExitOnError").Call(Id("log"), Err(), Lit("serve metrics on ").Op("+").Id("address")),
 		).Call(),
 	)
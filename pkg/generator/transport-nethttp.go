@@ -0,0 +1,308 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+)
+
+// renderMiddleware renders middlewares of server for BackendNetHTTP, the same as renderFiber does for fiber.
+func (tr *Transport) renderMiddleware(outDir string) (err error) {
+
+	srcFile := tr.newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	srcFile.ImportName(packageHttp, "http")
+	srcFile.ImportName(packageErrors, "errors")
+	srcFile.ImportName(packageZeroLog, "zerolog")
+	srcFile.ImportName(packageZeroLogLog, "log")
+
+	srcFile.Line().Const().Id("logLevelHeader").Op("=").Lit("X-Log-Level")
+
+	srcFile.Line().Add(middlewareFunc(true, "setLogger",
+		Id(_next_).Dot("ServeHTTP").Call(Id("w"), Id("r").Dot("WithContext").Call(Id("srv").Dot("log").Dot("WithContext").Call(Id("r").Dot("Context").Call()))),
+	))
+	srcFile.Line().Add(middlewareFunc(true, "logLevelHandler",
+		If(Id("levelName").Op(":=").Id("r").Dot("Header").Dot("Get").Call(Id("logLevelHeader")).Op(";").Id("levelName").Op("!=").Lit("")).Block(
+			If(List(Id("level"), Err()).Op(":=").Qual(packageZeroLog, "ParseLevel").Call(Id("levelName")).Op(";").Err().Op("==").Nil()).Block(
+				Id("logger").Op(":=").Qual(packageZeroLogLog, "Ctx").Call(Id("r").Dot("Context").Call()).Dot("Level").Call(Id("level")),
+				Id("r").Op("=").Id("r").Dot("WithContext").Call(Id("logger").Dot("WithContext").Call(Id("r").Dot("Context").Call())),
+			),
+		),
+		Id(_next_).Dot("ServeHTTP").Call(Id("w"), Id("r")),
+	))
+	srcFile.Line().Add(middlewareFunc(true, "bodyLimit",
+		If(Id("srv").Dot("maxBodySize").Op(">").Lit(0)).Block(
+			Id("r").Dot("Body").Op("=").Qual(packageHttp, "MaxBytesReader").Call(Id("w"), Id("r").Dot("Body"), Int64().Call(Id("srv").Dot("maxBodySize"))),
+		),
+		Id(_next_).Dot("ServeHTTP").Call(Id("w"), Id("r")),
+	))
+	srcFile.Line().Add(middlewareFunc(false, "recoverHandler",
+		Defer().Func().Params().Block(
+			If(Id("rec").Op(":=").Recover().Op(";").Id("rec").Op("!=").Nil()).Block(
+				List(Err(), Id("ok")).Op(":=").Id("rec").Op(".").Call(Error()),
+				If(Op("!").Id("ok")).Block(
+					Err().Op("=").Qual(packageErrors, "New").Call(Qual(packageFmt, "Sprintf").Call(Lit("%v"), Id("rec"))),
+				),
+				Qual(packageZeroLogLog, "Ctx").Call(Id("r").Dot("Context").Call()).Dot("Error").Call().Dot("Stack").Call().Dot("Err").Call(Qual(packageErrors, "Wrap").Call(Err(), Lit("recover"))).
+					Dot("Str").Call(Lit("method"), Id("r").Dot("Method")).
+					Dot("Str").Call(Lit("path"), Id("r").Dot("URL").Dot("RequestURI").Call()).
+					Dot("Msg").Call(Lit("panic occurred")),
+				Id("w").Dot("WriteHeader").Call(Qual(packageHttp, "StatusInternalServerError")),
+			),
+		).Call(),
+		Id(_next_).Dot("ServeHTTP").Call(Id("w"), Id("r")),
+	))
+	return srcFile.Save(path.Join(outDir, "middleware.go"))
+}
+
+// middlewareFunc returns middleware of net/http, which body has 'next', 'w' and 'r'. Middleware is a method of Server, when isMethod is set.
+func middlewareFunc(isMethod bool, name string, body ...Code) Code {
+
+	fn := Func()
+	if isMethod {
+		fn.Params(Id("srv").Op("*").Id("Server"))
+	}
+	return fn.Id(name).Params(Id(_next_).Qual(packageHttp, "Handler")).Params(Qual(packageHttp, "Handler")).Block(
+		Return(Qual(packageHttp, "HandlerFunc").Call(Func().Params(Id("w").Qual(packageHttp, "ResponseWriter"), Id("r").Op("*").Qual(packageHttp, "Request")).Block(body...))),
+	)
+}
+
+func (tr *Transport) cookieValueFunc() Code {
+
+	return Func().Id("cookieValue").Params(Id("r").Op("*").Qual(packageHttp, "Request"), Id("name").String()).String().Block(
+		If(List(Id("cookie"), Err()).Op(":=").Id("r").Dot("Cookie").Call(Id("name")).Op(";").Err().Op("==").Nil()).Block(
+			Return(Id("cookie").Dot("Value")),
+		),
+		Return(Lit("")),
+	)
+}
+
+func (tr *Transport) headersHandlerNetHTTP() Code {
+
+	return middlewareFunc(true, "headersHandler",
+		Id(_ctx_).Op(":=").Id("r").Dot("Context").Call(),
+		For(List(Id("headerName"), Id("handler")).Op(":=").Range().Id("srv").Dot("headerHandlers")).Block(
+			Id("header").Op(":=").Id("handler").Call(Id("r").Dot("Header").Dot("Get").Call(Id("headerName"))),
+			If(Id("header").Dot("RequestValue").Op("!=").Nil()).Block(
+				Id("r").Dot("Header").Dot("Set").Call(Id("header").Dot("RequestKey"), Id("headerValue").Call(Id("header").Dot("RequestValue"))),
+			),
+			If(Id("header").Dot("ResponseValue").Op("!=").Nil()).Block(
+				Id("w").Dot("Header").Call().Dot("Set").Call(Id("header").Dot("ResponseKey"), Id("headerValue").Call(Id("header").Dot("ResponseValue"))),
+			),
+			If(Id("header").Dot("LogValue").Op("!=").Nil()).Block(
+				Id("logger").Op(":=").Qual(packageZeroLogLog, "Ctx").Call(Id(_ctx_)).
+					Dot("With").Call().Dot("Interface").Call(Id("header").Dot("LogKey"), Id("header").Dot("LogValue")).Dot("Logger").Call(),
+				Id(_ctx_).Op("=").Id("logger").Dot("WithContext").Call(Id(_ctx_)),
+			),
+		),
+		Id(_next_).Dot("ServeHTTP").Call(Id("w"), Id("r").Dot("WithContext").Call(Id(_ctx_))),
+	)
+}
+
+func (tr *Transport) serverTypeNetHTTP() Code {
+
+	return Type().Id("Server").StructFunc(func(g *Group) {
+		g.Id("log").Qual(packageZeroLog, "Logger")
+		g.Line().Id("middlewares").Op("[]").Id("Handler")
+		g.Line().Id("maxBodySize").Int()
+		g.Line().Id("mux").Op("*").Qual(packageHttp, "ServeMux")
+		g.Id("handler").Qual(packageHttp, "Handler")
+		g.Line().Id("srvHTTP").Op("*").Qual(packageHttp, "Server")
+		g.Id("srvHealth").Op("*").Qual(packageHttp, "Server")
+		g.Id("srvMetrics").Op("*").Qual(packageHttp, "Server")
+		g.Line().Id("reporterCloser").Qual(packageIO, "Closer")
+		if tr.hasJsonRPC {
+			g.Line().Id("maxBatchSize").Int()
+			g.Id("maxParallelBatch").Int().Line()
+		}
+		if tr.hasWebSocket() {
+			g.Id("wsUpgrader").Qual(packageWebSocket, "Upgrader").Line()
+		}
+		for _, serviceName := range tr.serviceKeys() {
+			g.Id("http" + serviceName).Op("*").Id("http" + serviceName)
+		}
+		g.Id("headerHandlers").Map(String()).Id("HeaderHandler")
+	})
+}
+
+func (tr *Transport) serverNewNetHTTPFunc(outDir string) Code {
+
+	return Func().Id("New").Params(Id("log").Qual(packageZeroLog, "Logger"), Id("options").Op("...").Id("Option")).Params(Id("srv").Op("*").Id("Server")).
+		BlockFunc(func(bg *Group) {
+			bg.Line().Id("srv").Op("=").Op("&").Id("Server").Values(DictFunc(func(dict Dict) {
+
+				dict[Id("log")] = Id("log")
+				if tr.hasJsonRPC {
+					dict[Id("maxBatchSize")] = Id("defaultMaxBatchSize")
+					dict[Id("maxParallelBatch")] = Id("defaultMaxParallelBatch")
+				}
+				dict[Id("maxBodySize")] = Id("defaultMaxBodySize")
+				dict[Id("srvHTTP")] = Op("&").Qual(packageHttp, "Server").Values()
+				dict[Id("headerHandlers")] = Make(Map(String()).Id("HeaderHandler"))
+			}))
+			bg.For(List(Id("_"), Id("option")).Op(":=").Range().Id("options")).Block(
+				Id("option").Call(Id("srv")),
+			)
+			bg.Id("srv").Dot("mux").Op("=").Qual(packageHttp, "NewServeMux").Call()
+			bg.Id("srv").Dot("srvHTTP").Dot("Handler").Op("=").Id("srv")
+			bg.Id("srv").Dot("middlewares").Op("=").Op("[]").Id("Handler").ValuesFunc(func(vg *Group) {
+				vg.Id("recoverHandler")
+				vg.Id("srv").Dot("bodyLimit")
+				if tr.hasTrace() {
+					vg.Qual(fmt.Sprintf("%s/tracer", tr.pkgPath(outDir)), "Middleware").Call()
+				}
+				vg.Id("srv").Dot("setLogger")
+				vg.Id("srv").Dot("logLevelHandler")
+				vg.Id("srv").Dot("headersHandler")
+			})
+			bg.For(List(Id("_"), Id("option")).Op(":=").Range().Id("options")).Block(
+				Id("option").Call(Id("srv")),
+			)
+			if tr.hasJsonRPC {
+				bg.Id("srv").Dot("mux").Dot("HandleFunc").Call(Lit(muxPattern("POST", "/"+tr.tags.Value(tagHttpPrefix, ""))), Id("srv").Dot("serveBatch"))
+			}
+			if tr.hasWebSocket() {
+				bg.Id("srv").Dot("mux").Dot("HandleFunc").Call(Lit(muxPattern("GET", tr.wsPath())), Id("srv").Dot("serveWebSocket"))
+			}
+			bg.Id("srv").Dot("handler").Op("=").Id("srv").Dot("mux")
+			bg.For(Id("i").Op(":=").Len(Id("srv").Dot("middlewares")).Op("-").Lit(1).Op(";").Id("i").Op(">=").Lit(0).Op(";").Id("i").Op("--")).Block(
+				Id("srv").Dot("handler").Op("=").Id("srv").Dot("middlewares").Index(Id("i")).Call(Id("srv").Dot("handler")),
+			)
+			bg.Return()
+		})
+}
+
+func (tr *Transport) muxFunc() Code {
+	return Func().Params(Id("srv").Op("*").Id("Server")).Id("Mux").Params().Params(Op("*").Qual(packageHttp, "ServeMux")).Block(
+		Return(Id("srv").Dot("mux")),
+	)
+}
+
+func (tr *Transport) serveHTTPFunc() Code {
+	return Func().Params(Id("srv").Op("*").Id("Server")).Id("ServeHTTP").Params(tr.exchangeParams(_ctx_)...).Block(
+		Id("srv").Dot("handler").Dot("ServeHTTP").Call(Id("w"), Id("r")),
+	)
+}
+
+func (tr *Transport) listenFunc() Code {
+	return Func().Params(Id("srv").Op("*").Id("Server")).Id("Listen").Params(Id("address").String()).Params(Err().Error()).Block(
+		Id("srv").Dot("srvHTTP").Dot("Addr").Op("=").Id("address"),
+		If(Err().Op("=").Id("srv").Dot("srvHTTP").Dot("ListenAndServe").Call().Op(";").Qual(packageErrors, "Is").Call(Err(), Qual(packageHttp, "ErrServerClosed"))).Block(
+			Return(Nil()),
+		),
+		Return(),
+	)
+}
+
+// listenAndServe returns goroutine, which serves server until shutdown, any other error of server is fatal.
+func listenAndServe(server, log *Statement, what string) Code {
+
+	return Go().Func().Params().Block(
+		If(Err().Op(":=").Add(server).Dot("ListenAndServe").Call().Op(";").Op("!").Qual(packageErrors, "Is").Call(Err(), Qual(packageHttp, "ErrServerClosed"))).Block(
+			Id("ExitOnError").Call(log, Err(), Lit("serve "+what+" on ").Op("+").Id("address")),
+		),
+	).Call()
+}
+
+func (tr *Transport) serveHealthNetHTTPFunc() Code {
+
+	return Func().Params(Id("srv").Op("*").Id("Server")).Id("ServeHealth").Params(Id("address").String(), Id("response").Interface()).Block(
+		Id("mux").Op(":=").Qual(packageHttp, "NewServeMux").Call(),
+		Id("mux").Dot("HandleFunc").Call(Lit("GET /health"), Func().Params(tr.exchangeParams(_ctx_)...).Block(
+			Id("sendResponse").Call(Id("w"), Id("r"), Qual(packageHttp, "StatusOK"), Id("response")),
+		)),
+		Id("srv").Dot("srvHealth").Op("=").Op("&").Qual(packageHttp, "Server").Values(Dict{Id("Addr"): Id("address"), Id("Handler"): Id("mux")}),
+		listenAndServe(Id("srv").Dot("srvHealth"), Id("srv").Dot("log"), "health"),
+	)
+}
+
+func (tr *Transport) serveMetricsNetHTTPFunc() Code {
+
+	return Func().Params(Id("srv").Op("*").Id("Server")).Id("ServeMetrics").Params(Id("log").Qual(packageZeroLog, "Logger"), Id("path").String(), Id("address").String()).Block(
+		Id("mux").Op(":=").Qual(packageHttp, "NewServeMux").Call(),
+		Id("mux").Dot("Handle").Call(Id("path"), Qual(packagePrometheusHttp, "Handler").Call()),
+		Id("srv").Dot("srvMetrics").Op("=").Op("&").Qual(packageHttp, "Server").Values(Dict{Id("Addr"): Id("address"), Id("Handler"): Id("mux")}),
+		listenAndServe(Id("srv").Dot("srvMetrics"), Id("log"), "metrics"),
+	)
+}
+
+func (tr *Transport) shutdownNetHTTPFunc() Code {
+
+	return Func().Params(Id("srv").Op("*").Id("Server")).Id("Shutdown").Params().BlockFunc(func(bg *Group) {
+		servers := []string{"srvHTTP", "srvHealth"}
+		if tr.hasMetrics() {
+			servers = append(servers, "srvMetrics")
+		}
+		for _, server := range servers {
+			bg.If(Id("srv").Dot(server).Op("!=").Nil()).Block(
+				Id("_").Op("=").Id("srv").Dot(server).Dot("Shutdown").Call(Qual(packageContext, "Background").Call()),
+			)
+		}
+	})
+}
+
+func (tr *Transport) sendResponseNetHTTPFunc() Code {
+
+	return Func().Id("sendResponse").Params(Id("w").Qual(packageHttp, "ResponseWriter"), Id("r").Op("*").Qual(packageHttp, "Request"), Id("status").Int(), Id("resp").Interface()).Block(
+		Id("w").Dot("Header").Call().Dot("Set").Call(Lit("Content-Type"), Lit("application/json")),
+		Id("w").Dot("WriteHeader").Call(Id("status")),
+		If(Err().Op(":=").Qual(tr.tags.Value(tagPackageJSON, packageStdJSON), "NewEncoder").Call(Id("w")).Dot("Encode").Call(Id("resp")).Op(";").Err().Op("!=").Nil()).Block(
+			Qual(packageZeroLogLog, "Ctx").Call(Id("r").Dot("Context").Call()).Dot("Error").Call().Dot("Err").Call(Err()).Dot("Msg").Call(Lit("response write error")),
+		),
+	)
+}
+
+func (tr *Transport) sendErrorNetHTTPFunc() Code {
+
+	return Func().Id("sendError").Params(Id("w").Qual(packageHttp, "ResponseWriter"), Id("r").Op("*").Qual(packageHttp, "Request"), Err().Error()).Block(
+		Id("status").Op(":=").Qual(packageHttp, "StatusInternalServerError"),
+		If(List(Id("errCoder"), Id("ok")).Op(":=").Err().Op(".").Call(Id("withErrorCode")).Op(";").Id("ok")).Block(
+			Id("status").Op("=").Id("errCoder").Dot("Code").Call(),
+		),
+		Id("sendResponse").Call(Id("w"), Id("r"), Id("status"), Err()),
+	)
+}
+
+// serveBatchNetHTTPFunc returns handler of batch for BackendNetHTTP. Method of request is checked by pattern of route.
+func (tr *Transport) serveBatchNetHTTPFunc(target batchTarget) Code {
+
+	packageJSON := tr.tags.Value(tagPackageJSON, packageStdJSON)
+	parseError := func() Code {
+		return Id("sendResponse").Call(Id("w"), Id("r"), Qual(packageHttp, "StatusOK"), Id("makeErrorResponseJsonRPC").Call(Op("[]").Byte().Call(Lit(`"0"`)), Id("parseError"), Lit("request body could not be decoded: ").Op("+").Err().Dot("Error").Call(), Nil()))
+	}
+	return Func().Params(Id(target.receiver).Op("*").Id(target.receiverType)).Id("serveBatch").Params(tr.exchangeParams(_ctx_)...).BlockFunc(func(bg *Group) {
+		bg.Line()
+		bg.Var().Id("single").Bool()
+		bg.Var().Id("requests").Op("[]").Id("baseJsonRPC")
+		bg.List(Id("body"), Err()).Op(":=").Qual(packageIO, "ReadAll").Call(Id("r").Dot("Body"))
+		bg.If(Err().Op("!=").Nil()).Block(
+			parseError(),
+			Return(),
+		)
+		bg.If(Err().Op("=").Qual(packageJSON, "Unmarshal").Call(Id("body"), Op("&").Id("requests")).Op(";").Err().Op("!=").Nil()).Block(
+			Var().Id("request").Id("baseJsonRPC"),
+			If(Err().Op("=").Qual(packageJSON, "Unmarshal").Call(Id("body"), Op("&").Id("request")).Op(";").Err().Op("!=").Nil()).Block(
+				parseError(),
+				Return(),
+			),
+			Id("single").Op("=").True(),
+			Id("requests").Op("=").Append(Id("requests"), Id("request")),
+		)
+		bg.If(Id("single")).Block(
+			If(Id("response").Op(":=").Id(target.receiver).Dot("doSingleBatch").Call(Id("r").Dot("Context").Call(), Id("w"), Id("r"), Id("requests").Index(Lit(0))).Op(";").Id("response").Op("!=").Nil()).Block(
+				Id("sendResponse").Call(Id("w"), Id("r"), Qual(packageHttp, "StatusOK"), Id("response")),
+				Return(),
+			),
+			tr.sendNoContent(),
+			Return(),
+		)
+		bg.If(Id("responses").Op(":=").Id(target.receiver).Dot("doBatch").Call(Id("w"), Id("r"), Id("requests")).Op(";").Len(Id("responses")).Op("!=").Lit(0)).Block(
+			Id("sendResponse").Call(Id("w"), Id("r"), Qual(packageHttp, "StatusOK"), Id("responses")),
+			Return(),
+		)
+		bg.Add(tr.sendNoContent())
+	})
+}
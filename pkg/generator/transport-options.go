@@ -12,59 +12,81 @@ import (
 
 func (tr *Transport) renderOptions(outDir string) (err error) {
 
-	srcFile := newSrc(filepath.Base(outDir))
+	srcFile := tr.newSrc(filepath.Base(outDir))
 	srcFile.PackageComment(doNotEdit)
 
-	srcFile.ImportName(packageFiber, "fiber")
+	srcFile.ImportName(tr.fiberPkg(), "fiber")
+	srcFile.ImportName(packageHttp, "http")
 
+	// routes are set at the second pass of options, when server of framework is created
+	route, router, routes := Qual(tr.fiberPkg(), "App"), "srvHTTP", "Fiber"
+	if tr.isNetHTTP() {
+		route, router, routes = Qual(packageHttp, "ServeMux"), "mux", "Mux"
+	}
 	srcFile.Line().Type().Id("ServiceRoute").Interface(
-		Id("SetRoutes").Params(Id("route").Op("*").Qual(packageFiber, "App")),
+		Id("SetRoutes").Params(Id("route").Op("*").Add(route)),
 	)
 
 	srcFile.Line().Type().Id("Option").Func().Params(Id("srv").Op("*").Id("Server"))
-	srcFile.Type().Id("Handler").Op("=").Qual(packageFiber, "Handler")
+	if tr.isNetHTTP() {
+		srcFile.Type().Id("Handler").Op("=").Func().Params(Id(_next_).Qual(packageHttp, "Handler")).Qual(packageHttp, "Handler")
+	} else {
+		srcFile.Type().Id("Handler").Op("=").Qual(tr.fiberPkg(), "Handler")
+	}
 	srcFile.Type().Id("ErrorHandler").Func().Params(Err().Error()).Params(Error())
 
 	srcFile.Line().Func().Id("Service").Params(Id("svc").Id("ServiceRoute")).Id("Option").Block(
 		Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
-			If(Id("srv").Dot("srvHTTP").Op("!=").Nil()).Block(
-				Id("svc").Dot("SetRoutes").Call(Id("srv").Dot("Fiber").Call()),
+			If(Id("srv").Dot(router).Op("!=").Nil()).Block(
+				Id("svc").Dot("SetRoutes").Call(Id("srv").Dot(routes).Call()),
 			),
 		)),
 	)
 	for _, serviceName := range tr.serviceKeys() {
 		srcFile.Line().Func().Id(serviceName).Params(Id("svc").Op("*").Id("http" + serviceName)).Id("Option").Block(
 			Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
-				If(Id("srv").Dot("srvHTTP").Op("!=").Nil()).BlockFunc(func(gr *Group) {
+				If(Id("srv").Dot(router).Op("!=").Nil()).BlockFunc(func(gr *Group) {
 					gr.Id("srv").Dot("http" + serviceName).Op("=").Id("svc")
 					if tr.hasJsonRPC {
 						gr.Id("svc").Dot("maxBatchSize").Op("=").Id("srv").Dot("maxBatchSize")
 						gr.Id("svc").Dot("maxParallelBatch").Op("=").Id("srv").Dot("maxParallelBatch")
 					}
-					gr.Id("svc").Dot("SetRoutes").Call(Id("srv").Dot("Fiber").Call())
+					gr.Id("svc").Dot("SetRoutes").Call(Id("srv").Dot(routes).Call())
 				}),
 			)),
 		)
 	}
-	srcFile.Line().Func().Id("SetFiberCfg").Params(Id("cfg").Qual(packageFiber, "Config")).Id("Option").Block(
-		Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
-			Id("srv").Dot("config").Op("=").Id("cfg"),
-			Id("srv").Dot("config").Dot("DisableStartupMessage").Op("=").True(),
-		)),
-	)
+	config := func() *Statement { return Id("srv").Dot("config") }
+	readBufferSize, bodyLimit := "ReadBufferSize", config().Dot("BodyLimit")
+	if tr.isNetHTTP() {
+		// net/http server has no read buffer, size of buffer of fasthttp limits size of headers
+		config = func() *Statement { return Id("srv").Dot("srvHTTP") }
+		readBufferSize, bodyLimit = "MaxHeaderBytes", Id("srv").Dot("maxBodySize")
+	} else {
+		srcFile.Line().Func().Id("SetFiberCfg").Params(Id("cfg").Qual(tr.fiberPkg(), "Config")).Id("Option").Block(
+			Return(Func().Params(Id("srv").Op("*").Id("Server")).BlockFunc(func(bg *Group) {
+				bg.Id("srv").Dot("config").Op("=").Id("cfg")
+				if !tr.isFiberV3() {
+					bg.Id("srv").Dot("config").Dot("DisableStartupMessage").Op("=").True()
+				}
+			})),
+		)
+	}
 	srcFile.Line().Func().Id("SetReadBufferSize").Params(Id("size").Int()).Id("Option").Block(
 		Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
-			Id("srv").Dot("config").Dot("ReadBufferSize").Op("=").Id("size"),
-		)),
-	)
-	srcFile.Line().Func().Id("SetWriteBufferSize").Params(Id("size").Int()).Id("Option").Block(
-		Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
-			Id("srv").Dot("config").Dot("WriteBufferSize").Op("=").Id("size"),
+			config().Dot(readBufferSize).Op("=").Id("size"),
 		)),
 	)
+	if !tr.isNetHTTP() {
+		srcFile.Line().Func().Id("SetWriteBufferSize").Params(Id("size").Int()).Id("Option").Block(
+			Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+				Id("srv").Dot("config").Dot("WriteBufferSize").Op("=").Id("size"),
+			)),
+		)
+	}
 	srcFile.Line().Func().Id("MaxBodySize").Params(Id("max").Int()).Id("Option").Block(
 		Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
-			Id("srv").Dot("config").Dot("BodyLimit").Op("=").Id("max"),
+			bodyLimit.Op("=").Id("max"),
 		)),
 	)
 	if tr.hasJsonRPC {
@@ -79,14 +101,29 @@ func (tr *Transport) renderOptions(outDir string) (err error) {
 			)),
 		)
 	}
+	if tr.hasWebSocket() {
+		origin := Id("r").Dot("Header").Dot("Get").Call(Lit("Origin"))
+		request := Id("r").Op("*").Qual(packageHttp, "Request")
+		if !tr.isNetHTTP() {
+			origin = String().Call(Id("r").Dot("Request").Dot("Header").Dot("Peek").Call(Lit("Origin")))
+			request = Id("r").Op("*").Qual(packageFasthttp, "RequestCtx")
+		}
+		srcFile.Line().Func().Id("WebSocketOrigin").Params(Id("check").Func().Params(Id("origin").String()).Bool()).Id("Option").Block(
+			Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+				Id("srv").Dot("wsUpgrader").Dot("CheckOrigin").Op("=").Func().Params(request).Bool().Block(
+					Return(Id("check").Call(origin)),
+				),
+			)),
+		)
+	}
 	srcFile.Line().Func().Id("ReadTimeout").Params(Id("timeout").Qual(packageTime, "Duration")).Id("Option").Block(
 		Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
-			Id("srv").Dot("config").Dot("ReadTimeout").Op("=").Id("timeout"),
+			config().Dot("ReadTimeout").Op("=").Id("timeout"),
 		)),
 	)
 	srcFile.Line().Func().Id("WriteTimeout").Params(Id("timeout").Qual(packageTime, "Duration")).Id("Option").Block(
 		Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
-			Id("srv").Dot("config").Dot("WriteTimeout").Op("=").Id("timeout"),
+			config().Dot("WriteTimeout").Op("=").Id("timeout"),
 		)),
 	)
 	srcFile.Line().Func().Id("WithRequestID").Params(Id("headerName").String()).Id("Option").Block(
@@ -112,6 +149,16 @@ func (tr *Transport) renderOptions(outDir string) (err error) {
 			Id("srv").Dot("headerHandlers").Op("[").Id("headerName").Op("]").Op("=").Id("handler"),
 		)),
 	)
+	if tr.isNetHTTP() {
+		srcFile.Line().Func().Id("Use").Params(Id("handlers").Op("...").Id("Handler")).Id("Option").Block(
+			Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+				If(Id("srv").Dot("mux").Op("!=").Nil()).Block(
+					Id("srv").Dot("middlewares").Op("=").Append(Id("srv").Dot("middlewares"), Id("handlers").Op("...")),
+				),
+			)),
+		)
+		return srcFile.Save(path.Join(outDir, "options.go"))
+	}
 	srcFile.Line().Func().Id("Use").Params(Id("args").Op("...").Interface()).Id("Option").Block(
 		Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
 			If(Id("srv").Dot("srvHTTP").Op("!=").Nil()).Block(
@@ -14,15 +14,15 @@ import (
 func (tr *Transport) renderServer(outDir string) (err error) {
 
 	if tr.hasTrace() {
-		if err = pkgCopyTo("tracer", outDir); err != nil {
+		if err = pkgCopyTo(tr.output(), tr.tracerPkg(), outDir); err != nil {
 			return
 		}
 	}
-	srcFile := newSrc(filepath.Base(outDir))
+	srcFile := tr.newSrc(filepath.Base(outDir))
 	srcFile.PackageComment(doNotEdit)
 
 	srcFile.ImportName(packageIO, "io")
-	srcFile.ImportName(packageFiber, "fiber")
+	srcFile.ImportName(tr.fiberPkg(), "fiber")
 	srcFile.ImportName(packageZeroLogLog, "log")
 	srcFile.ImportName(packageZeroLog, "zerolog")
 	srcFile.ImportName(packagePrometheus, "prometheus")
@@ -38,13 +38,30 @@ func (tr *Transport) renderServer(outDir string) (err error) {
 		srcFile.ImportName(svc.pkgPath, filepath.Base(svc.pkgPath))
 	}
 
-	srcFile.Line().Add(tr.serverType())
-	srcFile.Line().Add(tr.serverNewFunc(outDir))
-	srcFile.Line().Add(tr.fiberFunc())
-	srcFile.Line().Add(tr.withLogFunc())
-	srcFile.Line().Add(tr.serveHealthFunc())
-	srcFile.Line().Add(tr.sendResponseFunc())
-	srcFile.Line().Add(tr.shutdownFunc())
+	if tr.isNetHTTP() {
+		srcFile.ImportName(packageHttp, "http")
+		srcFile.ImportName(packageErrors, "errors")
+		srcFile.Line().Const().Id("defaultMaxBodySize").Op("=").Lit(4).Op("*").Lit(1024).Op("*").Lit(1024)
+		srcFile.Line().Add(tr.serverTypeNetHTTP())
+		srcFile.Line().Add(tr.serverNewNetHTTPFunc(outDir))
+		srcFile.Line().Add(tr.muxFunc())
+		srcFile.Line().Add(tr.serveHTTPFunc())
+		srcFile.Line().Add(tr.listenFunc())
+		srcFile.Line().Add(tr.withLogFunc())
+		srcFile.Line().Add(tr.overridable(&srcFile, OverrideHealth, nil, tr.serveHealthNetHTTPFunc()))
+		srcFile.Line().Add(tr.sendResponseNetHTTPFunc())
+		srcFile.Line().Add(tr.overridable(&srcFile, OverrideErrorResponse, nil, tr.sendErrorNetHTTPFunc()))
+		srcFile.Line().Add(tr.shutdownNetHTTPFunc())
+	} else {
+		srcFile.Line().Add(tr.serverType())
+		srcFile.Line().Add(tr.serverNewFunc(outDir))
+		srcFile.Line().Add(tr.fiberFunc())
+		srcFile.Line().Add(tr.withLogFunc())
+		srcFile.Line().Add(tr.overridable(&srcFile, OverrideHealth, nil, tr.serveHealthFunc()))
+		srcFile.Line().Add(tr.sendResponseFunc())
+		srcFile.Line().Add(tr.overridable(&srcFile, OverrideErrorResponse, nil, tr.sendErrorFunc()))
+		srcFile.Line().Add(tr.shutdownFunc())
+	}
 	if tr.hasTrace() {
 		srcFile.Line().Add(tr.withTraceFunc(outDir))
 	}
@@ -61,7 +78,7 @@ func (tr *Transport) renderServer(outDir string) (err error) {
 }
 
 func (tr *Transport) fiberFunc() Code {
-	return Func().Params(Id("srv").Op("*").Id("Server")).Id("Fiber").Params().Params(Op("*").Qual(packageFiber, "App")).Block(
+	return Func().Params(Id("srv").Op("*").Id("Server")).Id("Fiber").Params().Params(Op("*").Qual(tr.fiberPkg(), "App")).Block(
 		Return(Id("srv").Dot("srvHTTP")),
 	)
 }
@@ -162,15 +179,18 @@ func (tr *Transport) serverType() Code {
 		g.Id("log").Qual(packageZeroLog, "Logger")
 		g.Line().Id("httpAfter").Op("[]").Id("Handler")
 		g.Id("httpBefore").Op("[]").Id("Handler")
-		g.Line().Id("config").Qual(packageFiber, "Config")
-		g.Line().Id("srvHTTP").Op("*").Qual(packageFiber, "App")
-		g.Id("srvHealth").Op("*").Qual(packageFiber, "App")
-		g.Id("srvMetrics").Op("*").Qual(packageFiber, "App")
+		g.Line().Id("config").Qual(tr.fiberPkg(), "Config")
+		g.Line().Id("srvHTTP").Op("*").Qual(tr.fiberPkg(), "App")
+		g.Id("srvHealth").Op("*").Qual(tr.fiberPkg(), "App")
+		g.Id("srvMetrics").Op("*").Qual(tr.fiberPkg(), "App")
 		g.Line().Id("reporterCloser").Qual(packageIO, "Closer")
 		if tr.hasJsonRPC {
 			g.Line().Id("maxBatchSize").Int()
 			g.Id("maxParallelBatch").Int().Line()
 		}
+		if tr.hasWebSocket() {
+			g.Id("wsUpgrader").Qual(packageFasthttpWS, "FastHTTPUpgrader").Line()
+		}
 		for _, serviceName := range tr.serviceKeys() {
 			g.Id("http" + serviceName).Op("*").Id("http" + serviceName)
 		}
@@ -190,15 +210,13 @@ func (tr *Transport) serverNewFunc(outDir string) Code {
 					dict[Id("maxParallelBatch")] = Id("defaultMaxParallelBatch")
 				}
 				dict[Id("headerHandlers")] = Make(Map(String()).Id("HeaderHandler"))
-				dict[Id("config")] = Qual(packageFiber, "Config").Values(Dict{
-					Id("DisableStartupMessage"): True(),
-				})
+				dict[Id("config")] = tr.fiberQuietConfig()
 			},
 			))
 			bg.For(List(Id("_"), Id("option")).Op(":=").Range().Id("options")).Block(
 				Id("option").Call(Id("srv")),
 			)
-			bg.Id("srv").Dot("srvHTTP").Op("=").Qual(packageFiber, "New").Call(Id("srv").Dot("config"))
+			bg.Id("srv").Dot("srvHTTP").Op("=").Qual(tr.fiberPkg(), "New").Call(Id("srv").Dot("config"))
 			bg.Id("srv").Dot("srvHTTP").Dot("Use").Call(Id("recoverHandler"))
 			if tr.hasTrace() {
 				bg.Id("srv").Dot("srvHTTP").Dot("Use").Call(Qual(fmt.Sprintf("%s/tracer", tr.pkgPath(outDir)), "Middleware").Call())
@@ -212,6 +230,9 @@ func (tr *Transport) serverNewFunc(outDir string) Code {
 			if tr.hasJsonRPC {
 				bg.Id("srv").Dot("srvHTTP").Dot("Post").Call(Lit("/"+tr.tags.Value(tagHttpPrefix, "")), Id("srv").Dot("serveBatch"))
 			}
+			if tr.hasWebSocket() {
+				bg.Id("srv").Dot("srvHTTP").Dot("Get").Call(Lit(tr.wsPath()), Id("srv").Dot("serveWebSocket"))
+			}
 			bg.Return()
 		})
 }
@@ -220,13 +241,13 @@ func (tr *Transport) serveHealthFunc() Code {
 
 	return Func().Params(Id("srv").Op("*").Id("Server")).Id("ServeHealth").Params(Id("address").String(), Id("response").Interface()).Block(
 
-		Id("srv").Dot("srvHealth").Op("=").Qual(packageFiber, "New").Call(Qual(packageFiber, "Config").Values(Dict{Id("DisableStartupMessage"): True()})),
+		Id("srv").Dot("srvHealth").Op("=").Qual(tr.fiberPkg(), "New").Call(tr.fiberQuietConfig()),
 		Id("srv").Dot("srvHealth").Dot("Get").Call(Lit("/health"),
-			Func().Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx")).Params(Error()).Block(
+			Func().Params(tr.fiberCtx(_ctx_)).Params(Error()).Block(
 				Return().Id(_ctx_).Dot("JSON").Call(Id("response")),
 			)),
 		Go().Func().Params().Block(
-			Err().Op(":=").Id("srv").Dot("srvHealth").Dot("Listen").Call(Id("address")),
+			Err().Op(":=").Add(tr.fiberListen(Id("srv").Dot("srvHealth"))),
 			Id("ExitOnError").Call(Id("srv").Dot("log"), Err(), Lit("serve health on ").Op("+").Id("address")),
 		).Call(),
 	)
@@ -250,11 +271,22 @@ func (tr *Transport) shutdownFunc() Code {
 }
 
 func (tr *Transport) sendResponseFunc() Code {
-	return Func().Id("sendResponse").Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx"), Id("resp").Interface()).Params(Err().Error()).Block(
+	return Func().Id("sendResponse").Params(tr.fiberCtx(_ctx_), Id("resp").Interface()).Params(Err().Error()).Block(
 		Id(_ctx_).Dot("Response").Call().Dot("Header").Dot("SetContentType").Call(Lit("application/json")),
 		If(Err().Op("=").Qual(tr.tags.Value(tagPackageJSON, packageStdJSON), "NewEncoder").Call(Id(_ctx_)).Dot("Encode").Call(Id("resp")).Op(";").Err().Op("!=").Nil()).Block(
-			Qual(packageZeroLogLog, "Ctx").Call(Id(_ctx_).Dot("UserContext").Call()).Dot("Error").Call().Dot("Err").Call(Err()).Dot("Str").Call(Lit("body"), String().Call(Id(_ctx_).Dot("Body").Call())).Dot("Msg").Call(Lit("response write error")),
+			Qual(packageZeroLogLog, "Ctx").Call(tr.userContext(_ctx_)).Dot("Error").Call().Dot("Err").Call(Err()).Dot("Str").Call(Lit("body"), String().Call(Id(_ctx_).Dot("Body").Call())).Dot("Msg").Call(Lit("response write error")),
 		),
 		Return(),
 	)
 }
+
+func (tr *Transport) sendErrorFunc() Code {
+	return Func().Id("sendError").Params(tr.fiberCtx(_ctx_), Err().Error()).Params(Error()).Block(
+		If(List(Id("errCoder"), Id("ok")).Op(":=").Err().Op(".").Call(Id("withErrorCode")).Op(";").Id("ok")).Block(
+			Id(_ctx_).Dot("Status").Call(Id("errCoder").Dot("Code").Call()),
+		).Else().Block(
+			Id(_ctx_).Dot("Status").Call(Qual(tr.fiberPkg(), "StatusInternalServerError")),
+		),
+		Return().Id("sendResponse").Call(Id(_ctx_), Err()),
+	)
+}
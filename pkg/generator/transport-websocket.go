@@ -0,0 +1,168 @@
+package generator
+
+import (
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen" // nolint:staticcheck
+)
+
+// renderWebSocket renders WebSocket endpoint of JSON-RPC: connection accepts single and batch requests,
+// which are served by the same handlers as requests of HTTP.
+func (tr *Transport) renderWebSocket(outDir string) (err error) {
+
+	srcFile := tr.newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	srcFile.ImportName(tr.fiberPkg(), "fiber")
+	srcFile.ImportName(packageHttp, "http")
+	srcFile.ImportName(tr.wsPkg(), "websocket")
+	srcFile.ImportName(packageSync, "sync")
+	srcFile.ImportName(packageContext, "context")
+	srcFile.ImportName(packageErrors, "errors")
+	srcFile.ImportName(packageZeroLogLog, "log")
+	srcFile.ImportName(tr.tags.Value(tagPackageJSON, packageStdJSON), "json")
+
+	srcFile.Line().Comment("wsConn is a connection of WebSocket, which JSON-RPC requests are read from.")
+	srcFile.Type().Id("wsConn").Interface(
+		Id("ReadMessage").Params().Params(Id("messageType").Int(), Id("data").Op("[]").Byte(), Err().Error()),
+		Id("WriteMessage").Params(Id("messageType").Int(), Id("data").Op("[]").Byte()).Error(),
+		Id("Close").Params().Error(),
+	)
+	srcFile.Line().Add(tr.serveWebSocketFunc())
+	srcFile.Line().Add(tr.serveConnFunc())
+	srcFile.Line().Add(tr.doMessageFunc())
+	return srcFile.Save(path.Join(outDir, "websocket.go"))
+}
+
+// wsPkg returns package of WebSocket for backend: fasthttp fork of gorilla websocket for fiber.
+func (tr *Transport) wsPkg() string {
+
+	if tr.isNetHTTP() {
+		return packageWebSocket
+	}
+	return packageFasthttpWS
+}
+
+func (tr *Transport) serveWebSocketFunc() Code {
+
+	if tr.isNetHTTP() {
+		return Func().Params(Id("srv").Op("*").Id("Server")).Id("serveWebSocket").Params(tr.exchangeParams(_ctx_)...).Block(
+			Line().List(Id("conn"), Err()).Op(":=").Id("srv").Dot("wsUpgrader").Dot("Upgrade").Call(Id("w"), Id("r"), Nil()),
+			If(Err().Op("!=").Nil()).Block(
+				Return(),
+			),
+			Id("conn").Dot("SetReadLimit").Call(Int64().Call(Id("srv").Dot("maxBodySize"))),
+			Id("srv").Dot("serveConn").Call(Id("r").Dot("Context").Call(), Id("conn")),
+		)
+	}
+	return Func().Params(Id("srv").Op("*").Id("Server")).Id("serveWebSocket").Params(tr.fiberCtx(_ctx_)).Params(Err().Error()).Block(
+		Line().Id("userCtx").Op(":=").Add(tr.userContext(_ctx_)),
+		Return(Id("srv").Dot("wsUpgrader").Dot("Upgrade").Call(tr.requestCtx(_ctx_), Func().Params(Id("conn").Op("*").Qual(packageFasthttpWS, "Conn")).Block(
+			If(Id("srv").Dot("config").Dot("BodyLimit").Op(">").Lit(0)).Block(
+				Id("conn").Dot("SetReadLimit").Call(Int64().Call(Id("srv").Dot("config").Dot("BodyLimit"))),
+			),
+			Id("srv").Dot("serveConn").Call(Id("userCtx"), Id("conn")),
+		))),
+	)
+}
+
+func (tr *Transport) serveConnFunc() Code {
+
+	return Comment("serveConn reads requests of connection until it is closed. Requests are executed concurrently,").Line().
+		Comment("but not more than maxParallelBatch at once, responses are written in order of completion.").Line().
+		Func().Params(Id("srv").Op("*").Id("Server")).Id("serveConn").Params(Id("userCtx").Qual(packageContext, "Context"), Id("conn").Id("wsConn")).BlockFunc(func(bg *Group) {
+
+		bg.Line()
+		bg.Var().Id("wg").Qual(packageSync, "WaitGroup")
+		bg.Var().Id("mutex").Qual(packageSync, "Mutex")
+		bg.List(Id(_ctx_), Id("cancel")).Op(":=").Qual(packageContext, "WithCancel").Call(Id("userCtx"))
+		bg.Id("workers").Op(":=").Id("srv").Dot("maxParallelBatch")
+		bg.If(Id("workers").Op("<").Lit(1)).Block(
+			Id("workers").Op("=").Lit(1),
+		)
+		bg.Id("jobs").Op(":=").Make(Chan().Struct(), Id("workers"))
+		bg.Defer().Func().Params().Block(
+			Id("cancel").Call(),
+			Id("wg").Dot("Wait").Call(),
+			Id("_").Op("=").Id("conn").Dot("Close").Call(),
+		).Call()
+		bg.For().BlockFunc(func(fg *Group) {
+			fg.List(Id("_"), Id("message"), Err()).Op(":=").Id("conn").Dot("ReadMessage").Call()
+			fg.If(Err().Op("!=").Nil()).Block(
+				If(Qual(tr.wsPkg(), "IsUnexpectedCloseError").Call(Err(), Qual(tr.wsPkg(), "CloseNormalClosure"), Qual(tr.wsPkg(), "CloseGoingAway"))).Block(
+					Qual(packageZeroLogLog, "Ctx").Call(Id(_ctx_)).Dot("Debug").Call().Dot("Err").Call(Err()).Dot("Msg").Call(Lit("websocket closed")),
+				),
+				Return(),
+			)
+			fg.Id("jobs").Op("<-").Struct().Values()
+			fg.Id("wg").Dot("Add").Call(Lit(1))
+			fg.Go().Func().Params().Block(
+				Defer().Func().Params().Block(
+					Op("<-").Id("jobs"),
+					Id("wg").Dot("Done").Call(),
+				).Call(),
+				Id("response").Op(":=").Id("srv").Dot("doMessage").Call(Id(_ctx_), Id("message")),
+				If(Id("response").Op("==").Nil()).Block(
+					Return(),
+				),
+				List(Id("data"), Err()).Op(":=").Qual(tr.tags.Value(tagPackageJSON, packageStdJSON), "Marshal").Call(Id("response")),
+				If(Err().Op("!=").Nil()).Block(
+					Qual(packageZeroLogLog, "Ctx").Call(Id(_ctx_)).Dot("Error").Call().Dot("Err").Call(Err()).Dot("Msg").Call(Lit("response encode error")),
+					Return(),
+				),
+				Id("mutex").Dot("Lock").Call(),
+				Defer().Id("mutex").Dot("Unlock").Call(),
+				If(Err().Op("=").Id("conn").Dot("WriteMessage").Call(Qual(tr.wsPkg(), "TextMessage"), Id("data")).Op(";").Err().Op("!=").Nil()).Block(
+					Qual(packageZeroLogLog, "Ctx").Call(Id(_ctx_)).Dot("Error").Call().Dot("Err").Call(Err()).Dot("Msg").Call(Lit("response write error")),
+				),
+			).Call()
+		})
+	})
+}
+
+// doMessageFunc returns function, which executes message of connection as single or batch request.
+// Response of message is nil, when there is nothing to answer. Panic of single request is answered by internal error.
+func (tr *Transport) doMessageFunc() Code {
+
+	packageJSON := tr.tags.Value(tagPackageJSON, packageStdJSON)
+	return Func().Params(Id("srv").Op("*").Id("Server")).Id("doMessage").Params(Id("userCtx").Qual(packageContext, "Context"), Id("message").Op("[]").Byte()).Params(Id("response").Any()).Block(
+		Line().Var().Id("request").Id("baseJsonRPC"),
+		Var().Id("requests").Op("[]").Id("baseJsonRPC"),
+		Defer().Func().Params().Block(
+			If(Id("r").Op(":=").Recover().Op(";").Id("r").Op("!=").Nil()).Block(
+				List(Err(), Id("ok")).Op(":=").Id("r").Op(".").Call(Error()),
+				If(Op("!").Id("ok")).Block(
+					Err().Op("=").Qual(packageErrors, "New").Call(Qual(packageFmt, "Sprintf").Call(Lit("%v"), Id("r"))),
+				),
+				Qual(packageZeroLogLog, "Ctx").Call(Id("userCtx")).Dot("Error").Call().Dot("Stack").Call().Dot("Err").Call(Qual(packageErrors, "Wrap").Call(Err(), Lit("recover"))).Dot("Msg").Call(Lit("panic occurred")),
+				Id("response").Op("=").Nil(),
+				If(Id("errResponse").Op(":=").Id("makeErrorResponseJsonRPC").Call(Id("request").Dot("ID"), Id("internalError"), Err().Dot("Error").Call(), Nil()).Op(";").Id("errResponse").Op("!=").Nil()).Block(
+					Id("response").Op("=").Id("errResponse"),
+				),
+			),
+		).Call(),
+		If(Err().Op(":=").Qual(packageJSON, "Unmarshal").Call(Id("message"), Op("&").Id("requests")).Op(";").Err().Op("!=").Nil()).Block(
+			If(Err().Op("=").Qual(packageJSON, "Unmarshal").Call(Id("message"), Op("&").Id("request")).Op(";").Err().Op("!=").Nil()).Block(
+				Return(Id("makeErrorResponseJsonRPC").Call(Op("[]").Byte().Call(Lit("null")), Id("parseError"), Lit("request body could not be decoded: ").Op("+").Err().Dot("Error").Call(), Nil())),
+			),
+			If(Id("single").Op(":=").Id("srv").Dot("doSingleBatch").Call(append(append([]Code{Id("userCtx")}, tr.exchangeNil()...), Id("request"))...).Op(";").Id("single").Op("!=").Nil().Op("&&").Id("request").Dot("ID").Op("!=").Nil()).Block(
+				Return(Id("single")),
+			),
+			Return(Nil()),
+		),
+		If(Len(Id("requests")).Op(">").Id("srv").Dot("maxBatchSize")).Block(
+			Return(Op("&").Id("baseJsonRPC").Values(Dict{
+				Id("Version"): Id("Version"),
+				Id("Error"): Op("&").Id("errorJsonRPC").Values(Dict{
+					Id("Code"):    Id("invalidRequestError"),
+					Id("Message"): Lit("batch size exceeded"),
+				}),
+			})),
+		),
+		If(Id("responses").Op(":=").Id("srv").Dot("doParallelBatch").Call(Id("userCtx"), Id("requests")).Op(";").Len(Id("responses")).Op("!=").Lit(0)).Block(
+			Return(Id("responses")),
+		),
+		Return(Nil()),
+	)
+}
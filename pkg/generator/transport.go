@@ -4,6 +4,7 @@
 package generator
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/seniorGolang/tg/v2/pkg/astra"
 	"github.com/seniorGolang/tg/v2/pkg/astra/types"
+	"github.com/seniorGolang/tg/v2/pkg/diagnostic"
 	"github.com/seniorGolang/tg/v2/pkg/mod"
 	"github.com/seniorGolang/tg/v2/pkg/tags"
 )
@@ -26,67 +28,98 @@ const keyCode = "code"
 
 const doNotEdit = "GENERATED BY 'T'ransport 'G'enerator. DO NOT EDIT."
 
+// names of annotations are declared by registry of annotations
 const (
-	tagLogger                 = "log"
-	tagDesc                   = "desc"
-	tagType                   = "type"
-	tagTag                    = "tags"
-	tagTests                  = "tests"
-	tagTrace                  = "trace"
-	tagEnums                  = "enums"
-	tagFormat                 = "format"
-	tagRequired               = "required"
-	tagSummary                = "summary"
-	tagHandler                = "handler"
-	tagExample                = "example"
-	tagMetrics                = "metrics"
-	tagHttpArg                = "http-args"
-	tagHttpPath               = "http-path"
-	tagDeprecated             = "deprecated"
-	tagHttpPrefix             = "http-prefix"
-	tagMethodHTTP             = "http-method"
-	tagServerHTTP             = "http-server"
-	tagHttpHeader             = "http-headers"
-	tagHttpCookies            = "http-cookies"
-	tagHttpSuccess            = "http-success"
-	tagServerJsonRPC          = "jsonRPC-server"
-	tagHttpResponse           = "http-response"
-	tagPackageJSON            = "packageJSON"
-	tagPackageUUID            = "uuidPackage"
-	tagSwaggerTags            = "swaggerTags"
-	tagLogSkip                = "log-skip"
-	tagEnableClientCB         = "clientWithCB"
-	tagDisableOmitEmpty       = "tagNoOmitempty"
-	tagRequestContentType     = "requestContentType"
-	tagResponseContentType    = "responseContentType"
-	tagHttpEnableInlineSingle = "enableInlineSingle"
-
-	tagTitle       = "title"
-	tagNameNPM     = "npmName"
-	tagServers     = "servers"
-	tagSecurity    = "security"
-	tagAppVersion  = "version"
-	tagAuthor      = "author"
-	tagLicense     = "license"
-	tagPrivateNPM  = "npmPrivate"
-	tagRegistryNPM = "npmRegistry"
+	tagLogger                 = tags.Log
+	tagDesc                   = tags.Desc
+	tagType                   = tags.Type
+	tagTag                    = tags.Tags
+	tagTests                  = tags.Tests
+	tagTrace                  = tags.Trace
+	tagEnums                  = tags.Enums
+	tagFormat                 = tags.Format
+	tagRequired               = tags.Required
+	tagSummary                = tags.Summary
+	tagHandler                = tags.Handler
+	tagExample                = tags.Example
+	tagMetrics                = tags.Metrics
+	tagHttpArg                = tags.HTTPArgs
+	tagHttpPath               = tags.HTTPPath
+	tagDeprecated             = tags.Deprecated
+	tagHttpPrefix             = tags.HTTPPrefix
+	tagMethodHTTP             = tags.HTTPMethod
+	tagServerHTTP             = tags.HTTPServer
+	tagServerGRPC             = tags.GRPCServer
+	tagHttpHeader             = tags.HTTPHeaders
+	tagHttpCookies            = tags.HTTPCookies
+	tagHttpSuccess            = tags.HTTPSuccess
+	tagServerJsonRPC          = tags.JsonRPCServer
+	tagJsonRPCWebSocket       = tags.JsonRPCWebSocket
+	tagNotify                 = tags.Notify
+	tagHttpResponse           = tags.HTTPResponse
+	tagPackageJSON            = tags.PackageJSON
+	tagPackageUUID            = tags.PackageUUID
+	tagSwaggerTags            = tags.SwaggerTags
+	tagLogSkip                = tags.LogSkip
+	tagEnableClientCB         = tags.ClientWithCB
+	tagDisableOmitEmpty       = tags.NoOmitEmpty
+	tagRequestContentType     = tags.RequestContentType
+	tagResponseContentType    = tags.ResponseContentType
+	tagHttpEnableInlineSingle = tags.EnableInlineSingle
+	tagDefaultError           = tags.DefaultError
+	tagNullable               = tags.Nullable
+
+	tagTitle       = tags.Title
+	tagNameNPM     = tags.NameNPM
+	tagServers     = tags.Servers
+	tagSecurity    = tags.Security
+	tagAppVersion  = tags.Version
+	tagAuthor      = tags.Author
+	tagLicense     = tags.License
+	tagPrivateNPM  = tags.PrivateNPM
+	tagRegistryNPM = tags.RegistryNPM
 )
 
 type Transport struct {
 	hasHTTP    bool
 	hasJsonRPC bool
+	hasGRPC    bool
 	version    string
+	svcDir     string
 	modPath    string
 	tags       tags.DocTags
+	pkgDocs    []types.Base
 	module     *modfile.File
 	log        logrus.FieldLogger
 	services   map[string]*service
+	state      *renderState
+}
+
+// renderState is shared between copies of Transport and its services.
+type renderState struct {
+	strict      bool
+	incremental bool
+	verify      bool
+	backend     string
+	errs        []error
+	out         Output
+	overrides   map[string]overrideSnippet
+	diags       diagnostic.List
+	loader      *packagesLoader
 }
 
 func NewTransport(log logrus.FieldLogger, version, svcDir string, ifaces ...string) (tr Transport, err error) {
+	return NewTransportWithDefaults(log, version, svcDir, nil, ifaces...)
+}
+
+// NewTransportWithDefaults works as NewTransport, but starts from default package-level annotations
+// (for example, from 'tg.yaml'). Annotations of package in code override defaults.
+func NewTransportWithDefaults(log logrus.FieldLogger, version, svcDir string, defaults tags.DocTags, ifaces ...string) (tr Transport, err error) {
 
 	tr.log = log
 	tr.version = version
+	tr.state = &renderState{}
+	tr.tags = make(tags.DocTags).Merge(defaults)
 	var files []os.DirEntry
 	tr.services = make(map[string]*service)
 	var include, exclude = make([]string, 0, len(ifaces)), make([]string, 0, len(ifaces))
@@ -107,6 +140,7 @@ func NewTransport(log logrus.FieldLogger, version, svcDir string, ifaces ...stri
 	if files, err = os.ReadDir(svcDir); err != nil {
 		return
 	}
+	tr.svcDir, _ = filepath.Abs(svcDir)
 	var interfaces []pair[string, types.Interface]
 	for _, file := range files {
 		if file.IsDir() || !strings.HasSuffix(file.Name(), ".go") {
@@ -119,10 +153,15 @@ func NewTransport(log logrus.FieldLogger, version, svcDir string, ifaces ...stri
 			return
 		}
 		tr.tags = tr.tags.Merge(tags.ParseTags(serviceAst.Docs))
+		tr.pkgDocs = append(tr.pkgDocs, serviceAst.Base)
 		for _, iface := range serviceAst.Interfaces {
 			interfaces = append(interfaces, newPair(filePath, iface))
 		}
 	}
+	var localInterfaces []types.Interface
+	for _, ifacePair := range interfaces {
+		localInterfaces = append(localInterfaces, ifacePair.Value)
+	}
 	for _, ifacePair := range interfaces {
 		filePath, iface := ifacePair.Key, ifacePair.Value
 		if len(include) != 0 {
@@ -138,7 +177,7 @@ func NewTransport(log logrus.FieldLogger, version, svcDir string, ifaces ...stri
 			}
 		}
 		if len(tags.ParseTags(iface.Docs)) != 0 {
-			service := newService(log, &tr, filePath, iface)
+			service := newService(log, &tr, filePath, iface, localInterfaces)
 			tr.services[iface.Name] = service
 			if service.tags.Contains(tagServerHTTP) {
 				tr.hasHTTP = true
@@ -146,17 +185,56 @@ func NewTransport(log logrus.FieldLogger, version, svcDir string, ifaces ...stri
 			if service.tags.Contains(tagServerJsonRPC) {
 				tr.hasJsonRPC = true
 			}
+			if service.tags.Contains(tagServerGRPC) {
+				tr.hasGRPC = true
+			}
 		}
 	}
 	return
 }
 
+// SetStrict enables strict mode: any failed render step fails the whole run
+// and output directory is restored to the state before generation.
+func (tr *Transport) SetStrict(strict bool) {
+	tr.state.strict = strict
+}
+
+// SourceDirs returns directories, which contract depends on: services package and packages of used types.
+// Packages outside of project and modules of workspace (module cache, standard library) are skipped.
+func (tr *Transport) SourceDirs() (dirs []string) {
+
+	dirs = append(dirs, tr.svcDir)
+	projectPath := filepath.Clean(mod.GoProjectPath(tr.svcDir))
+	for _, def := range tr.Contract().Types {
+		if isStdPackage(def.Package) {
+			continue
+		}
+		dir := searchTypeDir(tr.svcDir, def.Package)
+		if dir == "" || slices.Contains(dirs, dir) {
+			continue
+		}
+		if !strings.HasPrefix(dir, projectPath+string(filepath.Separator)) && mod.LocalPkgDir(tr.svcDir, def.Package) == "" {
+			continue
+		}
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs[1:])
+	return
+}
+
 func (tr *Transport) RenderAzure(appName, routePrefix, outDir, logLevel string, enableHealth bool) (err error) {
-	return newAzure(tr).render(appName, routePrefix, outDir, logLevel, enableHealth)
+
+	options := []any{appName, routePrefix, logLevel, enableHealth}
+	return tr.renderIncremental(targetAzure, outDir, options, func() error {
+		return newAzure(tr).render(appName, routePrefix, outDir, logLevel, enableHealth)
+	})
 }
 
 func (tr *Transport) RenderSwagger(outDir string, interfaces ...string) (err error) {
-	return newSwagger(tr).render(outDir, interfaces...)
+
+	return tr.renderIncremental(targetSwagger+":"+filepath.Base(outDir), filepath.Dir(outDir), interfaces, func() error {
+		return newSwagger(tr).render(outDir, interfaces...)
+	})
 }
 
 func (tr *Transport) serviceKeys() (keys []string) {
@@ -169,61 +247,104 @@ func (tr *Transport) serviceKeys() (keys []string) {
 }
 
 func (tr *Transport) RenderClient(outDir string) (err error) {
+	return tr.renderIncremental(targetClient, outDir, nil, func() error { return tr.renderClientFiles(outDir) })
+}
+
+func (tr *Transport) renderClientFiles(outDir string) (err error) {
+
+	var snapshot *dirSnapshot
+	if snapshot, err = tr.beginRender(outDir); err != nil {
+		return
+	}
+	defer func() { err = tr.endRender(snapshot, err) }()
 
 	tr.cleanup(outDir)
-	if err = os.MkdirAll(outDir, 0777); err != nil {
+	if err = tr.output().MkdirAll(outDir, 0777); err != nil {
 		return
 	}
 
 	if tr.hasHTTP {
-		showError(tr.log, tr.renderVersion(outDir, false), "renderVersion")
-		showError(tr.log, tr.renderClientError(outDir), "renderClientError")
+		tr.showError(tr.renderVersion(outDir, false), "renderVersion")
+		tr.showError(tr.renderClientError(outDir), "renderClientError")
 	}
 	if tr.hasJsonRPC {
-		showError(tr.log, tr.renderClientOptions(outDir), "renderClientOptions")
-		showError(tr.log, tr.renderVersion(outDir, false), "renderVersion")
-		showError(tr.log, tr.renderClientJsonRPC(outDir), "renderClientJsonRPC")
-		showError(tr.log, tr.renderClientError(outDir), "renderClientError")
-		showError(tr.log, tr.renderClientBatch(outDir), "renderClientBatch")
-		showError(tr.log, tr.renderClientCache(outDir), "renderClientCache")
+		tr.showError(tr.renderClientOptions(outDir), "renderClientOptions")
+		tr.showError(tr.renderVersion(outDir, false), "renderVersion")
+		tr.showError(tr.renderClientJsonRPC(outDir), "renderClientJsonRPC")
+		tr.showError(tr.renderClientError(outDir), "renderClientError")
+		tr.showError(tr.renderClientBatch(outDir), "renderClientBatch")
+		tr.showError(tr.renderClientCache(outDir), "renderClientCache")
+	}
+	if tr.hasGRPC {
+		tr.showError(tr.renderGRPC(outDir, false), "renderGRPC")
 	}
 	for _, serviceName := range tr.serviceKeys() {
 		svc := tr.services[serviceName]
-		showError(tr.log, svc.renderClient(outDir), "renderHTTP")
+		svc.showError(svc.renderClient(outDir), "renderClient")
 	}
 	return
 }
 
 func (tr *Transport) RenderServer(outDir string) (err error) {
+	return tr.renderIncremental(targetTransport, outDir, tr.backend(), func() error { return tr.renderTransportFiles(outDir) })
+}
+
+func (tr *Transport) renderTransportFiles(outDir string) (err error) {
+
+	var snapshot *dirSnapshot
+	if snapshot, err = tr.beginRender(outDir); err != nil {
+		return
+	}
+	defer func() { err = tr.endRender(snapshot, err) }()
 
 	tr.cleanup(outDir)
 
-	if err = os.MkdirAll(outDir, 0777); err != nil {
+	if err = tr.output().MkdirAll(outDir, 0777); err != nil {
 		return
 	}
 
-	showError(tr.log, tr.renderHTTP(outDir), "renderHTTP")
-	showError(tr.log, tr.renderContext(outDir), "renderCtx")
-	showError(tr.log, tr.renderFiber(outDir), "renderFiber")
-	showError(tr.log, tr.renderHeader(outDir), "renderHeader")
-	showError(tr.log, tr.renderErrors(outDir), "renderErrors")
-	showError(tr.log, tr.renderServer(outDir), "renderServer")
-	showError(tr.log, tr.renderOptions(outDir), "renderOptions")
-	showError(tr.log, tr.renderMetrics(outDir), "renderMetrics")
-	showError(tr.log, tr.renderVersion(outDir, false), "renderVersion")
+	tr.showError(tr.renderHTTP(outDir), "renderHTTP")
+	tr.showError(tr.renderContext(outDir), "renderCtx")
+	if tr.isNetHTTP() {
+		tr.showError(tr.renderMiddleware(outDir), "renderMiddleware")
+	} else {
+		tr.showError(tr.renderFiber(outDir), "renderFiber")
+	}
+	tr.showError(tr.renderHeader(outDir), "renderHeader")
+	tr.showError(tr.renderErrors(outDir), "renderErrors")
+	tr.showError(tr.renderServer(outDir), "renderServer")
+	tr.showError(tr.renderOptions(outDir), "renderOptions")
+	tr.showError(tr.renderMetrics(outDir), "renderMetrics")
+	tr.showError(tr.renderVersion(outDir, false), "renderVersion")
 	if tr.hasMetrics() {
-		showError(tr.log, tr.renderMetrics(outDir), "renderMetrics")
+		tr.showError(tr.renderMetrics(outDir), "renderMetrics")
 	}
 	if tr.hasJsonRPC {
-		showError(tr.log, tr.renderJsonRPC(outDir), "renderJsonRPC")
+		tr.showError(tr.renderJsonRPC(outDir), "renderJsonRPC")
+	}
+	if tr.hasWebSocket() {
+		tr.showError(tr.renderWebSocket(outDir), "renderWebSocket")
+	}
+	if tr.hasGRPC {
+		tr.showError(tr.renderGRPC(outDir, true), "renderGRPC")
 	}
 	for _, serviceName := range tr.serviceKeys() {
 		svc := tr.services[serviceName]
-		err = svc.render(outDir)
+		svc.render(outDir)
 	}
 	return
 }
 
+// hasWebSocket returns true, when JSON-RPC of services is served by WebSocket too.
+func (tr *Transport) hasWebSocket() bool {
+	return tr.hasJsonRPC && tr.tags.Value(tagJsonRPCWebSocket) != ""
+}
+
+// wsPath returns URL path of WebSocket endpoint of JSON-RPC.
+func (tr *Transport) wsPath() string {
+	return path.Join("/", tr.tags.Value(tagHttpPrefix), tr.tags.Value(tagJsonRPCWebSocket))
+}
+
 func (tr *Transport) hasTrace() (hasTrace bool) {
 	for _, serviceName := range tr.serviceKeys() {
 		svc := tr.services[serviceName]
@@ -244,10 +365,63 @@ func (tr *Transport) hasMetrics() (hasMetric bool) {
 	return
 }
 
-func showError(log logrus.FieldLogger, err error, msg string) {
+// showError logs failed render step and keeps it for the result of run.
+// Step of transport is reported at package clause of services package.
+func (tr *Transport) showError(err error, msg string) {
+	tr.showErrorAt(tr.position(), err, msg)
+}
+
+func (tr *Transport) showErrorAt(pos types.Position, err error, msg string) {
 	if err != nil {
-		log.WithError(err).Error(msg)
+		tr.log.WithError(err).Error(msg)
+		tr.state.errs = append(tr.state.errs, fmt.Errorf("%s: %w", msg, err))
+		tr.state.diags = append(tr.state.diags, diagnostic.Errorf(pos, ruleRender, "%s: %v", msg, err))
+	}
+}
+
+// position returns position of package clause of services package.
+func (tr *Transport) position() types.Position {
+
+	for _, pkgDoc := range tr.pkgDocs {
+		if pkgDoc.Pos.IsValid() {
+			return pkgDoc.Pos
+		}
+	}
+	return types.Position{Filename: tr.svcDir}
+}
+
+// Diagnostics returns problems of rendering: failed render steps and types, which cannot be described.
+// They are collected since transport is created.
+func (tr *Transport) Diagnostics() diagnostic.List {
+	return tr.state.diags
+}
+
+func (tr *Transport) beginRender(outDir string) (snapshot *dirSnapshot, err error) {
+
+	tr.state.errs = nil
+	if !tr.state.strict || !tr.onDisk() {
+		return
+	}
+	if snapshot, err = takeSnapshot(outDir); err != nil {
+		err = fmt.Errorf("snapshot of %s: %w", outDir, err)
+	}
+	return
+}
+
+// endRender returns aggregated error of run in strict mode and restores output directory on failure.
+func (tr *Transport) endRender(snapshot *dirSnapshot, err error) error {
+
+	if !tr.state.strict {
+		return err
+	}
+	if err = errors.Join(append([]error{err}, tr.state.errs...)...); err == nil || snapshot == nil {
+		return err
+	}
+	if restoreErr := snapshot.restore(); restoreErr != nil {
+		return errors.Join(err, fmt.Errorf("restore %s: %w", snapshot.root, restoreErr))
 	}
+	tr.log.WithField("dir", snapshot.root).Warn("generation failed, output restored")
+	return err
 }
 
 func (tr *Transport) goMod(svcDir string) (err error) {
@@ -12,16 +12,22 @@ export class RpcError extends Error {
     }
 }
 
+// RpcTransport resolves notification, which is request without id, by undefined.
 export type RpcTransport = (
     req: JsonRpcRequest,
     abortSignal: AbortSignal
-) => Promise<JsonRpcResponse>;
+) => Promise<JsonRpcResponse | undefined>;
 
 type RpcClientOptions =
     | string
-    | FetchOptions
-    | {
+    | (FetchOptions & NotifyOptions)
+    | ({
     transport: RpcTransport;
+} & NotifyOptions);
+
+type NotifyOptions = {
+    // notify maps name of client function to method, which is called as notification
+    notify?: Record<string, string>;
 };
 
 type FetchOptions = {
@@ -47,12 +53,17 @@ export function rpcClient<T extends object>(options: RpcClientOptions) {
 
     const transport =
         "transport" in options ? options.transport : fetchTransport(options);
+    const notify = options.notify ?? {};
 
     const sendRequest = async (method: string, params: any, signal: AbortSignal) => {
+        if (Object.prototype.hasOwnProperty.call(notify, method)) {
+            await transport(createNotification(notify[method], params), signal);
+            return;
+        }
         const res = await transport(createRequest(method, params), signal);
-        if ("result" in res) {
+        if (res && "result" in res) {
             return res.result;
-        } else if ("error" in res) {
+        } else if (res && "error" in res) {
             const {code, message, data} = res.error;
             throw new RpcError(message, code, data);
         }
@@ -103,6 +114,14 @@ export function createRequest(method: string, params: any): JsonRpcRequest {
     };
 }
 
+export function createNotification(method: string, params: any): JsonRpcRequest {
+    return {
+        jsonrpc: "2.0",
+        method,
+        params: params,
+    };
+}
+
 export function fetchTransport(options: FetchOptions): RpcTransport {
     return async (req: JsonRpcRequest, signal: AbortSignal): Promise<any> => {
         const headers = options?.getHeaders ? await options.getHeaders() : {};
@@ -120,6 +139,140 @@ export function fetchTransport(options: FetchOptions): RpcTransport {
         if (!res.ok) {
             throw new RpcError(res.statusText, res.status);
         }
+        if (req.id === undefined) {
+            return undefined;
+        }
         return await res.json();
     };
 }
+
+type WebSocketOptions = {
+    url: string;
+    reconnectDelay?: number;
+    maxReconnectDelay?: number;
+    timeout?: number;
+};
+
+// WebSocketTransport is RpcTransport, which keeps connection, close stops reconnecting and rejects pending requests.
+export type WebSocketTransport = RpcTransport & {
+    close(): void;
+};
+
+type PendingRequest = {
+    id: JsonRpcRequest["id"];
+    message: string;
+    sent: boolean;
+    notify: boolean;
+    timer: ReturnType<typeof setTimeout>;
+    resolve: (res?: JsonRpcResponse) => void;
+    reject: (err: Error) => void;
+};
+
+export function webSocketTransport(options: WebSocketOptions): WebSocketTransport {
+
+    const reconnectDelay = options.reconnectDelay ?? 1000;
+    const maxReconnectDelay = options.maxReconnectDelay ?? 30000;
+    const timeout = options.timeout ?? 30000;
+    const pending = new Map<number, PendingRequest>();
+    let queue: number[] = [];
+    let requestID = 0;
+    let attempt = 0;
+    let closed = false;
+    let reconnectTimer: ReturnType<typeof setTimeout> | undefined;
+    let socket: WebSocket;
+
+    const rejectPending = (err: Error, sentOnly: boolean) => {
+        pending.forEach((request, key) => {
+            if (request.sent || !sentOnly) {
+                clearTimeout(request.timer);
+                pending.delete(key);
+                request.reject(err);
+            }
+        });
+    };
+
+    const send = (key: number) => {
+        const request = pending.get(key);
+        if (!request) return;
+        if (socket.readyState !== WebSocket.OPEN) {
+            queue.push(key);
+            return;
+        }
+        request.sent = true;
+        socket.send(request.message);
+        if (request.notify) {
+            clearTimeout(request.timer);
+            pending.delete(key);
+            request.resolve();
+        }
+    };
+    const connect = () => {
+        socket = new WebSocket(options.url);
+        socket.onopen = () => {
+            attempt = 0;
+            const keys = queue;
+            queue = [];
+            keys.forEach(send);
+        };
+        socket.onmessage = (event: MessageEvent) => {
+            const res = JSON.parse(event.data);
+            if (res.id === undefined || res.id === null) {
+                // error without id (parsing of message) cannot be matched to request
+                if (res.error) {
+                    rejectPending(new RpcError(res.error.message, res.error.code, res.error.data), true);
+                }
+                return;
+            }
+            const request = pending.get(res.id);
+            if (!request) return;
+            pending.delete(res.id);
+            clearTimeout(request.timer);
+            request.resolve({...res, id: request.id});
+        };
+        socket.onclose = () => {
+            rejectPending(new RpcError("websocket connection closed", 0), true);
+            if (closed) {
+                return;
+            }
+            const delay = Math.min(reconnectDelay * 2 ** attempt, maxReconnectDelay);
+            attempt++;
+            reconnectTimer = setTimeout(connect, delay);
+        };
+    };
+    connect();
+
+    const transport = (req: JsonRpcRequest, signal: AbortSignal): Promise<JsonRpcResponse | undefined> => {
+        return new Promise((resolve, reject) => {
+            if (closed) {
+                reject(new RpcError("websocket transport closed", 0));
+                return;
+            }
+            const key = ++requestID;
+            const drop = (err: Error) => {
+                clearTimeout(request.timer);
+                pending.delete(key);
+                reject(err);
+            };
+            const request: PendingRequest = {
+                id: req.id,
+                message: JSON.stringify(req.id === undefined ? req : {...req, id: key}),
+                sent: false,
+                notify: req.id === undefined,
+                timer: setTimeout(() => drop(new RpcError("websocket request timeout", 0)), timeout),
+                resolve,
+                reject,
+            };
+            signal.addEventListener("abort", () => drop(new RpcError("request aborted", 0)));
+            pending.set(key, request);
+            send(key);
+        });
+    };
+    return Object.assign(transport, {
+        close: () => {
+            closed = true;
+            clearTimeout(reconnectTimer);
+            socket.close();
+            rejectPending(new RpcError("websocket transport closed", 0), false);
+        },
+    });
+}
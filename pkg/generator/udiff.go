@@ -0,0 +1,181 @@
+package generator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const diffContext = 3
+
+type diffOp struct {
+	kind byte
+	line string
+}
+
+// unifiedDiff returns difference of two texts in unified format.
+func unifiedDiff(name string, before, after []byte) string {
+
+	a, b := splitLines(before), splitLines(after)
+	ops := diffLines(a, b)
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- a/%s\n+++ b/%s\n", name, name)
+	for start := 0; start < len(ops); {
+		for start < len(ops) && ops[start].kind == ' ' {
+			start++
+		}
+		if start == len(ops) {
+			break
+		}
+		from := max(start-diffContext, 0)
+		end, equal := start, 0
+		for end < len(ops) && equal <= 2*diffContext {
+			if ops[end].kind == ' ' {
+				equal++
+			} else {
+				equal = 0
+			}
+			end++
+		}
+		if equal > diffContext {
+			end -= equal - diffContext
+		}
+		aStart, bStart := 1, 1
+		for _, op := range ops[:from] {
+			if op.kind != '+' {
+				aStart++
+			}
+			if op.kind != '-' {
+				bStart++
+			}
+		}
+		var aLen, bLen int
+		for _, op := range ops[from:end] {
+			if op.kind != '+' {
+				aLen++
+			}
+			if op.kind != '-' {
+				bLen++
+			}
+		}
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", hunkRange(aStart, aLen), hunkRange(bStart, bLen))
+		for _, op := range ops[from:end] {
+			out.WriteByte(op.kind)
+			out.WriteString(op.line)
+			out.WriteByte('\n')
+		}
+		start = end
+	}
+	return out.String()
+}
+
+func hunkRange(start, length int) string {
+
+	if length == 0 {
+		start--
+	}
+	if length == 1 {
+		return fmt.Sprint(start)
+	}
+	return fmt.Sprintf("%d,%d", start, length)
+}
+
+// maxDiffEdits limits number of edits, which diffLines looks for: when texts differ more, the rest of them is
+// replaced as a whole. Memory of search grows as square of number of edits.
+const maxDiffEdits = 2000
+
+// diffLines returns edit script of two lists of lines. Common prefix and suffix are trimmed,
+// the rest is compared by Myers algorithm.
+func diffLines(a, b []string) (ops []diffOp) {
+
+	var prefix, suffix int
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	for _, line := range a[:prefix] {
+		ops = append(ops, diffOp{kind: ' ', line: line})
+	}
+	ops = append(ops, myersDiff(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		ops = append(ops, diffOp{kind: ' ', line: line})
+	}
+	return
+}
+
+// myersDiff returns the shortest edit script of a and b, deletions go before insertions.
+func myersDiff(a, b []string) []diffOp {
+
+	n, m := len(a), len(b)
+	// trace keeps furthest x of each diagonal k in [-d, d] by index k+d after d edits
+	var trace [][]int
+	for d := 0; d <= n+m; d++ {
+		if d > maxDiffEdits {
+			return replaceLines(a, b)
+		}
+		v := make([]int, 2*d+1)
+		for k := -d; k <= d; k += 2 {
+			var x int
+			switch {
+			case d == 0:
+			case k == -d || (k != d && trace[d-1][k-1+d-1] < trace[d-1][k+1+d-1]):
+				x = trace[d-1][k+1+d-1]
+			default:
+				x = trace[d-1][k-1+d-1] + 1
+			}
+			for y := x - k; x < n && y < m && a[x] == b[y]; y++ {
+				x++
+			}
+			v[k+d] = x
+			if x >= n && x-k >= m {
+				return myersScript(a, b, append(trace, v))
+			}
+		}
+		trace = append(trace, v)
+	}
+	return nil
+}
+
+// myersScript walks trace of myersDiff back from the end of both lists.
+func myersScript(a, b []string, trace [][]int) (ops []diffOp) {
+
+	x, y := len(a), len(b)
+	for d := len(trace) - 1; d > 0; d-- {
+		prev, k := trace[d-1], x-y
+		prevK := k - 1
+		if k == -d || (k != d && prev[k-1+d-1] < prev[k+1+d-1]) {
+			prevK = k + 1
+		}
+		prevX := prev[prevK+d-1]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x, y = x-1, y-1
+			ops = append(ops, diffOp{kind: ' ', line: a[x]})
+		}
+		if x == prevX {
+			ops = append(ops, diffOp{kind: '+', line: b[prevY]})
+		} else {
+			ops = append(ops, diffOp{kind: '-', line: a[prevX]})
+		}
+		x, y = prevX, prevY
+	}
+	for x > 0 && y > 0 {
+		x, y = x-1, y-1
+		ops = append(ops, diffOp{kind: ' ', line: a[x]})
+	}
+	slices.Reverse(ops)
+	return
+}
+
+func replaceLines(a, b []string) (ops []diffOp) {
+
+	for _, line := range a {
+		ops = append(ops, diffOp{kind: '-', line: line})
+	}
+	for _, line := range b {
+		ops = append(ops, diffOp{kind: '+', line: line})
+	}
+	return
+}
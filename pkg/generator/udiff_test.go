@@ -0,0 +1,106 @@
+package generator
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+
+	before := []byte("a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n")
+	after := []byte("a\nb\nc\nd\nE\nf\ng\nh\ni\nj\nk\n")
+	expected := `--- a/file.go
++++ b/file.go
+@@ -2,9 +2,10 @@
+ b
+ c
+ d
+-e
++E
+ f
+ g
+ h
+ i
+ j
++k
+`
+	if diff := unifiedDiff("file.go", before, after); diff != expected {
+		t.Fatalf("unexpected diff:\n%s", diff)
+	}
+	expected = "--- a/file.go\n+++ b/file.go\n@@ -1,2 +0,0 @@\n-a\n-b\n"
+	if diff := unifiedDiff("file.go", []byte("a\nb\n"), nil); diff != expected {
+		t.Fatalf("unexpected diff:\n%s", diff)
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+
+	random := rand.New(rand.NewPCG(1, 2))
+	lines := func(n int) (list []string) {
+		for range n {
+			list = append(list, string(rune('a'+random.IntN(3))))
+		}
+		return
+	}
+	for range 500 {
+		a, b := lines(random.IntN(12)), lines(random.IntN(12))
+		var before, after []string
+		var edits int
+		for _, op := range diffLines(a, b) {
+			if op.kind != '+' {
+				before = append(before, op.line)
+			}
+			if op.kind != '-' {
+				after = append(after, op.line)
+			}
+			if op.kind != ' ' {
+				edits++
+			}
+		}
+		if !slices.Equal(before, a) || !slices.Equal(after, b) {
+			t.Fatalf("script of %v and %v gives %v and %v", a, b, before, after)
+		}
+		if expected := len(a) + len(b) - 2*lcsLength(a, b); edits != expected {
+			t.Fatalf("script of %v and %v has %d edits instead of %d", a, b, edits, expected)
+		}
+	}
+}
+
+func TestDiffLinesLarge(t *testing.T) {
+
+	a := make([]string, 20000)
+	for i := range a {
+		a[i] = fmt.Sprintf("line %d", i)
+	}
+	b := slices.Clone(a)
+	b[10000] = "changed"
+	if ops := diffLines(a, b); len(ops) != len(a)+1 {
+		t.Fatalf("unexpected script of %d operations", len(ops))
+	}
+	reversed := slices.Clone(a)
+	slices.Reverse(reversed)
+	// texts, which differ more than maxDiffEdits, are replaced as a whole
+	if ops := diffLines(a, reversed); len(ops) != 2*len(a) {
+		t.Fatalf("unexpected script of %d operations", len(ops))
+	}
+}
+
+func lcsLength(a, b []string) int {
+
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	return lcs[0][0]
+}
@@ -10,6 +10,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	. "github.com/dave/jennifer/jen" // nolint:staticcheck
@@ -35,6 +36,17 @@ func newPair[K, V any](k K, v V) pair[K, V] {
 	}
 }
 
+// sortedKeys returns keys of map in sorted order to keep generated code stable.
+func sortedKeys[V any](m map[string]V) (keys []string) {
+
+	keys = make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return
+}
+
 func removeSkippedFields(fields []types.Variable, skipFields []string) []types.Variable {
 
 	var result []types.Variable
@@ -74,18 +86,9 @@ func isErrorLast(fields []types.Variable) bool {
 		*name == "error"
 }
 
-func searchType(pkg, name string) (retType types.Type) {
+func (tr *Transport) searchType(pkg, name string) (retType types.Type) {
 
-	if retType, _ = parseType(pkg, name); retType == nil {
-		pkgPath := mod.PkgModPath(pkg)
-		if retType, _ = parseType(pkgPath, name); retType == nil {
-			pkgPath = path.Join("./vendor", pkg)
-			if retType, _ = parseType(pkgPath, name); retType == nil {
-				pkgPath = trimLocalPkg(pkg)
-				retType, _ = parseType(pkgPath, name)
-			}
-		}
-	}
+	retType, _ = tr.searchTypeWithConstants(pkg, name)
 	return
 }
 
@@ -95,23 +98,23 @@ func isPointerType(v types.Type) (isPointer bool) {
 	return
 }
 
-func nestedType(field types.Type, pkg string, path []string) (nested types.Type) {
+func (tr *Transport) nestedType(field types.Type, pkg string, path []string) (nested types.Type) {
 
 	if len(path) == 0 {
 		return field
 	}
 	switch f := field.(type) {
 	case types.TImport:
-		return nestedType(f.Next, f.Import.Package, path)
+		return tr.nestedType(f.Next, f.Import.Package, path)
 	case types.TName:
-		if nextType := searchType(pkg, f.TypeName); nextType != nil {
-			return nestedType(nextType, pkg, path[1:])
+		if nextType := tr.searchType(pkg, f.TypeName); nextType != nil {
+			return tr.nestedType(nextType, pkg, path[1:])
 		}
 		return f
 	case types.Struct:
 		for _, field := range f.Fields {
 			if field.Name == path[0] {
-				return nestedType(field.Type, pkg, path[1:])
+				return tr.nestedType(field.Type, pkg, path[1:])
 			}
 		}
 	case types.TArray:
@@ -119,7 +122,12 @@ func nestedType(field types.Type, pkg string, path []string) (nested types.Type)
 	case types.TMap:
 		return f
 	case types.TPointer:
-		return nestedType(f.Next, pkg, path[1:])
+		return tr.nestedType(f.Next, pkg, path[1:])
+	case types.TInstance:
+		if inst, defPkg, found := tr.instantiate(f, pkg); found {
+			return tr.nestedType(inst, defPkg, path)
+		}
+		return f
 	case types.TInterface:
 		return f
 	case types.TEllipsis:
@@ -203,6 +211,12 @@ func fieldType(ctx context.Context, field types.Type, allowEllipsis bool) *State
 		case types.TInterface:
 			mhds := interfaceType(ctx, f.Interface)
 			return c.Interface(mhds...)
+		case types.TInstance:
+			args := make([]Code, 0, len(f.Args))
+			for _, arg := range f.Args {
+				args = append(args, fieldType(ctx, arg, false))
+			}
+			return c.Add(fieldType(ctx, f.Next, false)).Types(args...)
 		case types.TEllipsis:
 			if allowEllipsis {
 				c.Op("...")
@@ -266,24 +280,65 @@ func callParamNames(object string, fields []types.Variable) *Statement {
 	return List(list...)
 }
 
+// searchTypeDir returns directory, where searchType looks for types of package. Empty, when there is no such directory.
+// Project is a module of root directory.
+func searchTypeDir(root, pkg string) string {
+
+	projectPath := mod.GoProjectPath(root)
+	for _, relPath := range []string{pkg, mod.LocalPkgDir(root, pkg), mod.PkgModPath(root, pkg), path.Join("./vendor", pkg), trimLocalPkg(root, pkg)} {
+		if relPath == "" {
+			continue
+		}
+		dir := filepath.Clean(relPath)
+		if !filepath.IsAbs(relPath) {
+			dir = filepath.Clean(path.Join(projectPath, relPath))
+		}
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir
+		}
+	}
+	return ""
+}
+
 func (ts *clientTS) searchType(pkg, name string) (retType types.Type, constants []types.Constant) {
+	return ts.searchTypeWithConstants(pkg, name)
+}
+
+// searchTypeWithConstants works as searchType, but also returns constants declared in package of type.
+func (tr *Transport) searchTypeWithConstants(pkg, name string) (retType types.Type, constants []types.Constant) {
 
-	if retType, constants = parseType(pkg, name); retType == nil {
-		pkgPath := mod.PkgModPath(pkg)
-		if retType, constants = parseType(pkgPath, name); retType == nil {
+	var found bool
+	if retType, constants, found = tr.loadType(pkg, name); found {
+		return
+	}
+	return searchSourceType(tr.svcDir, pkg, name)
+}
+
+// searchSourceType looks up declaration of type in source files of package, annotations of declaration are kept.
+// Package is searched in module of root directory, its local modules, module cache and vendor.
+func searchSourceType(root, pkg, name string) (retType types.Type, constants []types.Constant) {
+
+	if retType, constants = parseType(root, pkg, name); retType == nil {
+		if localDir := mod.LocalPkgDir(root, pkg); localDir != "" {
+			if retType, constants = parseType(root, localDir, name); retType != nil {
+				return
+			}
+		}
+		pkgPath := mod.PkgModPath(root, pkg)
+		if retType, constants = parseType(root, pkgPath, name); retType == nil {
 			pkgPath = path.Join("./vendor", pkg)
-			if retType, constants = parseType(pkgPath, name); retType == nil {
-				pkgPath = trimLocalPkg(pkg)
-				retType, constants = parseType(pkgPath, name)
+			if retType, constants = parseType(root, pkgPath, name); retType == nil {
+				pkgPath = trimLocalPkg(root, pkg)
+				retType, constants = parseType(root, pkgPath, name)
 			}
 		}
 	}
 	return
 }
 
-func trimLocalPkg(pkg string) (pgkPath string) {
+func trimLocalPkg(root, pkg string) (pgkPath string) {
 
-	module := getModName()
+	module := getModName(root)
 
 	if module == "" {
 		return pkg
@@ -300,9 +355,9 @@ func trimLocalPkg(pkg string) (pgkPath string) {
 	return
 }
 
-func getModName() (module string) {
+func getModName(root string) (module string) {
 
-	modPath, _ := mod.GoModPath(".")
+	modPath, _ := mod.GoModPath(root)
 	modFile, err := os.OpenFile(modPath, os.O_RDONLY, os.ModePerm)
 
 	if err != nil {
@@ -324,10 +379,13 @@ func getModName() (module string) {
 	return
 }
 
-func parseType(relPath, name string) (retType types.Type, constants []types.Constant) {
+// parseType looks up type in source files of directory, relative path is a path from project of root directory.
+func parseType(root, relPath, name string) (retType types.Type, constants []types.Constant) {
 
-	GoProjectPath := mod.GoProjectPath(".")
-	pkgPath := path.Join(GoProjectPath, relPath)
+	pkgPath := relPath
+	if !filepath.IsAbs(relPath) {
+		pkgPath = path.Join(mod.GoProjectPath(root), relPath)
+	}
 	_ = filepath.Walk(pkgPath, func(filePath string, info os.FileInfo, err error) (retErr error) {
 		if err != nil {
 			return err
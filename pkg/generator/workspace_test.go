@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/seniorGolang/tg/v2/pkg/config"
+)
+
+func TestGenerateWorkspace(t *testing.T) {
+
+	files := map[string]string{
+		"go.work":           "go 1.22\n\nuse (\n\t./api\n\t./clients\n)\n\nreplace example.com/dto => ./shared/dto\n",
+		"api/go.mod":        "module example.com/api\n\ngo 1.22\n\nrequire example.com/dto v0.0.0\n",
+		"clients/go.mod":    "module example.com/clients\n\ngo 1.22\n",
+		"shared/dto/go.mod": "module example.com/dto\n\ngo 1.22\n",
+		"shared/dto/user.go": `package dto
+
+type User struct {
+	Name string ` + "`json:\"name\"`" + `
+}
+`,
+		"api/service/user.go": `// @tg version=1.0.0
+package service
+
+import (
+	"context"
+
+	"example.com/dto"
+)
+
+// @tg jsonRPC-server
+type User interface {
+	Get(ctx context.Context, id int) (user dto.User, err error)
+}
+`,
+	}
+	memFS, _ := generateProject(t, files, Config{
+		Services: []config.Service{{
+			Dir:     "api/service",
+			Swagger: &config.Swagger{Out: "api/swagger.yaml"},
+			Client:  &config.Client{Out: "clients/user", Go: true},
+		}},
+		Strict: true,
+	})
+	assertContains(t, memFS, map[string][]string{
+		"api/swagger.yaml":        {"dto.User:", "name:"},
+		"clients/user/jsonrpc.go": {`"example.com/clients/user/jsonrpc"`},
+	})
+}
@@ -6,10 +6,14 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"golang.org/x/tools/imports"
 )
 
+// localPrefixMx guards imports.LocalPrefix, which is global option of imports.Process.
+var localPrefixMx sync.Mutex
+
 type File struct {
 	Name string
 	In   io.Reader
@@ -62,8 +66,11 @@ func (r Runner) processFile(file File, modulePath string) (err error) {
 		}
 	}
 	var res []byte
+	localPrefixMx.Lock()
 	imports.LocalPrefix = modulePath
-	if res, err = imports.Process(file.Name, src, nil); err != nil {
+	res, err = imports.Process(file.Name, src, nil)
+	localPrefixMx.Unlock()
+	if err != nil {
 		return
 	}
 	if bytes.Equal(src, res) {
@@ -0,0 +1,185 @@
+package lsp
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/seniorGolang/tg/v2/pkg/astra"
+	"github.com/seniorGolang/tg/v2/pkg/tags"
+)
+
+// scope is a declaration, which annotations are written for.
+type scope struct {
+	level   tags.Level
+	args    []string
+	results []string
+	imports []string
+}
+
+// complete returns names of annotations applicable to declaration or values of annotation at position.
+func complete(filePath, text string, pos position) (items []completionItem) {
+
+	lines := splitLines(text)
+	if pos.Line < 0 || pos.Line >= len(lines) {
+		return
+	}
+	line := lines[pos.Line]
+	offset := toOffset(line, pos.Character)
+	start, found := annotationsStart(line)
+	if !found || offset < start {
+		return
+	}
+	token := tokenAt(line, start, offset)
+	token.end = offset
+	current := newScope(filePath, text, lines, pos.Line)
+	name, value, isValue := strings.Cut(line[token.start:token.end], "=")
+	if !isValue {
+		return current.names(name, lineRange(lines, pos.Line, token))
+	}
+	valueStart := token.start + len(name) + 1
+	if strings.HasPrefix(value, "`") {
+		value, valueStart = value[1:], valueStart+1
+	}
+	annotation, found := lookup(name)
+	if !found {
+		return
+	}
+	itemStart := current.itemStart(annotation, value)
+	return current.values(annotation, value[itemStart:], lineRange(lines, pos.Line, span{start: valueStart + itemStart, end: offset}))
+}
+
+func newScope(filePath, text string, lines []string, line int) (current scope) {
+
+	var declLine int
+	if declLine, current.level = declaration(lines, line); declLine < 0 {
+		return
+	}
+	file, err := astra.ParseSource(filePath, []byte(text))
+	if err != nil {
+		return
+	}
+	for _, imp := range file.Imports {
+		current.imports = append(current.imports, imp.Package)
+	}
+	if current.level != tags.LevelMethod {
+		return
+	}
+	for _, iface := range file.Interfaces {
+		for _, fn := range iface.Methods {
+			if fn.Pos.Line != declLine+1 {
+				continue
+			}
+			for i, arg := range fn.Args {
+				if i == 0 && arg.Type != nil && arg.Type.String() == "context.Context" {
+					continue
+				}
+				current.args = append(current.args, arg.Name)
+			}
+			for i, result := range fn.Results {
+				if i == len(fn.Results)-1 && result.Type != nil && result.Type.String() == "error" {
+					continue
+				}
+				current.results = append(current.results, result.Name)
+			}
+		}
+	}
+	return
+}
+
+func (current scope) names(prefix string, replace textRange) (items []completionItem) {
+
+	if varName, _, isVar := strings.Cut(prefix, "."); isVar && current.level == tags.LevelMethod {
+		for _, annotation := range tags.Annotations() {
+			if annotation.Levels&tags.LevelVariable != 0 {
+				items = append(items, annotationItem(varName+".", annotation, replace))
+			}
+		}
+		return
+	}
+	for _, annotation := range tags.Annotations() {
+		if strings.HasPrefix(annotation.Name, "<") || (current.level != 0 && annotation.Levels&current.level == 0) {
+			continue
+		}
+		items = append(items, annotationItem("", annotation, replace))
+	}
+	for _, varName := range slices.Concat(current.args, current.results) {
+		items = append(items, completionItem{
+			Label:    varName + ".",
+			Kind:     completionVariable,
+			Detail:   "annotations of variable",
+			TextEdit: &textEdit{Range: replace, NewText: varName + "."},
+		})
+	}
+	return
+}
+
+func annotationItem(prefix string, annotation tags.Annotation, replace textRange) completionItem {
+
+	newText := prefix + annotation.Name
+	if annotation.Kind != tags.KindFlag {
+		newText += "="
+	}
+	return completionItem{
+		Label:         prefix + annotation.Name,
+		Kind:          completionProperty,
+		Detail:        signature(annotation),
+		Documentation: &markupContent{Kind: markdown, Value: describe(annotation)},
+		TextEdit:      &textEdit{Range: replace, NewText: newText},
+	}
+}
+
+// itemStart returns offset of item of list or map value or of segment of path, which is edited.
+func (current scope) itemStart(annotation tags.Annotation, value string) int {
+
+	switch annotation.Kind {
+	case tags.KindList, tags.KindMap:
+		return strings.LastIndex(value, annotation.Separator) + 1
+	case tags.KindPath:
+		return strings.LastIndex(value, "/") + 1
+	}
+	return 0
+}
+
+func (current scope) values(annotation tags.Annotation, item string, replace textRange) (items []completionItem) {
+
+	var values []string
+	kind := completionValue
+	switch annotation.Name {
+	case tags.HTTPArgs:
+		values, kind = current.args, completionVariable
+	case tags.HTTPHeaders, tags.HTTPCookies, tags.LogSkip:
+		values, kind = slices.Concat(current.args, current.results), completionVariable
+	case tags.HTTPPath, tags.HTTPPrefix:
+		if strings.HasPrefix(item, ":") {
+			for _, arg := range current.args {
+				values = append(values, ":"+arg)
+			}
+			kind = completionVariable
+		}
+	}
+	if annotation.Kind == tags.KindMap && strings.Contains(item, annotation.Splitter) {
+		// value of pair is free
+		return
+	}
+	if strings.Contains(annotation.Format, "<package>:") {
+		for _, imp := range current.imports {
+			values = append(values, imp+":")
+		}
+		kind = completionModule
+	}
+	values = append(values, annotation.Values...)
+	if annotation.Kind == tags.KindBool {
+		values = append(values, "true", "false")
+	}
+	if annotation.Default != "" && !slices.Contains(values, annotation.Default) {
+		values = append(values, annotation.Default)
+	}
+	for _, value := range values {
+		items = append(items, completionItem{
+			Label:    value,
+			Kind:     kind,
+			TextEdit: &textEdit{Range: replace, NewText: value},
+		})
+	}
+	return
+}
@@ -0,0 +1,78 @@
+package lsp
+
+import (
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/seniorGolang/tg/v2/pkg/astra"
+	tgDiagnostic "github.com/seniorGolang/tg/v2/pkg/diagnostic"
+	"github.com/seniorGolang/tg/v2/pkg/generator"
+)
+
+var severities = map[tgDiagnostic.Severity]int{
+	tgDiagnostic.SeverityError:   severityError,
+	tgDiagnostic.SeverityWarning: severityWarning,
+	tgDiagnostic.SeverityInfo:    severityInfo,
+}
+
+// check returns diagnostics of annotations and methods signatures declared in document.
+// Syntax errors are not reported, they are reported by Go language server.
+func check(filePath, text string) (diags []diagnostic) {
+
+	filePath, _ = filepath.Abs(filePath)
+	file, err := astra.ParseSource(filePath, []byte(text))
+	if err != nil {
+		return
+	}
+	lines := splitLines(text)
+	for _, d := range generator.CheckFile(filePath, file) {
+		// methods of embedded interfaces, which are declared in other files
+		if d.Pos.Filename != filePath {
+			continue
+		}
+		diags = append(diags, diagnostic{
+			Range:    diagnosticRange(lines, d),
+			Severity: severities[d.Severity],
+			Code:     d.Rule,
+			Source:   serverName,
+			Message:  d.Message,
+		})
+	}
+	return
+}
+
+// diagnosticRange returns range of annotation in comment of declaration, when diagnostic refers to annotation,
+// or range of identifier of declaration.
+func diagnosticRange(lines []string, d tgDiagnostic.Diagnostic) textRange {
+
+	line := min(max(d.Pos.Line-1, 0), len(lines)-1)
+	if d.Annotation != "" {
+		for i := line - 1; i >= 0 && strings.HasPrefix(strings.TrimSpace(lines[i]), "//"); i-- {
+			start, found := annotationsStart(lines[i])
+			if !found {
+				continue
+			}
+			for _, token := range tokens(lines[i], start) {
+				name, _, _ := strings.Cut(lines[i][token.start:token.end], "=")
+				if name == d.Annotation {
+					return lineRange(lines, i, span{start: token.start, end: token.start + len(name)})
+				}
+			}
+		}
+	}
+	start := min(max(d.Pos.Column-1, 0), len(lines[line]))
+	end := start
+	for end < len(lines[line]) {
+		r, size := utf8.DecodeRuneInString(lines[line][end:])
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			break
+		}
+		end += size
+	}
+	if end == start {
+		end = len(lines[line])
+	}
+	return lineRange(lines, line, span{start: start, end: end})
+}
@@ -0,0 +1,154 @@
+package lsp
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/seniorGolang/tg/v2/pkg/tags"
+)
+
+const mark = "@tg"
+
+var (
+	interfaceDecl = regexp.MustCompile(`^type\s+\w+(\[.*])?\s+interface\b`)
+	methodDecl    = regexp.MustCompile(`^[A-Za-z_]\w*\s*\(`)
+)
+
+// span is a range of bytes in line.
+type span struct {
+	start int
+	end   int
+}
+
+func splitLines(text string) (lines []string) {
+
+	lines = strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	return
+}
+
+// toCharacter converts byte offset in line to offset in UTF-16 code units, which are used by protocol.
+func toCharacter(line string, offset int) (character int) {
+
+	offset = min(max(offset, 0), len(line))
+	for _, r := range line[:offset] {
+		character += utf16.RuneLen(r)
+	}
+	return
+}
+
+// toOffset converts offset in UTF-16 code units to byte offset in line.
+func toOffset(line string, character int) (offset int) {
+
+	for offset < len(line) && character > 0 {
+		r, size := utf8.DecodeRuneInString(line[offset:])
+		character -= utf16.RuneLen(r)
+		offset += size
+	}
+	return
+}
+
+func lineRange(lines []string, line int, s span) textRange {
+	return textRange{
+		Start: position{Line: line, Character: toCharacter(lines[line], s.start)},
+		End:   position{Line: line, Character: toCharacter(lines[line], s.end)},
+	}
+}
+
+// annotationsStart returns offset of annotations in comment line, which starts by '@tg' mark.
+func annotationsStart(line string) (start int, found bool) {
+
+	trimmed := strings.TrimLeftFunc(line, unicode.IsSpace)
+	if !strings.HasPrefix(trimmed, "//") {
+		return
+	}
+	start = len(line) - len(trimmed) + 2
+	comment := line[start:]
+	start += len(comment) - len(strings.TrimLeftFunc(comment, unicode.IsSpace))
+	if !strings.HasPrefix(line[start:], mark) {
+		return 0, false
+	}
+	return start + len(mark), true
+}
+
+// tokens splits annotations to 'name' and 'name=value' tokens, values in backticks may contain spaces.
+func tokens(line string, start int) (result []span) {
+
+	var quoted bool
+	var current *span
+	for i := start; i < len(line); i++ {
+		c := line[i]
+		if c == '`' {
+			quoted = !quoted
+		}
+		if !quoted && (c == ' ' || c == '\t') {
+			if current != nil {
+				current.end = i
+				result = append(result, *current)
+				current = nil
+			}
+			continue
+		}
+		if current == nil {
+			current = &span{start: i}
+		}
+	}
+	if current != nil {
+		current.end = len(line)
+		result = append(result, *current)
+	}
+	return
+}
+
+// tokenAt returns token, which contains offset, or empty token at offset.
+func tokenAt(line string, start, offset int) span {
+
+	for _, token := range tokens(line, start) {
+		if token.start <= offset && offset <= token.end {
+			return token
+		}
+	}
+	return span{start: offset, end: offset}
+}
+
+// declaration returns line of declaration, which comment at line belongs to, and level of annotations of it.
+func declaration(lines []string, line int) (int, tags.Level) {
+
+	for i := line + 1; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		switch {
+		case strings.HasPrefix(trimmed, "//"):
+			continue
+		case trimmed == "":
+			return -1, 0
+		case strings.HasPrefix(trimmed, "package "):
+			return i, tags.LevelPackage
+		case interfaceDecl.MatchString(trimmed):
+			return i, tags.LevelInterface
+		case methodDecl.MatchString(trimmed):
+			return i, tags.LevelMethod
+		default:
+			return i, tags.LevelType
+		}
+	}
+	return -1, 0
+}
+
+// lookup returns declaration of annotation by name, names of variables annotations are prefixed by '<variable>.'.
+func lookup(name string) (annotation tags.Annotation, found bool) {
+
+	if annotation, found = tags.Lookup(name); found {
+		return
+	}
+	if _, suffix, isVar := strings.Cut(name, "."); isVar {
+		if annotation, found = tags.Lookup(suffix); found && annotation.Levels&tags.LevelVariable != 0 {
+			return
+		}
+	}
+	return tags.Annotation{}, false
+}
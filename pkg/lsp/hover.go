@@ -0,0 +1,66 @@
+package lsp
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/seniorGolang/tg/v2/pkg/tags"
+)
+
+// hoverAt returns documentation of annotation, which name is at position.
+func hoverAt(text string, pos position) *hover {
+
+	lines := splitLines(text)
+	if pos.Line < 0 || pos.Line >= len(lines) {
+		return nil
+	}
+	line := lines[pos.Line]
+	offset := toOffset(line, pos.Character)
+	start, found := annotationsStart(line)
+	if !found || offset < start {
+		return nil
+	}
+	token := tokenAt(line, start, offset)
+	name, _, _ := strings.Cut(line[token.start:token.end], "=")
+	if name == "" || offset > token.start+len(name) {
+		return nil
+	}
+	annotation, found := lookup(name)
+	if !found {
+		return nil
+	}
+	nameRange := lineRange(lines, pos.Line, span{start: token.start, end: token.start + len(name)})
+	return &hover{Contents: markupContent{Kind: markdown, Value: describe(annotation)}, Range: &nameRange}
+}
+
+// signature returns short form of annotation value, for example 'http-method=GET|POST'.
+func signature(annotation tags.Annotation) string {
+
+	switch {
+	case annotation.Kind == tags.KindFlag:
+		return annotation.Name
+	case annotation.Format != "":
+		return fmt.Sprintf("%s=%s", annotation.Name, annotation.Format)
+	case len(annotation.Values) != 0:
+		return fmt.Sprintf("%s=%s", annotation.Name, strings.Join(annotation.Values, "|"))
+	}
+	return fmt.Sprintf("%s=<%s>", annotation.Name, annotation.Kind)
+}
+
+func describe(annotation tags.Annotation) string {
+
+	var doc strings.Builder
+	fmt.Fprintf(&doc, "```\n@tg %s\n```\n\n%s\n\n", signature(annotation), annotation.Doc)
+	fmt.Fprintf(&doc, "- levels: %s\n", annotation.Levels)
+	fmt.Fprintf(&doc, "- value: %s\n", annotation.Kind)
+	if len(annotation.Values) != 0 {
+		fmt.Fprintf(&doc, "- values: %s\n", strings.Join(annotation.Values, ", "))
+	}
+	if annotation.Default != "" {
+		fmt.Fprintf(&doc, "- default: `%s`\n", annotation.Default)
+	}
+	if annotation.Deprecated != "" {
+		fmt.Fprintf(&doc, "\n**Deprecated**: %s\n", annotation.Deprecated)
+	}
+	return doc.String()
+}
@@ -0,0 +1,224 @@
+// Package lsp implements language server of '@tg' annotations for Go files: completion of names and values
+// of annotations, documentation on hover and diagnostics of annotations and methods signatures.
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/seniorGolang/tg/v2/pkg/logger"
+)
+
+const serverName = "tg"
+
+var log = logger.Log.WithField("module", "lsp")
+
+// Server serves one client by Language Server Protocol over in and out, usually stdin and stdout of process.
+// Documents are synchronized fully on every change, requests are handled in order of arrival.
+type Server struct {
+	in        *bufio.Reader
+	out       io.Writer
+	version   string
+	documents map[string]string
+	shutdown  bool
+}
+
+func NewServer(in io.Reader, out io.Writer, version string) *Server {
+	return &Server{in: bufio.NewReader(in), out: out, version: version, documents: make(map[string]string)}
+}
+
+// Serve handles messages of client until 'exit' notification or end of input.
+func (srv *Server) Serve() (err error) {
+
+	for {
+		var req request
+		var parseErr *responseError
+		if req, parseErr, err = srv.read(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return
+		}
+		// body of message is broken, but framing is not, so next messages are read
+		if parseErr != nil {
+			log.Warn(parseErr.Message)
+			if err = srv.write(response{JSONRPC: "2.0", Error: parseErr}); err != nil {
+				return
+			}
+			continue
+		}
+		if req.Method == "exit" {
+			if !srv.shutdown {
+				return fmt.Errorf("exit without shutdown")
+			}
+			return
+		}
+		result, rpcErr := srv.handle(req)
+		if req.ID == nil {
+			if rpcErr != nil {
+				log.WithField("method", req.Method).Warn(rpcErr.Message)
+			}
+			continue
+		}
+		resp := response{JSONRPC: "2.0", ID: req.ID, Error: rpcErr}
+		if rpcErr == nil {
+			if resp.Result, err = json.Marshal(result); err != nil {
+				return
+			}
+		}
+		if err = srv.write(resp); err != nil {
+			return
+		}
+	}
+}
+
+func (srv *Server) handle(req request) (result any, rpcErr *responseError) {
+
+	decode := func(params any) *responseError {
+		if err := json.Unmarshal(req.Params, params); err != nil {
+			return &responseError{Code: codeInvalidParams, Message: err.Error()}
+		}
+		return nil
+	}
+	switch req.Method {
+	case "initialize":
+		return initializeResult{
+			Capabilities: serverCapabilities{
+				TextDocumentSync:   textDocumentSyncOptions{OpenClose: true, Change: syncFull, Save: saveOptions{IncludeText: true}},
+				CompletionProvider: completionOptions{TriggerCharacters: []string{"@", " ", "=", ",", "|", ":", ".", "`"}},
+				HoverProvider:      true,
+			},
+			ServerInfo: serverInfo{Name: serverName, Version: srv.version},
+		}, nil
+	case "shutdown":
+		srv.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if rpcErr = decode(&params); rpcErr == nil {
+			srv.update(params.TextDocument.URI, params.TextDocument.Text)
+		}
+	case "textDocument/didChange":
+		var params didChangeParams
+		if rpcErr = decode(&params); rpcErr == nil && len(params.ContentChanges) != 0 {
+			srv.update(params.TextDocument.URI, params.ContentChanges[len(params.ContentChanges)-1].Text)
+		}
+	case "textDocument/didSave":
+		var params didSaveParams
+		if rpcErr = decode(&params); rpcErr == nil {
+			text, found := srv.documents[params.TextDocument.URI]
+			if params.Text != nil {
+				text, found = *params.Text, true
+			}
+			if found {
+				srv.update(params.TextDocument.URI, text)
+			}
+		}
+	case "textDocument/didClose":
+		var params didCloseParams
+		if rpcErr = decode(&params); rpcErr == nil {
+			delete(srv.documents, params.TextDocument.URI)
+			srv.publish(params.TextDocument.URI, nil)
+		}
+	case "textDocument/completion":
+		var params textDocumentPositionParams
+		if rpcErr = decode(&params); rpcErr == nil {
+			text := srv.documents[params.TextDocument.URI]
+			return completionList{Items: complete(uriToPath(params.TextDocument.URI), text, params.Position)}, nil
+		}
+	case "textDocument/hover":
+		var params textDocumentPositionParams
+		if rpcErr = decode(&params); rpcErr == nil {
+			if result := hoverAt(srv.documents[params.TextDocument.URI], params.Position); result != nil {
+				return result, nil
+			}
+			return nil, nil
+		}
+	default:
+		// notifications, which are not supported, are ignored
+		if req.ID != nil {
+			rpcErr = &responseError{Code: codeMethodNotFound, Message: fmt.Sprintf("method '%s' is not supported", req.Method)}
+		}
+	}
+	return
+}
+
+func (srv *Server) update(uri, text string) {
+
+	srv.documents[uri] = text
+	srv.publish(uri, check(uriToPath(uri), text))
+}
+
+func (srv *Server) publish(uri string, diags []diagnostic) {
+
+	if diags == nil {
+		diags = []diagnostic{}
+	}
+	if err := srv.write(notification{JSONRPC: "2.0", Method: "textDocument/publishDiagnostics", Params: publishDiagnosticsParams{URI: uri, Diagnostics: diags}}); err != nil {
+		log.WithError(err).Warn("publish diagnostics")
+	}
+}
+
+// read reads message framed by 'Content-Length' header.
+// read returns error only for broken framing of message, invalid body is returned as parse error of JSON-RPC.
+func (srv *Server) read() (req request, parseErr *responseError, err error) {
+
+	length := -1
+	for {
+		var line string
+		if line, err = srv.in.ReadString('\n'); err != nil {
+			return
+		}
+		if line = strings.TrimSpace(line); line == "" {
+			break
+		}
+		name, value, found := strings.Cut(line, ":")
+		if found && strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			if length, err = strconv.Atoi(strings.TrimSpace(value)); err != nil {
+				return req, nil, fmt.Errorf("invalid header '%s': %v", line, err)
+			}
+		}
+	}
+	if length < 0 {
+		return req, nil, fmt.Errorf("message without Content-Length header")
+	}
+	body := make([]byte, length)
+	if _, err = io.ReadFull(srv.in, body); err != nil {
+		return
+	}
+	if jsonErr := json.Unmarshal(body, &req); jsonErr != nil {
+		return req, &responseError{Code: codeParseError, Message: fmt.Sprintf("invalid message: %v", jsonErr)}, nil
+	}
+	return
+}
+
+func (srv *Server) write(msg any) (err error) {
+
+	var body []byte
+	if body, err = json.Marshal(msg); err != nil {
+		return
+	}
+	_, err = fmt.Fprintf(srv.out, "Content-Length: %d\r\n\r\n%s", len(body), body)
+	return
+}
+
+func uriToPath(uri string) string {
+
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Scheme != "file" {
+		return uri
+	}
+	filePath := parsed.Path
+	// file:///C:/dir/file.go
+	if len(filePath) > 2 && filePath[0] == '/' && filePath[2] == ':' {
+		filePath = filePath[1:]
+	}
+	return filepath.FromSlash(filePath)
+}
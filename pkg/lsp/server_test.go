@@ -0,0 +1,224 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const source = `// @tg version=1.0.0
+package service
+
+import (
+	"context"
+
+	"example.com/api/handlers"
+)
+
+// @tg http-server
+type Users interface {
+	// @tg http-method=GTE
+	// @tg http-path=/users/:userID
+	// @tg http-args=
+	// @tg handler=
+	Get(ctx context.Context, id int) (name string, err error)
+}
+`
+
+type client struct {
+	t      *testing.T
+	in     io.Writer
+	out    *bufio.Reader
+	nextID int
+}
+
+func (cli *client) send(method string, id *int, params any) {
+
+	msg := map[string]any{"jsonrpc": "2.0", "method": method, "params": params}
+	if id != nil {
+		msg["id"] = *id
+	}
+	body, _ := json.Marshal(msg)
+	if _, err := fmt.Fprintf(cli.in, "Content-Length: %d\r\n\r\n%s", len(body), body); err != nil {
+		cli.t.Fatal(err)
+	}
+}
+
+func (cli *client) call(method string, params any, result any) {
+
+	cli.nextID++
+	cli.send(method, &cli.nextID, params)
+	for {
+		var raw map[string]json.RawMessage
+		body := cli.readRaw()
+		if err := json.Unmarshal(body, &raw); err != nil {
+			cli.t.Fatal(err)
+		}
+		if _, isResponse := raw["id"]; !isResponse {
+			continue
+		}
+		if raw["error"] != nil {
+			cli.t.Fatalf("%s: %s", method, raw["error"])
+		}
+		if err := json.Unmarshal(raw["result"], result); err != nil {
+			cli.t.Fatalf("%s: %v", method, err)
+		}
+		return
+	}
+}
+
+func (cli *client) readRaw() []byte {
+
+	var length int
+	for {
+		line, err := cli.out.ReadString('\n')
+		if err != nil {
+			cli.t.Fatal(err)
+		}
+		if line = strings.TrimSpace(line); line == "" {
+			break
+		}
+		fmt.Sscanf(line, "Content-Length: %d", &length)
+	}
+	body := make([]byte, length)
+	if _, err := io.ReadFull(cli.out, body); err != nil {
+		cli.t.Fatal(err)
+	}
+	return body
+}
+
+func TestServer(t *testing.T) {
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/api\n\ngo 1.22\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	filePath := filepath.Join(dir, "service", "users.go")
+	if err := os.MkdirAll(filepath.Dir(filePath), 0777); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filePath, []byte(source), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+	uri := "file://" + filepath.ToSlash(filePath)
+
+	clientIn, serverOut := io.Pipe()
+	serverIn, clientOut := io.Pipe()
+	done := make(chan error, 1)
+	go func() { done <- NewServer(serverIn, serverOut, "test").Serve() }()
+	cli := &client{t: t, in: clientOut, out: bufio.NewReader(clientIn)}
+
+	var initialized initializeResult
+	cli.call("initialize", map[string]any{"capabilities": map[string]any{}}, &initialized)
+	if !initialized.Capabilities.HoverProvider || initialized.ServerInfo.Name != serverName {
+		t.Fatalf("unexpected initialize result: %+v", initialized)
+	}
+	cli.send("initialized", nil, map[string]any{})
+
+	cli.send("textDocument/didOpen", nil, didOpenParams{TextDocument: textDocumentItem{URI: uri, LanguageID: "go", Version: 1, Text: source}})
+	var published publishDiagnosticsParams
+	if err := json.Unmarshal(cli.readRaw(), &struct {
+		Params *publishDiagnosticsParams `json:"params"`
+	}{Params: &published}); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]textRange{
+		"annotation-value": {Start: position{Line: 11, Character: 8}, End: position{Line: 11, Character: 19}},
+		"http-path-arg":    {Start: position{Line: 12, Character: 8}, End: position{Line: 12, Character: 17}},
+	}
+	for _, d := range published.Diagnostics {
+		if want, found := expected[d.Code]; found && d.Range == want {
+			delete(expected, d.Code)
+		}
+	}
+	if len(expected) != 0 {
+		t.Fatalf("diagnostics %v are not published: %+v", expected, published.Diagnostics)
+	}
+
+	completion := func(line, character int) (labels []string) {
+		var list completionList
+		cli.call("textDocument/completion", textDocumentPositionParams{TextDocument: textDocumentIdentifier{URI: uri}, Position: position{Line: line, Character: character}}, &list)
+		for _, item := range list.Items {
+			labels = append(labels, item.Label)
+		}
+		return
+	}
+	checks := []struct {
+		line, character int
+		contains        []string
+		excludes        []string
+	}{
+		{line: 11, character: 12, contains: []string{"http-method", "http-path", "id.", "name."}, excludes: []string{"title", "log"}},
+		{line: 9, character: 11, contains: []string{"http-prefix", "log"}, excludes: []string{"summary"}},
+		{line: 11, character: 22, contains: []string{"GET", "DELETE"}},
+		{line: 12, character: 29, contains: []string{":id"}},
+		{line: 13, character: 18, contains: []string{"id"}, excludes: []string{"name"}},
+		{line: 14, character: 16, contains: []string{"example.com/api/handlers:"}},
+	}
+	for _, check := range checks {
+		labels := strings.Join(completion(check.line, check.character), " ")
+		for _, label := range check.contains {
+			if !strings.Contains(" "+labels+" ", " "+label+" ") {
+				t.Fatalf("completion at %d:%d does not contain '%s': %s", check.line, check.character, label, labels)
+			}
+		}
+		for _, label := range check.excludes {
+			if strings.Contains(" "+labels+" ", " "+label+" ") {
+				t.Fatalf("completion at %d:%d contains '%s': %s", check.line, check.character, label, labels)
+			}
+		}
+	}
+
+	var hovered hover
+	cli.call("textDocument/hover", textDocumentPositionParams{TextDocument: textDocumentIdentifier{URI: uri}, Position: position{Line: 11, Character: 10}}, &hovered)
+	if !strings.Contains(hovered.Contents.Value, "HTTP method of method") || !strings.Contains(hovered.Contents.Value, "default: `POST`") {
+		t.Fatalf("unexpected hover: %s", hovered.Contents.Value)
+	}
+
+	var shutdown any
+	cli.call("shutdown", nil, &shutdown)
+	cli.send("exit", nil, nil)
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestServerInvalidMessage(t *testing.T) {
+
+	clientIn, serverOut := io.Pipe()
+	serverIn, clientOut := io.Pipe()
+	done := make(chan error, 1)
+	go func() { done <- NewServer(serverIn, serverOut, "test").Serve() }()
+	cli := &client{t: t, in: clientOut, out: bufio.NewReader(clientIn)}
+
+	body := `{"jsonrpc": "2.0", "id": 1, "method": `
+	if _, err := fmt.Fprintf(clientOut, "Content-Length: %d\r\n\r\n%s", len(body), body); err != nil {
+		t.Fatal(err)
+	}
+	var resp response
+	if err := json.Unmarshal(cli.readRaw(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Error == nil || resp.Error.Code != codeParseError || resp.ID != nil {
+		t.Fatalf("unexpected response to invalid message: %+v", resp)
+	}
+
+	var initialized initializeResult
+	cli.call("initialize", map[string]any{"capabilities": map[string]any{}}, &initialized)
+	if initialized.ServerInfo.Name != serverName {
+		t.Fatalf("message after invalid one is not served: %+v", initialized)
+	}
+
+	if _, err := fmt.Fprint(clientOut, "Content-Length: many\r\n\r\n"); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-done; err == nil || !strings.Contains(err.Error(), "invalid header") {
+		t.Fatalf("broken framing is not returned: %v", err)
+	}
+}
@@ -16,9 +16,10 @@ import (
 	"golang.org/x/mod/module"
 )
 
-func PkgModPath(pkgName string) string {
+// PkgModPath returns directory of package in module cache by requirements of go.mod, which is used in root directory.
+func PkgModPath(root, pkgName string) string {
 
-	modPath, _ := GoModPath(".")
+	modPath, _ := GoModPath(root)
 	modInfo := parseMod(modPath)
 	pkgTokens := strings.Split(pkgName, "/")
 	for i := 0; i < len(pkgTokens); i++ {
@@ -62,8 +63,15 @@ func parseMod(modPath string) (pkgPath map[string]string) {
 	return
 }
 
+// GoProjectPath returns directory of go.mod with trailing slash. Outside of modules of workspace
+// directory of go.work is returned.
 func GoProjectPath(from string) string {
 	modPath, _ := GoModPath(from)
+	if modPath == "" || modPath == os.DevNull {
+		if workPath := GoWorkPath(from); workPath != "" {
+			return filepath.Dir(workPath) + string(filepath.Separator)
+		}
+	}
 	return strings.TrimSuffix(modPath, "go.mod")
 }
 
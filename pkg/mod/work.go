@@ -0,0 +1,138 @@
+package mod
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/mod/modfile"
+)
+
+var (
+	localModulesMx    sync.Mutex
+	localModulesCache = make(map[string]map[string]string)
+	goWorkMx          sync.Mutex
+	goWorkCache       = make(map[string]string)
+)
+
+// ResetCache forgets workspaces and local modules found before, so changes of 'go.work' and 'go.mod' files
+// are taken by next lookup. Long-running commands call it before each generation.
+func ResetCache() {
+
+	goWorkMx.Lock()
+	clear(goWorkCache)
+	goWorkMx.Unlock()
+	localModulesMx.Lock()
+	clear(localModulesCache)
+	localModulesMx.Unlock()
+}
+
+// GoWorkPath returns path of 'go.work', which is used in root directory.
+// Empty, when there is no workspace or it is disabled by GOWORK=off.
+func GoWorkPath(root string) (workPath string) {
+
+	absRoot, _ := filepath.Abs(root)
+	goWorkMx.Lock()
+	defer goWorkMx.Unlock()
+	if workPath, found := goWorkCache[absRoot]; found {
+		return workPath
+	}
+	defer func() { goWorkCache[absRoot] = workPath }()
+	cmd := exec.Command("go", "env", "GOWORK")
+	cmd.Dir = absRoot
+	stdout, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	if workPath = string(bytes.TrimSpace(stdout)); workPath == "off" {
+		return ""
+	}
+	return workPath
+}
+
+// LocalModules returns directories of modules, which are placed on local disk: modules from 'use' directives
+// of 'go.work' and modules replaced by local paths in 'go.work' and 'go.mod'. Key is a module path.
+func LocalModules(root string) (modules map[string]string) {
+
+	absRoot, _ := filepath.Abs(root)
+	localModulesMx.Lock()
+	defer localModulesMx.Unlock()
+	if modules, found := localModulesCache[absRoot]; found {
+		return modules
+	}
+	modules = make(map[string]string)
+	if modPath, _ := GoModPath(absRoot); modPath != "" && modPath != os.DevNull {
+		addLocalModule(modules, filepath.Dir(modPath))
+		if data, err := os.ReadFile(modPath); err == nil {
+			if modFile, err := modfile.Parse(modPath, data, nil); err == nil {
+				addReplaces(modules, filepath.Dir(modPath), modFile.Replace)
+			}
+		}
+	}
+	if workPath := GoWorkPath(absRoot); workPath != "" {
+		if data, err := os.ReadFile(workPath); err == nil {
+			if workFile, err := modfile.ParseWork(workPath, data, nil); err == nil {
+				for _, use := range workFile.Use {
+					addLocalModule(modules, localDir(filepath.Dir(workPath), use.Path))
+				}
+				addReplaces(modules, filepath.Dir(workPath), workFile.Replace)
+			}
+		}
+	}
+	localModulesCache[absRoot] = modules
+	return
+}
+
+// LocalPkgDir returns directory of package from local module of root directory (see LocalModules) or empty string.
+func LocalPkgDir(root, pkg string) string {
+
+	modules := LocalModules(root)
+	var modulePath string
+	for module := range modules {
+		if (pkg == module || strings.HasPrefix(pkg, module+"/")) && len(module) > len(modulePath) {
+			modulePath = module
+		}
+	}
+	if modulePath == "" {
+		return ""
+	}
+	return filepath.Join(modules[modulePath], filepath.FromSlash(strings.TrimPrefix(pkg, modulePath)))
+}
+
+func addLocalModule(modules map[string]string, dir string) {
+
+	data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return
+	}
+	if modulePath := modfile.ModulePath(data); modulePath != "" {
+		modules[modulePath] = dir
+	}
+}
+
+// addReplaces adds modules replaced by local directories, replaces with versions point to module cache and are skipped.
+func addReplaces(modules map[string]string, baseDir string, replaces []*modfile.Replace) {
+
+	for _, replace := range replaces {
+		if replace.New.Version != "" || !isLocalPath(replace.New.Path) {
+			continue
+		}
+		modules[replace.Old.Path] = localDir(baseDir, replace.New.Path)
+	}
+}
+
+func localDir(baseDir, dir string) string {
+
+	if filepath.IsAbs(dir) {
+		return filepath.Clean(dir)
+	}
+	return filepath.Join(baseDir, filepath.FromSlash(dir))
+}
+
+func isLocalPath(modPath string) bool {
+	return filepath.IsAbs(modPath) || strings.HasPrefix(modPath, "./") || strings.HasPrefix(modPath, "../") || path.Clean(modPath) == "."
+}
@@ -0,0 +1,77 @@
+package mod
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFiles(t *testing.T, files map[string]string) (dir string) {
+
+	dir = t.TempDir()
+	for name, content := range files {
+		filePath := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(filePath), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filePath, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return
+}
+
+func TestLocalModules(t *testing.T) {
+
+	dir := writeFiles(t, map[string]string{
+		"go.work":           "go 1.22\n\nuse (\n\t./api\n\t./clients\n)\n\nreplace example.com/dto => ./shared/dto\n",
+		"api/go.mod":        "module example.com/api\n\ngo 1.22\n\nreplace (\n\texample.com/errors => ../shared/errors\n\texample.com/remote => example.com/fork v1.0.0\n)\n",
+		"clients/go.mod":    "module example.com/clients\n\ngo 1.22\n",
+		"shared/dto/go.mod": "module example.com/dto\n\ngo 1.22\n",
+	})
+	t.Setenv("GOWORK", "")
+	ResetCache()
+	root := filepath.Join(dir, "api")
+	modules := LocalModules(root)
+	expected := map[string]string{
+		"example.com/api":     root,
+		"example.com/clients": filepath.Join(dir, "clients"),
+		"example.com/dto":     filepath.Join(dir, "shared", "dto"),
+		"example.com/errors":  filepath.Join(dir, "shared", "errors"),
+	}
+	if len(modules) != len(expected) {
+		t.Fatalf("unexpected modules: %v", modules)
+	}
+	for module, moduleDir := range expected {
+		if modules[module] != moduleDir {
+			t.Fatalf("directory of %s is %s instead of %s", module, modules[module], moduleDir)
+		}
+	}
+	if pkgDir := LocalPkgDir(root, "example.com/dto/user"); pkgDir != filepath.Join(dir, "shared", "dto", "user") {
+		t.Fatalf("unexpected directory of package: %s", pkgDir)
+	}
+	if pkgDir := LocalPkgDir(root, "example.com/remote/user"); pkgDir != "" {
+		t.Fatalf("package of module cache is local: %s", pkgDir)
+	}
+}
+
+func TestResetCache(t *testing.T) {
+
+	dir := writeFiles(t, map[string]string{
+		"api/go.mod":   "module example.com/api\n\ngo 1.22\n",
+		"other/go.mod": "module example.com/other\n\ngo 1.22\n",
+	})
+	root := filepath.Join(dir, "api")
+	t.Setenv("GOWORK", "off")
+	if modules := LocalModules(root); len(modules) != 1 {
+		t.Fatalf("unexpected modules: %v", modules)
+	}
+	replace := "module example.com/api\n\ngo 1.22\n\nreplace example.com/other => ../other\n"
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte(replace), 0600); err != nil {
+		t.Fatal(err)
+	}
+	ResetCache()
+	if modules := LocalModules(root); modules["example.com/other"] != filepath.Join(dir, "other") {
+		t.Fatalf("replace is not taken after reset: %v", modules)
+	}
+}
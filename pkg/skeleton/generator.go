@@ -88,6 +88,10 @@ func GenerateSkeleton(log logrus.FieldLogger, moduleName, projectName, serviceNa
 		log.WithError(err).Warning("render tg.go error")
 		return
 	}
+	if err = renderFile(tmpl, "tgyaml.tmpl", path.Join(baseDir, "tg.yaml"), meta); err != nil {
+		log.WithError(err).Warning("render tg.yaml error")
+		return
+	}
 	if err = os.MkdirAll(path.Join(baseDir, "contracts", "dto"), 0777); err != nil {
 		log.WithError(err).Warning("make types dir error")
 		return
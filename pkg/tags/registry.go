@@ -0,0 +1,217 @@
+package tags
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Level is a place of annotation in contract. Levels of annotation are combined as flags.
+type Level uint8
+
+const (
+	LevelPackage Level = 1 << iota
+	LevelInterface
+	LevelMethod
+	// LevelType is a declaration of type or field of struct.
+	LevelType
+	// LevelVariable is an argument or result of method, annotation is written on method as '<variable>.<name>'.
+	LevelVariable
+)
+
+var levelNames = []string{"package", "interface", "method", "type", "variable"}
+
+// String returns names of levels separated by ', '.
+func (l Level) String() string {
+
+	var names []string
+	for i, name := range levelNames {
+		if l&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// Kind is a type of annotation value.
+type Kind string
+
+const (
+	// KindFlag annotation has no value, it is enabled by presence.
+	KindFlag   Kind = "flag"
+	KindBool   Kind = "bool"
+	KindInt    Kind = "int"
+	KindString Kind = "string"
+	// KindEnum value is one of Values, case is ignored.
+	KindEnum Kind = "enum"
+	// KindPath value is URL path, placeholders are written as ':name'.
+	KindPath Kind = "path"
+	// KindList value is a list of items separated by Separator. Items are restricted by Values, when they are set.
+	KindList Kind = "list"
+	// KindMap value is a list of pairs separated by Separator, key and value of pair are separated by Splitter.
+	KindMap Kind = "map"
+)
+
+// Annotation describes '@tg' annotation: where it is applicable and which value it takes.
+type Annotation struct {
+	Name      string
+	Levels    Level
+	Kind      Kind
+	Values    []string
+	Separator string
+	Splitter  string
+	// Format is a human-readable format of value, for example '<package>:<function>'.
+	Format  string
+	Default string
+	// Deprecated is a hint for replacement, annotation is deprecated, when it is set.
+	Deprecated string
+	Doc        string
+	pattern    *regexp.Regexp
+}
+
+// Names of annotations.
+const (
+	Log                 = "log"
+	Trace               = "trace"
+	Metrics             = "metrics"
+	Tests               = "tests"
+	HTTPServer          = "http-server"
+	JsonRPCServer       = "jsonRPC-server"
+	JsonRPCWebSocket    = "jsonRPC-ws"
+	Notify              = "notify"
+	GRPCServer          = "grpc-server"
+	ClientWithCB        = "clientWithCB"
+	NoOmitEmpty         = "tagNoOmitempty"
+	HTTPPrefix          = "http-prefix"
+	Desc                = "desc"
+	Summary             = "summary"
+	Deprecated          = "deprecated"
+	SwaggerTags         = "swaggerTags"
+	HTTPMethod          = "http-method"
+	HTTPPath            = "http-path"
+	HTTPSuccess         = "http-success"
+	HTTPArgs            = "http-args"
+	HTTPHeaders         = "http-headers"
+	HTTPCookies         = "http-cookies"
+	HTTPResponse        = "http-response"
+	Handler             = "handler"
+	LogSkip             = "log-skip"
+	EnableInlineSingle  = "enableInlineSingle"
+	RequestContentType  = "requestContentType"
+	ResponseContentType = "responseContentType"
+	PackageJSON         = "packageJSON"
+	PackageUUID         = "uuidPackage"
+	DefaultError        = "defaultError"
+	Title               = "title"
+	Version             = "version"
+	Servers             = "servers"
+	Security            = "security"
+	Author              = "author"
+	License             = "license"
+	NameNPM             = "npmName"
+	PrivateNPM          = "npmPrivate"
+	RegistryNPM         = "npmRegistry"
+	Type                = "type"
+	Format              = "format"
+	Example             = "example"
+	Enums               = "enums"
+	Required            = "required"
+	Nullable            = "nullable"
+	Tags                = "tags"
+)
+
+// statusCode is a name of annotations, which describe responses by HTTP status codes, for example '404=skip'.
+const statusCode = "<code>"
+
+var responseFormat = regexp.MustCompile(`^(skip|[^:\s]+:[^:\s]+)?$`)
+var qualFormat = regexp.MustCompile(`^[^:\s]+:[^:\s]+$`)
+var importFormat = regexp.MustCompile(`^[\w.~-]+(/[\w.~-]+)*$`)
+var statusFormat = regexp.MustCompile(`^[1-5][0-9][0-9]$`)
+
+var registry = map[string]Annotation{}
+
+func init() {
+
+	const (
+		contract  = LevelPackage | LevelInterface | LevelMethod
+		service   = LevelPackage | LevelInterface
+		field     = LevelType | LevelVariable
+		operation = LevelInterface | LevelMethod
+	)
+	annotations := []Annotation{
+		{Name: Log, Levels: service, Kind: KindFlag, Doc: "Generates middleware logging requests of service."},
+		{Name: Trace, Levels: service, Kind: KindFlag, Doc: "Generates middleware tracing requests of service by OpenTelemetry."},
+		{Name: Metrics, Levels: service, Kind: KindFlag, Doc: "Generates middleware collecting Prometheus metrics of service."},
+		{Name: Tests, Levels: service, Kind: KindFlag, Doc: "Generates tests of service."},
+		{Name: HTTPServer, Levels: service, Kind: KindFlag, Doc: "Serves methods of service by HTTP (REST)."},
+		{Name: JsonRPCServer, Levels: service, Kind: KindFlag, Doc: "Serves methods of service by JSON-RPC 2.0."},
+		{Name: JsonRPCWebSocket, Levels: LevelPackage, Kind: KindPath, Doc: "URL path of WebSocket endpoint, which serves JSON-RPC requests of all services."},
+		{Name: Notify, Levels: LevelMethod, Kind: KindFlag, Doc: "Generates client helpers, which call JSON-RPC method as notification without waiting for response."},
+		{Name: GRPCServer, Levels: service, Kind: KindFlag, Doc: "Serves methods of service by gRPC, protobuf contract is derived from methods."},
+		{Name: ClientWithCB, Levels: service, Kind: KindFlag, Doc: "Generates methods of JSON-RPC Go client with callbacks."},
+		{Name: NoOmitEmpty, Levels: service, Kind: KindFlag, Doc: "Disables 'omitempty' in JSON tags of exchange structs."},
+		{Name: HTTPPrefix, Levels: service, Kind: KindPath, Doc: "Prefix of URL path of methods."},
+		{Name: Desc, Levels: contract | field, Kind: KindString, Doc: "Description for documentation, it wins over doc comment."},
+		{Name: Summary, Levels: LevelMethod, Kind: KindString, Doc: "Summary of operation in OpenAPI."},
+		{Name: Deprecated, Levels: operation, Kind: KindFlag, Doc: "Marks operation as deprecated in OpenAPI."},
+		{Name: SwaggerTags, Levels: contract, Kind: KindList, Separator: ",", Doc: "Tags grouping operations in OpenAPI, name of interface by default."},
+		{Name: HTTPMethod, Levels: contract, Kind: KindEnum, Values: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}, Default: "POST", Doc: "HTTP method of method, it makes method REST one."},
+		{Name: HTTPPath, Levels: operation, Kind: KindPath, Doc: "URL path of method or JSON-RPC batch endpoint of interface, placeholders ':name' are mapped to arguments."},
+		{Name: HTTPSuccess, Levels: contract, Kind: KindInt, Format: "<status code>", Default: "200", Doc: "HTTP status code of successful response.", pattern: statusFormat},
+		{Name: HTTPArgs, Levels: LevelMethod, Kind: KindMap, Separator: ",", Splitter: "|", Format: "<argument>|<query parameter>,...", Doc: "Maps query parameters of URL to arguments."},
+		{Name: HTTPHeaders, Levels: LevelMethod, Kind: KindMap, Separator: ",", Splitter: "|", Format: "<variable>|<header>,...", Doc: "Maps HTTP headers to arguments and results."},
+		{Name: HTTPCookies, Levels: LevelMethod, Kind: KindMap, Separator: ",", Splitter: "|", Format: "<variable>|<cookie>,...", Doc: "Maps cookies to arguments and results."},
+		{Name: HTTPResponse, Levels: LevelMethod, Kind: KindString, Format: "<package>:<function>", Doc: "Function, which sends response of REST method instead of generated code.", pattern: qualFormat},
+		{Name: Handler, Levels: LevelMethod, Kind: KindString, Format: "<package>:<function>", Doc: "Custom handler of REST method, it replaces generated handler completely.", pattern: qualFormat},
+		{Name: LogSkip, Levels: LevelMethod, Kind: KindList, Separator: ",", Doc: "Variables excluded from log."},
+		{Name: EnableInlineSingle, Levels: contract, Kind: KindFlag, Doc: "Single result of method is sent without wrapping object."},
+		{Name: RequestContentType, Levels: contract, Kind: KindString, Default: "application/json", Doc: "Content type of request body in OpenAPI."},
+		{Name: ResponseContentType, Levels: contract, Kind: KindString, Default: "application/json", Doc: "Content type of response body in OpenAPI."},
+		{Name: PackageJSON, Levels: contract, Kind: KindString, Format: "<import path>", Default: "encoding/json", Doc: "Package of JSON codec in generated code.", pattern: importFormat},
+		{Name: PackageUUID, Levels: contract, Kind: KindString, Format: "<import path>", Default: "github.com/google/uuid", Doc: "Package of UUID type in generated code.", pattern: importFormat},
+		{Name: DefaultError, Levels: contract, Kind: KindString, Format: "skip|<package>:<type>", Doc: "Default error response in OpenAPI.", pattern: responseFormat},
+		{Name: statusCode, Levels: contract, Kind: KindString, Format: "skip|<package>:<type>", Doc: "Response of HTTP status code in OpenAPI, for example '404=skip'.", pattern: responseFormat},
+		{Name: Title, Levels: LevelPackage, Kind: KindString, Doc: "Title of OpenAPI document."},
+		{Name: Version, Levels: LevelPackage, Kind: KindString, Doc: "Version of API in OpenAPI document and npm package."},
+		{Name: Servers, Levels: LevelPackage, Kind: KindList, Separator: "|", Format: "<url>[;<description>]|...", Doc: "Servers of OpenAPI document."},
+		{Name: Security, Levels: LevelPackage, Kind: KindList, Separator: "|", Values: []string{"bearer"}, Doc: "Security schemes of OpenAPI document."},
+		{Name: Author, Levels: LevelPackage, Kind: KindString, Doc: "Author of npm package."},
+		{Name: License, Levels: LevelPackage, Kind: KindString, Doc: "License of npm package."},
+		{Name: NameNPM, Levels: LevelPackage, Kind: KindString, Doc: "Name of npm package."},
+		{Name: PrivateNPM, Levels: LevelPackage, Kind: KindBool, Default: "false", Doc: "Marks npm package as private."},
+		{Name: RegistryNPM, Levels: LevelPackage, Kind: KindString, Doc: "Registry of npm package."},
+		{Name: Type, Levels: field, Kind: KindString, Doc: "Type of field in OpenAPI."},
+		{Name: Format, Levels: field, Kind: KindString, Doc: "Format of field in OpenAPI, for example 'uuid'."},
+		{Name: Example, Levels: field, Kind: KindString, Doc: "Example of field value in OpenAPI."},
+		{Name: Enums, Levels: field, Kind: KindList, Separator: ",", Doc: "Possible values of field in OpenAPI."},
+		{Name: Required, Levels: field, Kind: KindFlag, Doc: "Marks field as required in OpenAPI."},
+		{Name: Nullable, Levels: field, Kind: KindBool, Default: "false", Doc: "Marks field as optional in TypeScript client."},
+		{Name: Tags, Levels: LevelVariable, Kind: KindMap, Separator: "|", Splitter: ":", Format: "<tag>:<value>|...", Doc: "Struct tags of generated field, for example 'json:id,omitempty|dumper:hide'."},
+	}
+	for _, annotation := range annotations {
+		registry[annotation.Name] = annotation
+	}
+}
+
+// Lookup returns declaration of annotation by name. Names of HTTP status codes are resolved to '<code>' annotation.
+func Lookup(name string) (annotation Annotation, found bool) {
+
+	if annotation, found = registry[name]; found {
+		return
+	}
+	if code, err := strconv.Atoi(name); err == nil && code >= 100 && code <= 599 {
+		return registry[statusCode], true
+	}
+	return
+}
+
+// Annotations returns declarations of all annotations sorted by name.
+func Annotations() (annotations []Annotation) {
+
+	for _, annotation := range registry {
+		annotations = append(annotations, annotation)
+	}
+	sort.Slice(annotations, func(i, j int) bool { return annotations[i].Name < annotations[j].Name })
+	return
+}
@@ -0,0 +1,204 @@
+package tags
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/seniorGolang/tg/v2/pkg/utils"
+)
+
+// Rules of problems, they are stable identifiers for diagnostics.
+const (
+	RuleUnknown    = "unknown-annotation"
+	RuleValue      = "annotation-value"
+	RuleLevel      = "annotation-level"
+	RuleDeprecated = "deprecated-annotation"
+)
+
+// Problem is a violation of annotations schema. Only invalid values are errors,
+// unknown annotations may be used by plugins.
+type Problem struct {
+	Name    string
+	Rule    string
+	Message string
+	Error   bool
+}
+
+// Validate checks annotations declared at level against registry. On method level annotations of variables
+// are written as '<variable>.<name>', vars are names of arguments and results of method.
+func Validate(docTags DocTags, level Level, vars ...string) (problems []Problem) {
+
+	names := make([]string, 0, len(docTags))
+	for name := range docTags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		annotationLevel, annotationName := level, name
+		if varName, suffix, found := strings.Cut(name, "."); found && level == LevelMethod {
+			if len(vars) != 0 && !slices.ContainsFunc(vars, func(v string) bool { return v == varName || utils.ToLowerCamel(v) == varName }) {
+				problems = append(problems, Problem{Name: name, Rule: RuleUnknown, Message: fmt.Sprintf("annotation '%s' refers to unknown variable '%s'%s", name, varName, didYouMean(varName, vars))})
+				continue
+			}
+			annotationLevel, annotationName = LevelVariable, suffix
+		}
+		annotation, found := Lookup(annotationName)
+		if !found {
+			problems = append(problems, Problem{Name: name, Rule: RuleUnknown, Message: fmt.Sprintf("unknown annotation '%s'%s", name, didYouMean(annotationName, annotationNames(annotationLevel)))})
+			continue
+		}
+		if annotation.Levels&annotationLevel == 0 {
+			problems = append(problems, Problem{Name: name, Rule: RuleLevel, Message: fmt.Sprintf("annotation '%s' is not applicable to %s, it is applicable to %s", name, annotationLevel, annotation.Levels)})
+			continue
+		}
+		if annotation.Deprecated != "" {
+			problems = append(problems, Problem{Name: name, Rule: RuleDeprecated, Message: fmt.Sprintf("annotation '%s' is deprecated: %s", name, annotation.Deprecated)})
+		}
+		if annotation.Kind == KindFlag && docTags[name] != "" {
+			problems = append(problems, Problem{Name: name, Rule: RuleValue, Message: fmt.Sprintf("annotation '%s' takes no value, '%s' is ignored", name, docTags[name])})
+			continue
+		}
+		if err := annotation.CheckValue(docTags[name]); err != nil {
+			problems = append(problems, Problem{Name: name, Rule: RuleValue, Message: fmt.Sprintf("annotation '%s': %v", name, err), Error: true})
+		}
+	}
+	return
+}
+
+// CheckValue checks value of annotation by its kind.
+func (annotation Annotation) CheckValue(value string) (err error) {
+
+	if annotation.Kind == KindFlag {
+		return
+	}
+	if value == "" {
+		if annotation.pattern != nil && annotation.pattern.MatchString(value) {
+			return
+		}
+		return fmt.Errorf("value is required%s", annotation.expected())
+	}
+	switch annotation.Kind {
+	case KindBool:
+		if _, err = strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("'%s' is not a boolean", value)
+		}
+	case KindInt:
+		if _, err = strconv.Atoi(value); err != nil {
+			return fmt.Errorf("'%s' is not an integer%s", value, annotation.expected())
+		}
+	case KindEnum:
+		if !annotation.allowed(value) {
+			return fmt.Errorf("'%s' is not one of %s", value, strings.Join(annotation.Values, ", "))
+		}
+	case KindPath:
+		if strings.ContainsFunc(value, unicode.IsSpace) || strings.ContainsAny(value, "?#") {
+			return fmt.Errorf("'%s' is not URL path", value)
+		}
+		for _, token := range strings.Split(value, "/") {
+			if token == ":" {
+				return fmt.Errorf("placeholder of path '%s' has no name", value)
+			}
+		}
+	case KindList:
+		for _, item := range strings.Split(value, annotation.Separator) {
+			if item = strings.TrimSpace(item); item == "" {
+				return fmt.Errorf("empty item in '%s'%s", value, annotation.expected())
+			}
+			if len(annotation.Values) != 0 && !annotation.allowed(item) {
+				return fmt.Errorf("'%s' is not one of %s", item, strings.Join(annotation.Values, ", "))
+			}
+		}
+	case KindMap:
+		for _, pair := range strings.Split(value, annotation.Separator) {
+			if key, val, found := strings.Cut(pair, annotation.Splitter); !found || strings.TrimSpace(key) == "" || strings.TrimSpace(val) == "" {
+				return fmt.Errorf("'%s' is not a pair%s", pair, annotation.expected())
+			}
+		}
+	}
+	if annotation.pattern != nil && !annotation.pattern.MatchString(value) {
+		return fmt.Errorf("'%s' has invalid format%s", value, annotation.expected())
+	}
+	return
+}
+
+func (annotation Annotation) allowed(value string) bool {
+
+	for _, allowed := range annotation.Values {
+		if strings.EqualFold(allowed, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func (annotation Annotation) expected() string {
+
+	if annotation.Format == "" {
+		return ""
+	}
+	return fmt.Sprintf(", expected '%s'", annotation.Format)
+}
+
+// annotationNames returns names of annotations applicable to level.
+func annotationNames(level Level) (names []string) {
+
+	for name, annotation := range registry {
+		if name != statusCode && annotation.Levels&level != 0 {
+			names = append(names, name)
+		}
+	}
+	return
+}
+
+func didYouMean(name string, candidates []string) string {
+
+	if suggestion := Suggest(name, candidates); suggestion != "" {
+		return fmt.Sprintf(", did you mean '%s'?", suggestion)
+	}
+	return ""
+}
+
+// Suggest returns the closest to name candidate, which differs from it by typo. Case is ignored.
+func Suggest(name string, candidates []string) (suggestion string) {
+
+	best := len(name)/3 + 1
+	for _, candidate := range candidates {
+		distance := editDistance(strings.ToLower(name), strings.ToLower(candidate))
+		if distance < best || (distance == best && suggestion != "" && candidate < suggestion) {
+			best, suggestion = distance, candidate
+		}
+	}
+	return
+}
+
+// editDistance returns count of insertions, deletions, substitutions and transpositions of adjacent characters,
+// which turn a into b.
+func editDistance(a, b string) int {
+
+	ra, rb := []rune(a), []rune(b)
+	d := make([][]int, len(ra)+1)
+	for i := range d {
+		d[i] = make([]int, len(rb)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(ra)][len(rb)]
+}
@@ -12,6 +12,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/pkg/errors"
 
@@ -41,7 +42,7 @@ func GetPkgPath(fName string, isDir bool) (string, error) {
 }
 
 var (
-	goModPathCache = make(map[string]string)
+	goModPathCache sync.Map
 )
 
 func GoModPath(fName string, isDir bool) (string, error) {
@@ -52,14 +53,13 @@ func GoModPath(fName string, isDir bool) (string, error) {
 		root = filepath.Dir(fName)
 	}
 
-	goModPath, ok := goModPathCache[root]
-
-	if ok {
-		return goModPath, nil
+	if cached, ok := goModPathCache.Load(root); ok {
+		return cached.(string), nil
 	}
 
+	var goModPath string
 	defer func() {
-		goModPathCache[root] = goModPath
+		goModPathCache.Store(root, goModPath)
 	}()
 
 	var stdout []byte
@@ -108,19 +108,17 @@ func GetPkgPathFromGoMod(fName string, isDir bool, goModPath string) (string, er
 var (
 	gopathCache           = ""
 	modulePrefix          = []byte("\nmodule ")
-	pkgPathFromGoModCache = make(map[string]string)
+	pkgPathFromGoModCache sync.Map
 )
 
-func GetModulePath(goModPath string) string {
-
-	pkgPath, ok := pkgPathFromGoModCache[goModPath]
+func GetModulePath(goModPath string) (pkgPath string) {
 
-	if ok {
-		return pkgPath
+	if cached, ok := pkgPathFromGoModCache.Load(goModPath); ok {
+		return cached.(string)
 	}
 
 	defer func() {
-		pkgPathFromGoModCache[goModPath] = pkgPath
+		pkgPathFromGoModCache.Store(goModPath, pkgPath)
 	}()
 
 	data, err := os.ReadFile(goModPath)
@@ -0,0 +1,174 @@
+// Package watcher polls directories for changes of Go files.
+package watcher
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+// Watcher reports changed, created and removed '.go' files of directories (with subdirectories).
+// Changes are collected until there are no new changes for debounce interval.
+type Watcher struct {
+	interval   time.Duration
+	debounce   time.Duration
+	dirs       []string
+	files      map[string]fileState
+	changed    map[string]bool
+	lastChange time.Time
+	now        func() time.Time
+}
+
+func New(interval, debounce time.Duration) *Watcher {
+	return &Watcher{
+		interval: interval,
+		debounce: debounce,
+		files:    make(map[string]fileState),
+		changed:  make(map[string]bool),
+		now:      time.Now,
+	}
+}
+
+// SetDirs replaces watched directories. Known state of files of kept directories is not changed,
+// so changes made while previous ones are handled are reported by next poll. Files of added directories
+// are taken as they are now.
+func (w *Watcher) SetDirs(dirs ...string) {
+
+	known := make(map[string]bool, len(w.dirs))
+	for _, dir := range w.dirs {
+		known[dir] = true
+	}
+	w.dirs = w.dirs[:0]
+	var added []string
+	for _, dir := range dirs {
+		abs, err := filepath.Abs(dir)
+		if err != nil || slices.Contains(w.dirs, abs) {
+			continue
+		}
+		w.dirs = append(w.dirs, abs)
+		if !known[abs] {
+			added = append(added, abs)
+		}
+	}
+	sort.Strings(w.dirs)
+	for filePath := range w.files {
+		if !w.watched(filePath) {
+			delete(w.files, filePath)
+		}
+	}
+	for filePath, state := range scanDirs(added) {
+		if _, found := w.files[filePath]; !found {
+			w.files[filePath] = state
+		}
+	}
+}
+
+func (w *Watcher) Dirs() []string {
+	return w.dirs
+}
+
+// Watch calls onChange with sorted list of changed files until context is done.
+func (w *Watcher) Watch(ctx context.Context, onChange func(files []string)) error {
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		if files := w.Poll(); len(files) != 0 {
+			onChange(files)
+		}
+	}
+}
+
+// Poll scans directories once. It returns sorted list of files changed since previous report,
+// when there are no new changes for debounce interval, otherwise nothing.
+func (w *Watcher) Poll() (files []string) {
+
+	current := w.scan()
+	for _, file := range diff(w.files, current) {
+		w.changed[file] = true
+		w.lastChange = w.now()
+	}
+	w.files = current
+	if len(w.changed) == 0 || w.now().Sub(w.lastChange) < w.debounce {
+		return nil
+	}
+	files = make([]string, 0, len(w.changed))
+	for file := range w.changed {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	w.changed = make(map[string]bool)
+	return files
+}
+
+func (w *Watcher) watched(filePath string) bool {
+
+	for _, dir := range w.dirs {
+		if strings.HasPrefix(filePath, dir+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *Watcher) scan() map[string]fileState {
+	return scanDirs(w.dirs)
+}
+
+func scanDirs(dirs []string) (files map[string]fileState) {
+
+	files = make(map[string]fileState)
+	for _, dir := range dirs {
+		_ = filepath.WalkDir(dir, func(filePath string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if entry.IsDir() {
+				if filePath != dir && (strings.HasPrefix(entry.Name(), ".") || entry.Name() == "vendor") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !strings.HasSuffix(entry.Name(), ".go") {
+				return nil
+			}
+			if info, infoErr := entry.Info(); infoErr == nil {
+				files[filePath] = fileState{size: info.Size(), modTime: info.ModTime()}
+			}
+			return nil
+		})
+	}
+	return
+}
+
+func diff(before, after map[string]fileState) (changed []string) {
+
+	for filePath, state := range after {
+		if prev, found := before[filePath]; !found || prev != state {
+			changed = append(changed, filePath)
+		}
+	}
+	for filePath := range before {
+		if _, found := after[filePath]; !found {
+			if _, err := os.Stat(filePath); os.IsNotExist(err) {
+				changed = append(changed, filePath)
+			}
+		}
+	}
+	return
+}
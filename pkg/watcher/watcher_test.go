@@ -0,0 +1,112 @@
+package watcher
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, filePath, content string) {
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0777); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filePath, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// newWatcher returns watcher of dirs with clock, which is moved by returned function.
+func newWatcher(dirs ...string) (w *Watcher, advance func(time.Duration)) {
+
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	w = New(time.Second, time.Second)
+	w.now = func() time.Time { return clock }
+	w.SetDirs(dirs...)
+	return w, func(d time.Duration) { clock = clock.Add(d) }
+}
+
+func TestPoll(t *testing.T) {
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "service", "user.go"), "package service\n")
+	writeFile(t, filepath.Join(dir, "service", "old.go"), "package service\n")
+	writeFile(t, filepath.Join(dir, "dto", "user.go"), "package dto\n")
+	w, advance := newWatcher(filepath.Join(dir, "service"), filepath.Join(dir, "dto"))
+	if dirs := w.Dirs(); len(dirs) != 2 || dirs[0] != filepath.Join(dir, "dto") {
+		t.Fatalf("unexpected dirs: %v", dirs)
+	}
+
+	writeFile(t, filepath.Join(dir, "service", "user.go"), "package service\n\ntype User struct{}\n")
+	writeFile(t, filepath.Join(dir, "service", "sub", "new.go"), "package sub\n")
+	writeFile(t, filepath.Join(dir, "service", "notes.txt"), "notes")
+	writeFile(t, filepath.Join(dir, "service", "vendor", "lib.go"), "package lib\n")
+	writeFile(t, filepath.Join(dir, "service", ".cache", "tmp.go"), "package tmp\n")
+	writeFile(t, filepath.Join(dir, "other", "other.go"), "package other\n")
+	if err := os.Remove(filepath.Join(dir, "service", "old.go")); err != nil {
+		t.Fatal(err)
+	}
+	if files := w.Poll(); len(files) != 0 {
+		t.Fatalf("changes are reported before debounce interval: %v", files)
+	}
+	advance(500 * time.Millisecond)
+	writeFile(t, filepath.Join(dir, "dto", "user.go"), "package dto\n\ntype User struct{}\n")
+	if files := w.Poll(); len(files) != 0 {
+		t.Fatalf("change within debounce interval does not postpone report: %v", files)
+	}
+	advance(time.Second)
+	expected := []string{
+		filepath.Join(dir, "dto", "user.go"),
+		filepath.Join(dir, "service", "old.go"),
+		filepath.Join(dir, "service", "sub", "new.go"),
+		filepath.Join(dir, "service", "user.go"),
+	}
+	if files := w.Poll(); !slices.Equal(files, expected) {
+		t.Fatalf("unexpected changes:\n%v\nexpected:\n%v", files, expected)
+	}
+	if files := w.Poll(); len(files) != 0 {
+		t.Fatalf("changes are reported twice: %v", files)
+	}
+}
+
+func TestSetDirsKeepsState(t *testing.T) {
+
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "service", "user.go"), "package service\n")
+	writeFile(t, filepath.Join(dir, "dto", "user.go"), "package dto\n")
+	writeFile(t, filepath.Join(dir, "legacy", "user.go"), "package legacy\n")
+	w, advance := newWatcher(filepath.Join(dir, "service"), filepath.Join(dir, "legacy"))
+
+	// files are changed while changes are handled, then set of directories is updated
+	writeFile(t, filepath.Join(dir, "service", "user.go"), "package service\n\ntype User struct{}\n")
+	w.SetDirs(filepath.Join(dir, "service"), filepath.Join(dir, "dto"))
+	advance(time.Second)
+	if files := w.Poll(); len(files) != 0 {
+		t.Fatal("changes are reported before debounce interval")
+	}
+	advance(time.Second)
+	if files := w.Poll(); !slices.Equal(files, []string{filepath.Join(dir, "service", "user.go")}) {
+		t.Fatalf("change made before update of directories is lost: %v", files)
+	}
+
+	writeFile(t, filepath.Join(dir, "dto", "user.go"), "package dto\n\ntype User struct{}\n")
+	writeFile(t, filepath.Join(dir, "legacy", "user.go"), "package legacy\n\ntype User struct{}\n")
+	w.Poll()
+	advance(time.Second)
+	if files := w.Poll(); !slices.Equal(files, []string{filepath.Join(dir, "dto", "user.go")}) {
+		t.Fatalf("unexpected changes of added and removed directories: %v", files)
+	}
+}
+
+func TestWatchCanceled(t *testing.T) {
+
+	w, _ := newWatcher(t.TempDir())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := w.Watch(ctx, func([]string) { t.Fatal("no changes are expected") }); err != context.Canceled {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}